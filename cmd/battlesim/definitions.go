@@ -0,0 +1,179 @@
+//go:build headless
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/myrpg/cmd/myrpg/game/entities"
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/skills"
+)
+
+// UnitDefinition describes one combatant of the simulated battles
+type UnitDefinition struct {
+	Name     string   `json:"name"`               // Display name
+	Job      string   `json:"job"`                // Job ID or name from the job data file
+	Level    int      `json:"level"`              // Character level
+	BackRow  bool     `json:"back_row,omitempty"` // Classic battle row preference (party only)
+	Strategy string   `json:"strategy,omitempty"` // Classic battle targeting strategy
+	Skills   []string `json:"skills,omitempty"`   // Learned skill IDs, prerequisites first
+
+	Affinities map[string]string `json:"affinities,omitempty"` // Element to affinity, e.g. "fire": "weak"
+}
+
+// Definitions holds the party and the enemy group fought in every simulated battle
+type Definitions struct {
+	Party   []UnitDefinition `json:"party"`
+	Enemies []UnitDefinition `json:"enemies"`
+}
+
+// defaultDefinitions returns the starting party against the goblin encounter
+func defaultDefinitions() *Definitions {
+	return &Definitions{
+		Party: []UnitDefinition{
+			{Name: "Conan", Job: "warrior", Level: 3},
+			{Name: "Gandalf", Job: "mage", Level: 2, BackRow: true},
+			{Name: "Robin", Job: "rogue", Level: 4},
+		},
+		Enemies: []UnitDefinition{
+			{Name: "Goblin Scout", Job: "rogue", Level: 2},
+			{Name: "Goblin Warrior", Job: "warrior", Level: 3},
+			{Name: "Goblin Archer", Job: "archer", Level: 2},
+		},
+	}
+}
+
+// loadDefinitions reads party and enemy definitions from a JSON file
+func loadDefinitions(path string) (*Definitions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definitions: %v", err)
+	}
+
+	defs := &Definitions{}
+	if err := json.Unmarshal(data, defs); err != nil {
+		return nil, fmt.Errorf("failed to parse definitions: %v", err)
+	}
+	if err := defs.validate(); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// validate checks that both sides have valid units
+func (d *Definitions) validate() error {
+	if len(d.Party) == 0 || len(d.Enemies) == 0 {
+		return fmt.Errorf("definitions need at least one party member and one enemy")
+	}
+	// Tactical battles line each side up in a single grid column
+	if len(d.Party) > constants.GridHeight || len(d.Enemies) > constants.GridHeight {
+		return fmt.Errorf("at most %d units per side", constants.GridHeight)
+	}
+	for _, def := range append(append([]UnitDefinition{}, d.Party...), d.Enemies...) {
+		if def.Name == "" {
+			return fmt.Errorf("unit without a name")
+		}
+		if def.Level <= 0 {
+			return fmt.Errorf("unit %s has invalid level %d", def.Name, def.Level)
+		}
+		if _, err := components.ParseJobType(def.Job); err != nil {
+			return fmt.Errorf("unit %s: %v", def.Name, err)
+		}
+		if def.Strategy != "" {
+			if _, err := components.ParseTargetStrategy(def.Strategy); err != nil {
+				return fmt.Errorf("unit %s: %v", def.Name, err)
+			}
+		}
+		if _, err := components.ParseElementProfile(def.Affinities); err != nil {
+			return fmt.Errorf("unit %s: %v", def.Name, err)
+		}
+	}
+	return nil
+}
+
+// build creates fresh party and enemy entities for one battle
+func (d *Definitions) build() ([]*ecs.Entity, []*ecs.Entity, error) {
+	party := make([]*ecs.Entity, 0, len(d.Party))
+	for _, def := range d.Party {
+		unit, err := buildUnit(def, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		party = append(party, unit)
+	}
+
+	enemies := make([]*ecs.Entity, 0, len(d.Enemies))
+	for _, def := range d.Enemies {
+		unit, err := buildUnit(def, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		enemies = append(enemies, unit)
+	}
+
+	return party, enemies, nil
+}
+
+// buildUnit creates a combatant without sprites, so no window is needed
+func buildUnit(def UnitDefinition, enemy bool) (*ecs.Entity, error) {
+	job, err := components.ParseJobType(def.Job)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := components.NewRPGStatsComponent(def.Name, job, def.Level)
+	stats.BackRow = def.BackRow
+	if stats.ElementAffinities, err = components.ParseElementProfile(def.Affinities); err != nil {
+		return nil, err
+	}
+
+	unit := ecs.NewEntity(def.Name)
+	unit.AddComponent(ecs.ComponentTransform, components.NewTransform(0, 0, 32, 32))
+	unit.AddComponent(ecs.ComponentRPGStats, stats)
+
+	// Enemies use the game's job-based AI unless the definition picks a strategy
+	ai := components.NewBattleAIComponent(components.TargetRandom)
+	if enemy {
+		ai = entities.NewEnemyBattleAI(job)
+		unit.AddTag(ecs.TagEnemy)
+	} else {
+		unit.AddTag(ecs.TagPlayer)
+	}
+	if def.Strategy != "" {
+		ai.Strategy, _ = components.ParseTargetStrategy(def.Strategy)
+	}
+	unit.AddComponent(ecs.ComponentBattleAI, ai)
+
+	if len(def.Skills) > 0 {
+		if err := learnSkills(unit, job, def.Skills); err != nil {
+			return nil, fmt.Errorf("unit %s: %v", def.Name, err)
+		}
+	}
+
+	return unit, nil
+}
+
+// learnSkills gives a unit a skills component with the given skills learned in order
+func learnSkills(unit *ecs.Entity, job components.JobType, skillIDs []string) error {
+	skillsComp := components.NewSkillsComponent(job)
+	unit.AddComponent(ecs.ComponentSkills, skillsComp)
+	registry := skills.GetGlobalSkillRegistry()
+
+	for _, skillID := range skillIDs {
+		skill, exists := registry.GetSkill(skillID)
+		if !exists {
+			return fmt.Errorf("unknown skill: %s", skillID)
+		}
+		skillsComp.AddSkillPoints(skill.SkillPoints)
+		if err := skills.LearnSkill(unit, skill); err != nil {
+			return fmt.Errorf("%v (missing prerequisites?)", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+//go:build headless
+
+// Command battlesim runs thousands of seeded battles without a window to help
+// balance jobs and encounters. The AI plays both sides using the classic and
+// tactical battle managers, and the results are reported as CSV or JSON.
+//
+// The simulator is built with the headless tag, which leaves out the ebiten
+// renderers so it runs on machines without a display.
+//
+// Usage:
+//
+//	go run -tags headless ./cmd/battlesim -battles 1000 -seed 42 -system both -format csv
+//	go run -tags headless ./cmd/battlesim -defs party.json -format json -out report.json
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/skills"
+)
+
+func main() {
+	system := flag.String("system", "both", "battle system to simulate: classic, tactical or both")
+	battles := flag.Int("battles", 1000, "number of battles per battle system")
+	seed := flag.Int64("seed", 1, "base random seed, battle i uses seed+i")
+	jobsPath := flag.String("jobs", constants.JobDataFile, "job definitions file")
+	skillsPath := flag.String("skills", constants.SkillDataFile, "skill trees file")
+	defsPath := flag.String("defs", "", "JSON file with party and enemy definitions (default: goblin encounter)")
+	format := flag.String("format", "csv", "report format: csv or json")
+	outPath := flag.String("out", "", "report file (default: stdout)")
+	maxSeconds := flag.Int("max-seconds", defaultClassicMaxSeconds, "classic battle time limit in seconds")
+	maxRounds := flag.Int("max-rounds", defaultTacticalMaxRounds, "tactical battle round limit")
+	enableLog := flag.Bool("log", false, "write battle logs to the log file")
+	flag.Parse()
+
+	if *enableLog {
+		if err := logger.Init(); err != nil {
+			log.Fatal("failed to initialize logger:", err)
+		}
+		defer logger.Close()
+	}
+
+	if *battles <= 0 {
+		log.Fatal("battles must be positive")
+	}
+	if *format != "csv" && *format != "json" {
+		log.Fatalf("unknown format: %s", *format)
+	}
+	if *system != "classic" && *system != "tactical" && *system != "both" {
+		log.Fatalf("unknown battle system: %s", *system)
+	}
+
+	if err := components.LoadJobRegistry(*jobsPath); err != nil {
+		log.Fatal(err)
+	}
+	if err := skills.LoadSkillRegistry(*skillsPath); err != nil {
+		log.Fatal(err)
+	}
+
+	defs := defaultDefinitions()
+	if *defsPath != "" {
+		loaded, err := loadDefinitions(*defsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defs = loaded
+	}
+
+	opts := Options{
+		Battles:    *battles,
+		Seed:       *seed,
+		MaxSeconds: *maxSeconds,
+		MaxRounds:  *maxRounds,
+	}
+
+	reports := make([]*Report, 0, 2)
+	if *system == "classic" || *system == "both" {
+		results, err := runClassicBattles(defs, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, buildReport("classic", len(defs.Party), results))
+	}
+	if *system == "tactical" || *system == "both" {
+		results, err := runTacticalBattles(defs, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, buildReport("tactical", len(defs.Party), results))
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("failed to create report file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	var err error
+	if *format == "json" {
+		err = writeJSON(out, reports)
+	} else {
+		err = writeCSV(out, reports)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Simulated %d battles per system\n", *battles)
+}
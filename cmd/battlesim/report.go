@@ -0,0 +1,145 @@
+//go:build headless
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// JobDamage is the damage dealt by one job on one side over all battles
+type JobDamage struct {
+	Side      string  `json:"side"`
+	Job       string  `json:"job"`
+	Total     int     `json:"total"`
+	PerBattle float64 `json:"per_battle"`
+}
+
+// SurvivalBucket counts the battles that ended with a given number of living party members
+type SurvivalBucket struct {
+	Survivors int     `json:"survivors"`
+	Battles   int     `json:"battles"`
+	Percent   float64 `json:"percent"`
+}
+
+// Report summarizes the battles simulated with one battle system
+type Report struct {
+	System        string           `json:"system"`
+	Battles       int              `json:"battles"`
+	Wins          int              `json:"wins"`
+	WinRate       float64          `json:"win_rate"`
+	Timeouts      int              `json:"timeouts"`
+	AverageRounds float64          `json:"average_rounds"`
+	DamagePerJob  []JobDamage      `json:"damage_per_job"`
+	Survival      []SurvivalBucket `json:"survival"`
+}
+
+// buildReport aggregates the results of a battle system
+func buildReport(system string, partySize int, results []*BattleResult) *Report {
+	report := &Report{
+		System:       system,
+		Battles:      len(results),
+		DamagePerJob: make([]JobDamage, 0),
+		Survival:     make([]SurvivalBucket, 0, partySize+1),
+	}
+	if len(results) == 0 {
+		return report
+	}
+
+	rounds := 0
+	damage := make(map[jobKey]int)
+	survival := make([]int, partySize+1)
+	for _, result := range results {
+		if result.Victory {
+			report.Wins++
+		}
+		if result.Timeout {
+			report.Timeouts++
+		}
+		rounds += result.Rounds
+		survival[result.Survivors]++
+		for key, amount := range result.Damage {
+			damage[key] += amount
+		}
+	}
+
+	battles := float64(len(results))
+	report.WinRate = float64(report.Wins) / battles
+	report.AverageRounds = float64(rounds) / battles
+
+	for key, total := range damage {
+		report.DamagePerJob = append(report.DamagePerJob, JobDamage{
+			Side:      key.Side,
+			Job:       key.Job,
+			Total:     total,
+			PerBattle: float64(total) / battles,
+		})
+	}
+	sort.Slice(report.DamagePerJob, func(i, j int) bool {
+		a, b := report.DamagePerJob[i], report.DamagePerJob[j]
+		if a.Side != b.Side {
+			return a.Side > b.Side // party first
+		}
+		return a.Job < b.Job
+	})
+
+	for survivors, count := range survival {
+		report.Survival = append(report.Survival, SurvivalBucket{
+			Survivors: survivors,
+			Battles:   count,
+			Percent:   float64(count) * 100 / battles,
+		})
+	}
+
+	return report
+}
+
+// writeJSON writes the reports as an indented JSON array
+func writeJSON(w io.Writer, reports []*Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(reports)
+}
+
+// writeCSV writes the reports as system,section,key,value rows
+func writeCSV(w io.Writer, reports []*Report) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"system", "section", "key", "value"}}
+
+	for _, report := range reports {
+		summary := func(key, value string) {
+			rows = append(rows, []string{report.System, "summary", key, value})
+		}
+		summary("battles", strconv.Itoa(report.Battles))
+		summary("wins", strconv.Itoa(report.Wins))
+		summary("win_rate", formatFloat(report.WinRate))
+		summary("timeouts", strconv.Itoa(report.Timeouts))
+		summary("average_rounds", formatFloat(report.AverageRounds))
+
+		for _, damage := range report.DamagePerJob {
+			key := fmt.Sprintf("%s/%s", damage.Side, damage.Job)
+			rows = append(rows,
+				[]string{report.System, "damage_total", key, strconv.Itoa(damage.Total)},
+				[]string{report.System, "damage_per_battle", key, formatFloat(damage.PerBattle)})
+		}
+
+		for _, bucket := range report.Survival {
+			rows = append(rows, []string{report.System, "survival_percent",
+				strconv.Itoa(bucket.Survivors), formatFloat(bucket.Percent)})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write csv: %v", err)
+	}
+	return nil
+}
+
+// formatFloat formats report values with a fixed precision
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
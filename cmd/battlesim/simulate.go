@@ -0,0 +1,249 @@
+//go:build headless
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/battle/classic"
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/tactical"
+)
+
+const (
+	classicTimeStep          = 100 * time.Millisecond // Battle time advanced by each classic update
+	tacticalMaxUpdates       = 100000                 // Safety cap on tactical updates per battle
+	sideParty                = "party"
+	sideEnemies              = "enemies"
+	defaultClassicMaxSeconds = 600
+	defaultTacticalMaxRounds = 50
+)
+
+// Options configures the battles run by the simulator
+type Options struct {
+	Battles    int   // Number of battles per system
+	Seed       int64 // Base seed, battle i uses Seed+i
+	MaxSeconds int   // Classic battle time limit in seconds
+	MaxRounds  int   // Tactical round limit
+}
+
+// jobKey identifies the damage dealt by one job on one side
+type jobKey struct {
+	Side string
+	Job  string
+}
+
+// BattleResult holds the outcome of one simulated battle
+type BattleResult struct {
+	Victory   bool           // The party won
+	Timeout   bool           // The battle hit the time or round limit
+	Rounds    int            // Rounds fought
+	Survivors int            // Living party members at the end
+	Damage    map[jobKey]int // Damage dealt by side and job
+}
+
+// newBattleResult creates an empty battle result
+func newBattleResult() *BattleResult {
+	return &BattleResult{
+		Damage: make(map[jobKey]int),
+	}
+}
+
+// addDamage records damage dealt by an attacker
+func (br *BattleResult) addDamage(attacker *ecs.Entity, side string, damage int) {
+	if damage <= 0 || attacker == nil {
+		return
+	}
+	if stats := attacker.RPGStats(); stats != nil {
+		br.Damage[jobKey{Side: side, Job: stats.Job.String()}] += damage
+	}
+}
+
+// sideOf returns the report side of an entity
+func sideOf(entity *ecs.Entity) string {
+	if entity.HasTag(ecs.TagEnemy) {
+		return sideEnemies
+	}
+	return sideParty
+}
+
+// countSurvivors returns the number of living party members
+func countSurvivors(party []*ecs.Entity) int {
+	survivors := 0
+	for _, member := range party {
+		if stats := member.RPGStats(); stats != nil && stats.IsAlive() {
+			survivors++
+		}
+	}
+	return survivors
+}
+
+// runClassicBattles simulates classic battles with the AI playing both sides
+func runClassicBattles(defs *Definitions, opts Options) ([]*BattleResult, error) {
+	results := make([]*BattleResult, 0, opts.Battles)
+	for i := 0; i < opts.Battles; i++ {
+		result, err := runClassicBattle(defs, opts, opts.Seed+int64(i))
+		if err != nil {
+			return nil, fmt.Errorf("classic battle %d: %v", i+1, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runClassicBattle simulates a single seeded classic battle
+func runClassicBattle(defs *Definitions, opts Options, seed int64) (*BattleResult, error) {
+	party, enemies, err := defs.build()
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBattleResult()
+	rounds := newRoundCounter(append(append([]*ecs.Entity{}, party...), enemies...))
+
+	manager := classic.NewBattleManager()
+	manager.SetRNG(rand.New(rand.NewSource(seed)))
+	manager.SetAutoBattle(true)
+	manager.SetOnActionExecuted(func(action *classic.BattleAction) {
+		rounds.acted(action.Entity)
+		for _, target := range action.Results {
+			result.addDamage(action.Entity, sideOf(action.Entity), target.Damage)
+		}
+	})
+
+	if err := manager.StartBattle(party, enemies); err != nil {
+		return nil, err
+	}
+
+	limit := time.Duration(opts.MaxSeconds) * time.Second
+	for elapsed := time.Duration(0); !manager.IsShowingResult(); elapsed += classicTimeStep {
+		if elapsed >= limit {
+			result.Timeout = true
+			break
+		}
+		manager.Update(classicTimeStep)
+	}
+
+	result.Rounds = rounds.total()
+	result.Victory = manager.GetState() == classic.BattleStateVictory
+	result.Survivors = countSurvivors(party)
+	return result, nil
+}
+
+// roundCounter counts the rounds of a classic battle, where every entity acts on its own
+// ATB gauge: a round ends once every living combatant has acted since the round started
+type roundCounter struct {
+	combatants []*ecs.Entity
+	actedThis  map[*ecs.Entity]bool
+	completed  int
+}
+
+// newRoundCounter creates a round counter for the given combatants
+func newRoundCounter(combatants []*ecs.Entity) *roundCounter {
+	return &roundCounter{
+		combatants: combatants,
+		actedThis:  make(map[*ecs.Entity]bool),
+	}
+}
+
+// acted records a turn of an entity, closing the round when every living combatant has acted
+func (rc *roundCounter) acted(entity *ecs.Entity) {
+	rc.actedThis[entity] = true
+	for _, combatant := range rc.combatants {
+		if stats := combatant.RPGStats(); stats != nil && stats.IsAlive() && !rc.actedThis[combatant] {
+			return
+		}
+	}
+	rc.completed++
+	rc.actedThis = make(map[*ecs.Entity]bool)
+}
+
+// total returns the rounds fought, counting a round cut short by the end of the battle
+func (rc *roundCounter) total() int {
+	if len(rc.actedThis) > 0 {
+		return rc.completed + 1
+	}
+	return rc.completed
+}
+
+// runTacticalBattles simulates tactical battles with the AI playing both sides
+func runTacticalBattles(defs *Definitions, opts Options) ([]*BattleResult, error) {
+	results := make([]*BattleResult, 0, opts.Battles)
+	for i := 0; i < opts.Battles; i++ {
+		result, err := runTacticalBattle(defs, opts, opts.Seed+int64(i))
+		if err != nil {
+			return nil, fmt.Errorf("tactical battle %d: %v", i+1, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runTacticalBattle simulates a single seeded tactical battle on a fresh grid
+func runTacticalBattle(defs *Definitions, opts Options, seed int64) (*BattleResult, error) {
+	party, enemies, err := defs.build()
+	if err != nil {
+		return nil, err
+	}
+
+	// Party on the left edge, enemies on the right edge
+	placeColumn(party, 1)
+	placeColumn(enemies, constants.GridWidth-2)
+
+	grid := tactical.NewGrid(constants.GridWidth, constants.GridHeight, constants.TileSize)
+	manager := tactical.NewTurnBasedCombatManager(grid)
+	manager.DebugMode = false
+	manager.SetRNG(rand.New(rand.NewSource(seed)))
+	manager.SetAutoBattle(true)
+
+	units := append(append([]*ecs.Entity{}, party...), enemies...)
+	if err := manager.InitializeCombat(units); err != nil {
+		return nil, err
+	}
+	for _, unit := range units {
+		manager.SetAIProfile(unit, tactical.AIProfileAggressive)
+	}
+
+	result := newBattleResult()
+	for updates := 0; manager.IsActive; updates++ {
+		if manager.GetCurrentRound() > opts.MaxRounds || updates >= tacticalMaxUpdates {
+			result.Timeout = true
+			break
+		}
+
+		// Attribute the damage of the attack about to be executed
+		action := manager.PendingAction
+		hpBefore := 0
+		attacking := manager.Phase == tactical.CombatPhaseActionExecution && action != nil &&
+			action.Type == tactical.ActionAttack && action.Target != nil
+		if attacking {
+			hpBefore = action.Target.RPGStats().CurrentHP
+		}
+
+		if err := manager.Update(); err != nil {
+			return nil, err
+		}
+
+		if attacking {
+			result.addDamage(action.Actor, sideOf(action.Actor), hpBefore-action.Target.RPGStats().CurrentHP)
+		}
+	}
+
+	result.Rounds = manager.GetCurrentRound()
+	result.Victory = manager.GetResult() == tactical.CombatResultPlayerVictory
+	result.Survivors = countSurvivors(party)
+	return result, nil
+}
+
+// placeColumn positions units down a grid column, centered vertically
+func placeColumn(units []*ecs.Entity, column int) {
+	firstRow := (constants.GridHeight - len(units)) / 2
+	for i, unit := range units {
+		transform := unit.Transform()
+		transform.X = float64(column*constants.TileSize) + constants.GridOffsetX
+		transform.Y = float64((firstRow+i)*constants.TileSize) + constants.GridOffsetY
+	}
+}
@@ -47,11 +47,44 @@ func CreateEnemy(x, y float64) *ecs.Entity {
 	enemy.AddComponent(ecs.ComponentSprite, components.NewSpriteComponent(enemySprite, 1.0, 0, 0))
 	enemy.AddComponent(ecs.ComponentCollider, components.NewColliderComponent(true, 32, 32, 0, 0))
 	enemy.AddComponent(ecs.ComponentRPGStats, components.NewRPGStatsComponent("Goblin", components.JobWarrior, 5)) // Level 5 warrior for stronger enemy
+	enemy.AddComponent(ecs.ComponentLoot, NewEnemyLoot(5))
+	enemy.AddComponent(ecs.ComponentBattleAI, NewEnemyBattleAI(components.JobWarrior))
 	enemy.AddTag(ecs.TagEnemy)
 
 	return enemy
 }
 
+// NewEnemyLoot creates the default enemy drop table, with gold scaling by level
+func NewEnemyLoot(level int) *components.LootComponent {
+	return components.NewLootComponent(level*3,
+		components.NewDropEntry(200, 50, 1, 2), // Health Potion
+		components.NewDropEntry(210, 25, 1, 1), // Mana Potion
+		components.NewDropEntry(301, 15, 1, 1), // Magic Crystal
+		components.NewDropEntry(302, 30, 1, 2), // Iron Ore
+		components.NewDropEntry(303, 35, 1, 3), // Healing Herb
+		components.NewDropEntry(220, 10, 1, 1), // Fire Bomb
+		components.NewDropEntry(1, 5, 1, 1),    // Iron Sword
+		components.NewDropEntry(11, 2, 1, 1),   // Flame Sword
+		components.NewDropEntry(230, 3, 1, 1),  // Tome of Forgetting
+	)
+}
+
+// NewEnemyBattleAI creates the default enemy battle AI, with a targeting strategy that suits the job
+func NewEnemyBattleAI(job components.JobType) *components.BattleAIComponent {
+	switch job {
+	case components.JobWarrior:
+		return components.NewBattleAIComponent(components.TargetFrontRow)
+	case components.JobRogue:
+		return components.NewBattleAIComponent(components.TargetLowestHP)
+	case components.JobMage:
+		return components.NewBattleAIComponent(components.TargetHealerFirst)
+	case components.JobArcher:
+		return components.NewBattleAIComponent(components.TargetHighestThreat)
+	default:
+		return components.NewBattleAIComponent(components.TargetRandom)
+	}
+}
+
 // CreatePlayerAtPosition creates a player entity at the specified position with a custom name
 func CreatePlayerAtPosition(name string, x, y float64) *ecs.Entity {
 	// Load player sprite
@@ -106,6 +139,8 @@ func CreateEnemyWithJob(name string, x, y float64, job components.JobType, level
 	enemy.AddComponent(ecs.ComponentSprite, components.NewSpriteComponent(enemySprite, 1.0, 0, 0))
 	enemy.AddComponent(ecs.ComponentCollider, components.NewColliderComponent(true, 32, 32, 0, 0))
 	enemy.AddComponent(ecs.ComponentRPGStats, components.NewRPGStatsComponent(name, job, level))
+	enemy.AddComponent(ecs.ComponentLoot, NewEnemyLoot(level))
+	enemy.AddComponent(ecs.ComponentBattleAI, NewEnemyBattleAI(job))
 	enemy.AddTag(ecs.TagEnemy)
 
 	return enemy
@@ -255,6 +290,8 @@ func CreateAnimatedEnemyWithJob(name string, x, y float64, job components.JobTyp
 	enemy.AddComponent(ecs.ComponentTransform, components.NewTransform(x, y, 32, 32))
 	enemy.AddComponent(ecs.ComponentCollider, components.NewColliderComponent(true, 32, 32, 0, 0))
 	enemy.AddComponent(ecs.ComponentRPGStats, components.NewRPGStatsComponent(name, job, level))
+	enemy.AddComponent(ecs.ComponentLoot, NewEnemyLoot(level))
+	enemy.AddComponent(ecs.ComponentBattleAI, NewEnemyBattleAI(job))
 	enemy.AddTag(ecs.TagEnemy)
 
 	// Load sprite sheet and create animations
@@ -0,0 +1,84 @@
+// Package entities provides the enemy definitions used by battle events
+package entities
+
+import (
+	"log"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// EnemyDefinition describes an enemy type that battle events refer to by ID
+type EnemyDefinition struct {
+	Name       string                    // Display name
+	Job        components.JobType        // Job used for stats and AI
+	Level      int                       // Enemy level
+	Strategy   string                    // Classic battle targeting strategy (empty = job default)
+	Affinities components.ElementProfile // Elemental weaknesses, resistances, immunities and absorbs
+}
+
+// enemyDefinitions holds the enemies battle events can spawn, by ID
+var enemyDefinitions = map[string]EnemyDefinition{
+	"goblin_scout": {
+		Name: "Goblin Scout", Job: components.JobRogue, Level: 5,
+		Affinities: components.ElementProfile{components.ElementFire: components.AffinityWeak},
+	},
+	"goblin_warrior": {
+		Name: "Goblin Warrior", Job: components.JobWarrior, Level: 5,
+		Affinities: components.ElementProfile{components.ElementFire: components.AffinityWeak},
+	},
+	"goblin_archer": {
+		Name: "Goblin Archer", Job: components.JobArcher, Level: 5, Strategy: "lowest_hp",
+		Affinities: components.ElementProfile{
+			components.ElementFire:   components.AffinityWeak,
+			components.ElementPoison: components.AffinityResist,
+		},
+	},
+	"orc_warrior": {
+		Name: "Orc Warrior", Job: components.JobWarrior, Level: 6,
+		Affinities: components.ElementProfile{
+			components.ElementIce:    components.AffinityResist,
+			components.ElementPoison: components.AffinityImmune,
+			components.ElementHoly:   components.AffinityWeak,
+		},
+	},
+	"orc_shaman": {
+		Name: "Orc Shaman", Job: components.JobMage, Level: 6, Strategy: "highest_threat",
+		Affinities: components.ElementProfile{
+			components.ElementDark: components.AffinityAbsorb,
+			components.ElementHoly: components.AffinityWeak,
+		},
+	},
+}
+
+// GetEnemyDefinition returns the definition of an enemy ID, false if the ID is unknown
+func GetEnemyDefinition(id string) (EnemyDefinition, bool) {
+	def, exists := enemyDefinitions[id]
+	return def, exists
+}
+
+// CreateEnemyFromDefinition creates the enemy with the given ID, falling back to the
+// default enemy for unknown IDs
+func CreateEnemyFromDefinition(id string, x, y float64) *ecs.Entity {
+	def, exists := GetEnemyDefinition(id)
+	if !exists {
+		return CreateEnemy(x, y)
+	}
+
+	enemy := CreateEnemyWithJob(def.Name, x, y, def.Job, def.Level)
+	stats := enemy.RPGStats()
+	for element, affinity := range def.Affinities {
+		stats.SetElementAffinity(element, affinity)
+	}
+
+	// The definition strategy replaces the job default
+	if def.Strategy != "" {
+		strategy, err := components.ParseTargetStrategy(def.Strategy)
+		if err != nil {
+			log.Printf("Enemy %s: %v. Using the %s default.", id, err, def.Job)
+		} else {
+			enemy.BattleAI().Strategy = strategy
+		}
+	}
+	return enemy
+}
@@ -70,7 +70,31 @@ func CreateDialogEvent(id, name string, x, y float64, npcID, dialogID string) *e
 	return CreateEventEntity(id, name, x, y, eventComp)
 }
 
-// CreateChestEvent creates a chest event entity
+// CreateJobChangeEvent creates a job shrine event entity where the party changes jobs
+func CreateJobChangeEvent(id, name string, x, y float64) *ecs.Entity {
+	eventComp := components.NewEventComponent(id, name, components.TriggerOnTouch, components.EventJobChange)
+	eventComp.SetEventData(components.EventData{
+		Title: name,
+	})
+	eventComp.SetRepeatable(true)                             // Shrines can be visited any number of times
+	eventComp.SetActiveInMode(components.GameModeExploration) // Only active in exploration mode
+	return CreateEventEntity(id, name, x, y, eventComp)
+}
+
+// CreateShopEvent creates a merchant event entity that opens a shop from the shop registry
+func CreateShopEvent(id, name string, x, y float64, shopID string) *ecs.Entity {
+	eventComp := components.NewEventComponent(id, name, components.TriggerOnTouch, components.EventShop)
+	eventComp.SetEventData(components.EventData{
+		Title:  name,
+		ShopID: shopID,
+	})
+	eventComp.SetRepeatable(true)                             // Shops can be visited any number of times
+	eventComp.SetActiveInMode(components.GameModeExploration) // Only active in exploration mode
+	return CreateEventEntity(id, name, x, y, eventComp)
+}
+
+// CreateChestEvent creates a chest event entity. Item IDs are item registry IDs or item names
+// ("iron_sword"). Locked chests open with an Iron Key or a Rogue lockpick.
 func CreateChestEvent(id, name string, x, y float64, itemIDs []string, gold int, locked bool) *ecs.Entity {
 	eventComp := components.NewEventComponent(id, name, components.TriggerOnTouch, components.EventChest)
 	eventComp.SetEventData(components.EventData{
@@ -78,6 +102,7 @@ func CreateChestEvent(id, name string, x, y float64, itemIDs []string, gold int,
 		Gold:     gold,
 		IsLocked: locked,
 	})
+	eventComp.SetRepeatable(true)                             // Locked or partly looted chests can be retried, emptied chests are completed
 	eventComp.SetActiveInMode(components.GameModeExploration) // Only active in exploration mode
 	return CreateEventEntity(id, name, x, y, eventComp)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/engine"
 	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/skills"
 )
 
 func main() {
@@ -28,6 +29,14 @@ func main() {
 
 	logger.Info("Starting MyRPG Game")
 
+	// Load job definitions before any character is created
+	if err := components.LoadJobRegistry(constants.JobDataFile); err != nil {
+		log.Fatalf("Failed to load job definitions: %v", err)
+	}
+	if err := skills.LoadSkillRegistry(constants.SkillDataFile); err != nil {
+		log.Fatalf("Failed to load skill trees: %v", err)
+	}
+
 	// Create a new game instance
 	// Note: As of Go 1.20+, the global random generator is automatically seeded
 	game := engine.NewGame()
@@ -97,6 +106,11 @@ func main() {
 		[]string{"iron_sword", "health_potion"}, 50, false)
 	game.AddEntity(chestEvent)
 
+	lockedChestEvent := entities.CreateChestEvent("chest_locked", "Iron Chest",
+		constants.LockedChestX, constants.LockedChestY,
+		[]string{"flame_sword", "magic_crystal", "mana_potion"}, 120, true)
+	game.AddEntity(lockedChestEvent)
+
 	// Dialog event (NPC)
 	npcEvent := entities.CreateDialogEvent("npc_elder", "Village Elder",
 		constants.Enemy4StartX, constants.Enemy4StartY,
@@ -116,6 +130,16 @@ func main() {
 		"Spike Pit", 15)
 	game.AddEntity(trapEvent)
 
+	// Job shrine event
+	shrineEvent := entities.CreateJobChangeEvent("shrine_jobs", "Job Shrine",
+		constants.JobShrineX, constants.JobShrineY)
+	game.AddEntity(shrineEvent)
+
+	// Shop event
+	shopEvent := entities.CreateShopEvent("shop_village", "Village Store",
+		constants.ShopX, constants.ShopY, "village_store")
+	game.AddEntity(shopEvent)
+
 	// Configure attack animation duration (customizable)
 	game.SetAttackAnimationDuration(1500 * time.Millisecond) // 1.5 seconds for attack animation
 
@@ -0,0 +1,180 @@
+package classic
+
+import (
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// ATBMode selects whether battle time flows while the player chooses a command
+type ATBMode int
+
+const (
+	ATBModeWait   ATBMode = iota // Gauges pause while a menu is open
+	ATBModeActive                // Gauges keep filling and enemies keep acting while a menu is open
+)
+
+func (m ATBMode) String() string {
+	switch m {
+	case ATBModeWait:
+		return "Wait"
+	case ATBModeActive:
+		return "Active"
+	default:
+		return "Unknown"
+	}
+}
+
+// timeUntilReady returns the seconds of battle time the entry needs to fill its gauge
+func (ae *ActivityEntry) timeUntilReady() float64 {
+	if ae.Ready {
+		return 0
+	}
+	if ae.FillRate <= 0 {
+		return constants.ATBGaugeMax
+	}
+	return (constants.ATBGaugeMax - ae.Gauge) / ae.FillRate
+}
+
+// resetGauge empties the gauge after the entity acted
+func (ae *ActivityEntry) resetGauge() {
+	ae.Gauge = 0
+	ae.Ready = false
+}
+
+// SetATBMode selects Active or Wait battle time
+func (bm *BattleManager) SetATBMode(mode ATBMode) {
+	bm.atbMode = mode
+}
+
+// GetATBMode returns the current battle time mode
+func (bm *BattleManager) GetATBMode() ATBMode {
+	return bm.atbMode
+}
+
+// ToggleATBMode switches between Active and Wait battle time
+func (bm *BattleManager) ToggleATBMode() {
+	if bm.atbMode == ATBModeWait {
+		bm.atbMode = ATBModeActive
+	} else {
+		bm.atbMode = ATBModeWait
+	}
+	logger.Debug("⏱️  ATB mode: %s", bm.atbMode.String())
+}
+
+// SetBattleSpeed sets the gauge fill multiplier, clamped to the allowed range
+func (bm *BattleManager) SetBattleSpeed(multiplier float64) {
+	if multiplier < constants.BattleSpeedMin {
+		multiplier = constants.BattleSpeedMin
+	}
+	if multiplier > constants.BattleSpeedMax {
+		multiplier = constants.BattleSpeedMax
+	}
+	bm.battleSpeed = multiplier
+}
+
+// GetBattleSpeed returns the gauge fill multiplier
+func (bm *BattleManager) GetBattleSpeed() float64 {
+	return bm.battleSpeed
+}
+
+// ChangeBattleSpeed raises (direction > 0) or lowers the battle speed by one step
+func (bm *BattleManager) ChangeBattleSpeed(direction int) {
+	if direction > 0 {
+		bm.SetBattleSpeed(bm.battleSpeed + constants.BattleSpeedStep)
+	} else {
+		bm.SetBattleSpeed(bm.battleSpeed - constants.BattleSpeedStep)
+	}
+	logger.Debug("⏱️  Battle speed: x%.1f", bm.battleSpeed)
+}
+
+// GetGauge returns how full an entity's ATB gauge is, from 0 to 1
+func (bm *BattleManager) GetGauge(entity *ecs.Entity) float64 {
+	for _, entry := range bm.activityQueue {
+		if entry.Entity == entity {
+			return entry.Gauge / constants.ATBGaugeMax
+		}
+	}
+	return 0
+}
+
+// updateGauges advances battle time and fills every gauge by the entity's speed
+func (bm *BattleManager) updateGauges(deltaTime time.Duration) {
+	// Wait mode stops the clock while the player chooses
+	if bm.atbMode == ATBModeWait && bm.isWaitingForInput() {
+		return
+	}
+
+	elapsed := time.Duration(float64(deltaTime) * bm.battleSpeed)
+	bm.battleTime += elapsed
+
+	for _, entry := range bm.activityQueue {
+		if entry.Ready {
+			continue
+		}
+		entry.Gauge += entry.FillRate * elapsed.Seconds()
+		if entry.Gauge >= constants.ATBGaugeMax {
+			entry.Gauge = constants.ATBGaugeMax
+			entry.Ready = true
+			entry.ReadyAt = bm.battleTime
+			// A full gauge starts the entity's turn, counting down its ability cooldowns
+			if skills := entry.Entity.Skills(); skills != nil {
+				skills.TickCooldowns()
+			}
+		}
+	}
+}
+
+// refreshPlayerSelection keeps the player's selection valid while battle time flows.
+// A fallen player loses the turn and fallen targets are replaced.
+func (bm *BattleManager) refreshPlayerSelection() {
+	if bm.currentPlayerEntity == nil || !bm.isWaitingForInput() {
+		return
+	}
+
+	if stats := bm.currentPlayerEntity.RPGStats(); stats == nil || stats.CurrentHP <= 0 {
+		logger.Debug("💀 %s fell before choosing an action", bm.currentPlayerEntity.GetID())
+		bm.currentPlayerEntity = nil
+		bm.selectedTarget = nil
+		bm.selectedSpell = nil
+		bm.selectedItem = nil
+		bm.targetGroups = nil
+		bm.state = BattleStatePlayerTurn
+		return
+	}
+
+	if bm.state != BattleStateWaitingForTarget {
+		return
+	}
+
+	if len(bm.targetGroups) > 0 {
+		if len(livingEntities(bm.targetGroups[bm.targetIndex])) == len(bm.targetGroups[bm.targetIndex]) {
+			return
+		}
+		bm.targetGroups = bm.targetGroupsFor(bm.currentPlayerEntity, bm.selectedSpell)
+		if bm.targetIndex >= len(bm.targetGroups) {
+			bm.targetIndex = 0
+		}
+		if len(bm.targetGroups) == 0 {
+			bm.CancelSelection()
+		}
+		return
+	}
+
+	if bm.selectedTarget != nil {
+		if stats := bm.selectedTarget.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+			return
+		}
+	}
+	bm.updateAvailableTargets()
+	if len(bm.availableTargets) == 0 {
+		bm.CancelSelection()
+		return
+	}
+	if bm.targetIndex >= len(bm.availableTargets) {
+		bm.targetIndex = 0
+	}
+	bm.selectedTarget = bm.availableTargets[bm.targetIndex]
+}
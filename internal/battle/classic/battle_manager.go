@@ -4,12 +4,15 @@ package classic
 
 import (
 	"fmt"
+	"math/rand"
 	"sort"
 	"time"
 
+	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/systems"
 )
 
 // BattleState represents the current state of the battle
@@ -25,6 +28,8 @@ const (
 	BattleStateEscaped
 	BattleStateWaitingForPlayerAction // Waiting for player to select an action
 	BattleStateWaitingForTarget       // Waiting for player to select a target
+	BattleStateWaitingForSpell        // Waiting for player to select a spell
+	BattleStateWaitingForItem         // Waiting for player to select an item
 )
 
 // BattleAction represents an action in the battle queue
@@ -33,11 +38,41 @@ type BattleAction struct {
 	ActionType ActionType
 	Target     *ecs.Entity
 	Speed      int
-	Timestamp  time.Time
+	Timestamp  time.Duration    // Battle time when the action was queued
+	Targets    []*ecs.Entity    // All targets of a multi-target action (Target is the first one)
+	Spell      *Spell           // Spell cast by a Magic action (nil = basic magic)
+	Item       *components.Item // Item used by an Item action
 	// Results populated after execution
-	DamageDealt   int
-	TargetHPAfter int
-	TargetMaxHP   int
+	Results       []TargetResult // Result for every target hit by the action
+	DamageDealt   int            // Damage dealt to the first target
+	TargetHPAfter int            // HP of the first target after the action
+	TargetMaxHP   int            // Maximum HP of the first target
+	Succeeded     bool           // Whether an Escape action succeeded
+	Failure       string         // Why the action failed, empty on success
+}
+
+// TargetResult records what an action did to one of its targets
+type TargetResult struct {
+	Target   *ecs.Entity
+	Damage   int                        // Damage dealt
+	Healed   int                        // HP restored
+	HPAfter  int                        // Target HP after the action
+	MaxHP    int                        // Target maximum HP
+	Affinity components.ElementAffinity // Target affinity to the element of the damage
+}
+
+// recordFirstResult copies the first target result into the single-target fields
+func (ba *BattleAction) recordFirstResult() {
+	if len(ba.Results) == 0 {
+		return
+	}
+	first := ba.Results[0]
+	if ba.Target == nil {
+		ba.Target = first.Target
+	}
+	ba.DamageDealt = first.Damage
+	ba.TargetHPAfter = first.HPAfter
+	ba.TargetMaxHP = first.MaxHP
 }
 
 // ActionType represents different types of battle actions
@@ -49,14 +84,17 @@ const (
 	ActionItem
 	ActionDefend
 	ActionEscape
+	ActionRowSwap
 )
 
 // ActivityEntry represents an entity's position in the activity queue
 type ActivityEntry struct {
-	Entity       *ecs.Entity
-	NextActionAt time.Time
-	Speed        int
-	ActionDelay  time.Duration
+	Entity   *ecs.Entity
+	Speed    int
+	Gauge    float64       // ATB gauge, the entity can act at constants.ATBGaugeMax
+	FillRate float64       // Gauge points filled per second of battle time
+	Ready    bool          // Whether the gauge is full and the entity waits for its turn
+	ReadyAt  time.Duration // Battle time when the gauge filled, ready entities act in this order
 }
 
 // BattleManager manages the Dragon Quest-style battle system
@@ -77,25 +115,45 @@ type BattleManager struct {
 	playerFormation *Formation
 	enemyFormation  *Formation
 
-	// Battle timing
-	battleTime    time.Time
-	speedModifier float64
+	// Battle timing, driven by the game time passed to Update
+	battleTime  time.Duration // Battle time elapsed since the battle started
+	atbMode     ATBMode       // Whether gauges keep filling while the player chooses
+	battleSpeed float64       // Gauge fill speed multiplier
 
 	// Player turn management
-	currentPlayerEntity *ecs.Entity     // Player whose turn it is
-	selectedAction      ActionType      // Action the player selected
-	selectedTarget      *ecs.Entity     // Target the player selected
-	targetIndex         int             // Index for target selection navigation
-	availableTargets    []*ecs.Entity   // Available targets for current action
-	isDefending         map[string]bool // Track which entities are defending
+	currentPlayerEntity *ecs.Entity      // Player whose turn it is
+	selectedAction      ActionType       // Action the player selected
+	selectedTarget      *ecs.Entity      // Target the player selected
+	targetIndex         int              // Index for target selection navigation
+	availableTargets    []*ecs.Entity    // Available targets for current action
+	targetGroups        [][]*ecs.Entity  // Selectable groups for multi-target spells
+	isDefending         map[string]bool  // Track which entities are defending
+	selectedSpell       *Spell           // Spell the player selected for Magic
+	selectedItem        *components.Item // Item the player selected for Item
+	spellList           []*Spell         // Spells available to the current player
+	itemList            []*BattleItem    // Consumables available to the current player
+	listIndex           int              // Cursor position in the spell or item list
+
+	// Commands
+	bossBattle  bool                       // Escaping is refused in boss battles
+	autoBattle  bool                       // The AI also chooses the party's actions
+	rng         *rand.Rand                 // Random source for escape rolls and enemy AI
+	threat      map[*ecs.Entity]int        // Damage dealt by each participant this battle
+	consumables *systems.ConsumableManager // Applies item effects
 
 	// Debug tracking for target flashing issue
 	lastSelectedTargetID string    // Track last selected target to detect rapid changes
 	lastTargetChangeTime time.Time // Track timing to prevent rapid changes
 
+	// Victory rewards
+	rewards       *systems.BattleRewards
+	rewardHandler func(playerParty, enemyParty []*ecs.Entity) *systems.BattleRewards
+
 	// Callbacks
 	onBattleEnd      func(victory bool)
+	onEscape         func()
 	onActionExecuted func(action *BattleAction)
+	onMessage        func(message string)
 }
 
 // NewBattleManager creates a new Dragon Quest-style battle manager
@@ -107,13 +165,16 @@ func NewBattleManager() *BattleManager {
 		enemyParty:           make([]*ecs.Entity, 0),
 		activityQueue:        make([]*ActivityEntry, 0),
 		actionQueue:          make([]*BattleAction, 0),
-		speedModifier:        1000.0, // milliseconds per speed point
-		battleTime:           time.Now(),
+		atbMode:              ATBModeWait,
+		battleSpeed:          constants.DefaultBattleSpeed,
 		targetIndex:          0,
 		availableTargets:     make([]*ecs.Entity, 0),
 		isDefending:          make(map[string]bool),
 		lastSelectedTargetID: "",          // Initialize target tracking
 		lastTargetChangeTime: time.Time{}, // Initialize timing
+		rng:                  rand.New(rand.NewSource(time.Now().UnixNano())),
+		consumables:          systems.NewConsumableManager(),
+		threat:               make(map[*ecs.Entity]int),
 	}
 }
 
@@ -130,7 +191,22 @@ func (bm *BattleManager) StartBattle(playerParty, enemyParty []*ecs.Entity) erro
 	bm.enemyParty = enemyParty
 	bm.battleStarted = true
 	bm.state = BattleStatePlayerTurn
-	bm.battleTime = time.Now()
+	bm.battleTime = 0
+	bm.currentPlayerEntity = nil
+	bm.rewards = nil
+	bm.actionQueue = make([]*BattleAction, 0)
+	bm.isDefending = make(map[string]bool)
+	bm.threat = make(map[*ecs.Entity]int)
+	bm.selectedSpell = nil
+	bm.selectedItem = nil
+
+	// Effective stats include the latest equipment and skill modifiers, abilities start ready
+	for _, entity := range append(append([]*ecs.Entity{}, playerParty...), enemyParty...) {
+		entity.RefreshStatModifiers()
+		if skills := entity.Skills(); skills != nil {
+			skills.ResetCooldowns()
+		}
+	}
 
 	// Initialize formations
 	bm.playerFormation = NewPlayerFormation(playerParty)
@@ -173,19 +249,19 @@ func (bm *BattleManager) initializeActivityQueue() {
 	for _, entity := range allParticipants {
 		if stats := entity.RPGStats(); stats != nil {
 			speed := bm.calculateEntitySpeed(entity)
-			delay := time.Duration(bm.speedModifier/float64(speed)) * time.Millisecond
 
+			// Gauges start partly filled so the first turns are not all at once
 			entry := &ActivityEntry{
-				Entity:       entity,
-				NextActionAt: bm.battleTime.Add(delay),
-				Speed:        speed,
-				ActionDelay:  delay,
+				Entity:   entity,
+				Speed:    speed,
+				Gauge:    bm.rng.Float64() * constants.ATBStartGaugeMax,
+				FillRate: float64(speed) * constants.ATBFillPerSpeed,
 			}
 
 			bm.activityQueue = append(bm.activityQueue, entry)
 
-			logger.Debug("   Added to queue: %s (speed: %d, delay: %v)",
-				entity.GetID(), speed, delay)
+			logger.Debug("   Added to queue: %s (speed: %d, gauge: %.0f)",
+				entity.GetID(), speed, entry.Gauge)
 		}
 	}
 
@@ -200,6 +276,11 @@ func (bm *BattleManager) calculateEntitySpeed(entity *ecs.Entity) int {
 		return 10 // default speed
 	}
 
+	// The Speed stat fills the ATB gauge; the job formula covers entities without one
+	if speed := stats.GetStat(components.StatSpeed); speed > 0 {
+		return speed
+	}
+
 	baseSpeed := 10
 
 	// Job-based speed modifiers
@@ -228,10 +309,17 @@ func (bm *BattleManager) calculateEntitySpeed(entity *ecs.Entity) int {
 	return finalSpeed
 }
 
-// sortActivityQueue sorts the queue by next action time
+// sortActivityQueue sorts the queue by turn order: ready entities first, then by time until ready
 func (bm *BattleManager) sortActivityQueue() {
-	sort.Slice(bm.activityQueue, func(i, j int) bool {
-		return bm.activityQueue[i].NextActionAt.Before(bm.activityQueue[j].NextActionAt)
+	sort.SliceStable(bm.activityQueue, func(i, j int) bool {
+		a, b := bm.activityQueue[i], bm.activityQueue[j]
+		if a.Ready != b.Ready {
+			return a.Ready
+		}
+		if a.Ready {
+			return a.ReadyAt < b.ReadyAt
+		}
+		return a.timeUntilReady() < b.timeUntilReady()
 	})
 }
 
@@ -241,7 +329,13 @@ func (bm *BattleManager) Update(deltaTime time.Duration) {
 		return
 	}
 
-	bm.battleTime = bm.battleTime.Add(deltaTime)
+	// Nobody acts once the battle result is shown
+	if bm.IsShowingResult() {
+		return
+	}
+
+	// Fill the ATB gauges with the elapsed game time
+	bm.updateGauges(deltaTime)
 
 	// Check if any entities are ready to act
 	bm.processActivityQueue()
@@ -249,73 +343,75 @@ func (bm *BattleManager) Update(deltaTime time.Duration) {
 	// Execute queued actions
 	bm.processActionQueue()
 
+	// In Active mode the chosen target may fall while the player is still choosing
+	bm.refreshPlayerSelection()
+
 	// Check for battle end conditions
 	bm.checkBattleEndConditions()
 }
 
-// processActivityQueue checks for entities ready to act
+// processActivityQueue gives a turn to the entities whose gauge is full
 func (bm *BattleManager) processActivityQueue() {
-	// Don't process queue while waiting for player input
-	if bm.state == BattleStateWaitingForPlayerAction || bm.state == BattleStateWaitingForTarget {
+	// In Wait mode nobody acts while the player chooses
+	if bm.atbMode == ATBModeWait && bm.isWaitingForInput() {
 		return
 	}
 
-	for len(bm.activityQueue) > 0 {
-		entry := bm.activityQueue[0]
+	bm.sortActivityQueue()
+	ready := make([]*ActivityEntry, 0)
+	for _, entry := range bm.activityQueue {
+		if !entry.Ready {
+			break // Everyone else is still filling
+		}
+		ready = append(ready, entry)
+	}
 
-		if bm.battleTime.Before(entry.NextActionAt) {
-			break // No one is ready to act yet
+	for _, entry := range ready {
+		if bm.isPlayerEntity(entry.Entity) && !bm.autoBattle {
+			// Only one party member chooses at a time, the others keep their full gauge.
+			// The gauge is emptied once the player action is queued.
+			if bm.isWaitingForInput() {
+				continue
+			}
+			bm.scheduleEntityAction(entry)
+			if bm.atbMode == ATBModeWait {
+				break
+			}
+			continue
 		}
 
-		// This entity is ready to act
 		bm.scheduleEntityAction(entry)
-
-		// For player entities, don't update timing until action is completed
-		// For enemy entities, update timing immediately
-		if !bm.isPlayerEntity(entry.Entity) {
-			// Update the entity's next action time
-			entry.NextActionAt = bm.battleTime.Add(entry.ActionDelay)
-
-			// Re-sort the queue
-			bm.sortActivityQueue()
-		} else {
-			// For player entities, we'll update timing after action completion
-			// Break here so we don't process more queue entries during player turn
-			break
-		}
+		entry.resetGauge()
 	}
+
+	bm.sortActivityQueue()
 }
 
 // scheduleEntityAction determines what action an entity will take
 func (bm *BattleManager) scheduleEntityAction(entry *ActivityEntry) {
 	entity := entry.Entity
 
-	// Determine if this is a player or enemy
-	isPlayer := bm.isPlayerEntity(entity)
+	// Determine if this is a player or enemy; auto battles let the AI play both sides
+	isPlayer := bm.isPlayerEntity(entity) && !bm.autoBattle
 
 	if isPlayer {
 		// Set up player turn - wait for player input
 		bm.currentPlayerEntity = entity
 		bm.state = BattleStateWaitingForPlayerAction
 		bm.selectedAction = ActionAttack // Default selection
+		bm.selectedSpell = nil
+		bm.selectedItem = nil
 		bm.targetIndex = 0
 		bm.updateAvailableTargets()
 
 		logger.Debug("🎯 Player %s turn - waiting for action selection", entity.GetID())
 	} else {
-		// Enemy AI - simple attack for now
-		target := bm.selectRandomTarget(bm.playerParty)
-		if target != nil {
-			action := &BattleAction{
-				Entity:     entity,
-				ActionType: ActionAttack,
-				Target:     target,
-				Speed:      entry.Speed,
-				Timestamp:  bm.battleTime,
-			}
+		// AI picks an action and a target from its battle AI settings
+		action := bm.decideAIAction(entity, entry)
+		if action != nil {
 			bm.actionQueue = append(bm.actionQueue, action)
 
-			logger.Debug("👹 Enemy %s scheduled attack on %s", entity.GetID(), target.GetID())
+			logger.Debug("👹 %s scheduled action %d", entity.GetID(), action.ActionType)
 		}
 	}
 }
@@ -331,6 +427,11 @@ func (bm *BattleManager) processActionQueue() {
 		if bm.onActionExecuted != nil {
 			bm.onActionExecuted(action)
 		}
+
+		// A successful escape ends the battle, the rest of the queue is dropped
+		if bm.state == BattleStateEscaped {
+			return
+		}
 	}
 }
 
@@ -340,62 +441,125 @@ func (bm *BattleManager) executeAction(action *BattleAction) {
 	case ActionAttack:
 		bm.executeAttackAction(action, false)
 	case ActionMagic:
-		bm.executeAttackAction(action, true)
+		if action.Spell != nil {
+			bm.executeSpellAction(action)
+		} else {
+			bm.executeAttackAction(action, true)
+		}
 	case ActionDefend:
 		bm.executeDefendAction(action)
+	case ActionItem:
+		bm.executeItemAction(action)
+	case ActionEscape:
+		bm.executeEscapeAction(action)
+	case ActionRowSwap:
+		bm.executeRowSwapAction(action)
 	default:
 		logger.Debug("⚠️  Unknown action type: %v", action.ActionType)
 	}
 }
 
-// executeAttackAction performs physical or magical attacks
+// executeAttackAction performs physical or magical attacks on every target of the action
 func (bm *BattleManager) executeAttackAction(action *BattleAction, isMagical bool) {
-	if action.Target == nil {
-		return
+	targets := action.Targets
+	if len(targets) == 0 && action.Target != nil {
+		targets = []*ecs.Entity{action.Target}
 	}
 
+	for _, target := range targets {
+		// Group attacks skip targets that fell earlier in the same action
+		if stats := target.RPGStats(); len(targets) > 1 && (stats == nil || stats.CurrentHP <= 0) {
+			continue
+		}
+		bm.applyAttack(action, target, isMagical)
+	}
+
+	action.recordFirstResult()
+}
+
+// applyAttack deals the damage of an attack or spell to a single target
+func (bm *BattleManager) applyAttack(action *BattleAction, target *ecs.Entity, isMagical bool) {
 	actionName := "attacks"
 	if isMagical {
 		actionName = "casts magic on"
 	}
 
-	logger.Debug("⚔️  Executing action: %s %s %s", action.Entity.GetID(), actionName, action.Target.GetID())
+	logger.Debug("⚔️  Executing action: %s %s %s", action.Entity.GetID(), actionName, target.GetID())
 
 	attacker := action.Entity.RPGStats()
-	defender := action.Target.RPGStats()
+	defender := target.RPGStats()
 
 	if attacker == nil || defender == nil {
 		return
 	}
 
+	// Spells use their own element, physical attacks and weapon skills the weapon element
+	element := components.ElementNone
+	if !isMagical {
+		element = action.Entity.WeaponElement()
+	} else if action.Spell != nil {
+		element = action.Spell.Element
+	}
+
 	// Basic damage formula - different for physical vs magical
 	var damage int
 	if isMagical {
 		// Magic damage based on level and potentially different stats
 		damage = attacker.Level*7 + 15 // Slightly higher base damage
+		if action.Spell != nil {
+			damage += action.Spell.Power
+		}
+		damage += attacker.GetStat(components.StatMagicAttack) - defender.GetStat(components.StatMagicDefense)
 	} else {
 		// Physical damage
 		damage = attacker.Level*5 + 10
+		damage += attacker.GetStat(components.StatAttack) - defender.GetStat(components.StatDefense)
+		if action.Spell != nil && action.Spell.Scale > 0 {
+			damage = int(float64(damage) * action.Spell.Scale)
+		}
+
+		// Melee attacks are weaker from and against the back row
+		if !isRangedAttacker(action.Entity) {
+			if bm.isInBackRow(action.Entity) {
+				damage = damage * constants.BackRowMeleeDamagePercent / 100
+			}
+			if bm.isInBackRow(target) {
+				damage = damage * constants.BackRowMeleeDamagePercent / 100
+			}
+		}
+	}
+
+	if damage < 1 {
+		damage = 1
 	}
 
 	// Check if defender is defending (50% damage reduction)
-	if bm.isDefending[action.Target.GetID()] {
+	if bm.isDefending[target.GetID()] {
 		damage = damage / 2
-		logger.Debug("🛡️ %s is defending! Damage reduced to %d", action.Target.GetID(), damage)
+		logger.Debug("🛡️ %s is defending! Damage reduced to %d", target.GetID(), damage)
 		// Clear defend status after taking damage
-		delete(bm.isDefending, action.Target.GetID())
+		delete(bm.isDefending, target.GetID())
 	}
 
-	// Apply damage
-	defender.CurrentHP -= damage
-	if defender.CurrentHP < 0 {
-		defender.CurrentHP = 0
+	// Apply damage, scaled by the target affinity to the element
+	damage, affinity := target.TakeElementalDamage(damage, element)
+	result := TargetResult{
+		Target:   target,
+		HPAfter:  defender.CurrentHP,
+		MaxHP:    defender.GetMaxHP(),
+		Affinity: affinity,
 	}
+	if damage < 0 {
+		result.Healed = -damage
+		damage = 0
+	}
+	result.Damage = damage
+
+	// Remember who deals the most damage for threat-based targeting
+	bm.threat[action.Entity] += damage
 
 	// Store results in action for battle log
-	action.DamageDealt = damage
-	action.TargetHPAfter = defender.CurrentHP
-	action.TargetMaxHP = defender.MaxHP
+	action.Results = append(action.Results, result)
 
 	damageText := "💥"
 	if isMagical {
@@ -403,12 +567,12 @@ func (bm *BattleManager) executeAttackAction(action *BattleAction, isMagical boo
 	}
 
 	logger.Debug("%s %s takes %d damage! HP: %d/%d",
-		damageText, action.Target.GetID(), damage, defender.CurrentHP, defender.MaxHP)
+		damageText, target.GetID(), damage, defender.CurrentHP, defender.GetMaxHP())
 
 	// Check if target is defeated
 	if defender.CurrentHP <= 0 {
-		logger.Debug("💀 %s is defeated!", action.Target.GetID())
-		bm.removeFromActivityQueue(action.Target)
+		logger.Debug("💀 %s is defeated!", target.GetID())
+		bm.removeFromActivityQueue(target)
 	}
 }
 
@@ -441,9 +605,7 @@ func (bm *BattleManager) selectRandomTarget(targets []*ecs.Entity) *ecs.Entity {
 		return nil
 	}
 
-	// For now, just return the first alive target
-	// In a real implementation, you'd use random selection
-	return aliveTargets[0]
+	return aliveTargets[bm.rng.Intn(len(aliveTargets))]
 }
 
 func (bm *BattleManager) removeFromActivityQueue(entity *ecs.Entity) {
@@ -456,6 +618,11 @@ func (bm *BattleManager) removeFromActivityQueue(entity *ecs.Entity) {
 }
 
 func (bm *BattleManager) checkBattleEndConditions() {
+	// The result is only decided once
+	if bm.IsShowingResult() {
+		return
+	}
+
 	// Check if all enemies are defeated
 	aliveEnemies := 0
 	for _, enemy := range bm.enemyParty {
@@ -485,27 +652,32 @@ func (bm *BattleManager) checkBattleEndConditions() {
 
 func (bm *BattleManager) endBattle(victory bool) {
 	logger.Debug("🏁 Battle ended! Victory: %t", victory)
+	bm.tickStatusEffects()
 
 	if victory {
 		bm.state = BattleStateVictory
-		logger.Debug("🎉 Victory! Battle will remain active for 3 seconds to show results")
+		logger.Debug("🎉 Victory! Battle will remain active to show the rewards")
+
+		// Grant rewards once, they are shown on the victory summary
+		if bm.rewardHandler != nil {
+			bm.rewards = bm.rewardHandler(bm.playerParty, bm.enemyParty)
+		}
 	} else {
 		bm.state = BattleStateDefeat
-		logger.Debug("💀 Defeat! Battle will remain active for 3 seconds to show results")
+		logger.Debug("💀 Defeat! Battle will remain active to show results")
 	}
 
 	// Don't immediately end the battle - keep it active in victory/defeat state
 	// The battle will be ended after player input to return to exploration
+}
 
-	// Schedule battle end after 3 seconds
-	go func() {
-		time.Sleep(3 * time.Second)
-		bm.battleStarted = false
-		logger.Debug("⏰ Battle timeout - ending battle")
-		if bm.onBattleEnd != nil {
-			bm.onBattleEnd(victory)
+// tickStatusEffects counts the finished battle toward the duration of every participant's status effects
+func (bm *BattleManager) tickStatusEffects() {
+	for _, entity := range append(append([]*ecs.Entity{}, bm.playerParty...), bm.enemyParty...) {
+		if stats := entity.RPGStats(); stats != nil {
+			stats.TickStatusEffects()
 		}
-	}()
+	}
 }
 
 // Getters
@@ -529,6 +701,16 @@ func (bm *BattleManager) GetActivityQueue() []*ActivityEntry {
 	return bm.activityQueue
 }
 
+// GetRewards returns the rewards granted by the last victory (nil if none)
+func (bm *BattleManager) GetRewards() *systems.BattleRewards {
+	return bm.rewards
+}
+
+// SetRewardHandler sets the function that grants rewards to the party after a victory
+func (bm *BattleManager) SetRewardHandler(handler func(playerParty, enemyParty []*ecs.Entity) *systems.BattleRewards) {
+	bm.rewardHandler = handler
+}
+
 // Setters for callbacks
 func (bm *BattleManager) SetOnBattleEnd(callback func(victory bool)) {
 	bm.onBattleEnd = callback
@@ -538,6 +720,16 @@ func (bm *BattleManager) SetOnActionExecuted(callback func(action *BattleAction)
 	bm.onActionExecuted = callback
 }
 
+// SetOnEscape sets the function called once the player confirms a successful escape
+func (bm *BattleManager) SetOnEscape(callback func()) {
+	bm.onEscape = callback
+}
+
+// SetOnMessage sets the function that receives battle messages (refused commands, etc.)
+func (bm *BattleManager) SetOnMessage(callback func(message string)) {
+	bm.onMessage = callback
+}
+
 // Player input methods
 
 // updateAvailableTargets updates the list of available targets based on the selected action
@@ -546,15 +738,47 @@ func (bm *BattleManager) updateAvailableTargets() {
 	bm.availableTargets = make([]*ecs.Entity, 0)
 
 	switch bm.selectedAction {
-	case ActionAttack, ActionMagic:
-		// Attack and magic target enemies
+	case ActionAttack:
+		// Melee attacks only reach the back row once the front row is gone
+		for _, enemy := range bm.reachableTargets(bm.currentPlayerEntity, bm.enemyParty) {
+			if stats := enemy.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+				bm.availableTargets = append(bm.availableTargets, enemy)
+			}
+		}
+	case ActionMagic:
+		// Healing spells target living party members
+		if bm.selectedSpell != nil && bm.selectedSpell.Scope == TargetScopeSingleAlly {
+			for _, player := range bm.playerParty {
+				if stats := player.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+					bm.availableTargets = append(bm.availableTargets, player)
+				}
+			}
+			break
+		}
+		// Magic targets any enemy
 		for _, enemy := range bm.enemyParty {
 			if stats := enemy.RPGStats(); stats != nil && stats.CurrentHP > 0 {
 				bm.availableTargets = append(bm.availableTargets, enemy)
 			}
 		}
-	case ActionDefend:
-		// Defend doesn't need a target
+	case ActionItem:
+		// Offensive items target living enemies
+		if bm.selectedItem != nil && itemTargetsEnemy(bm.selectedItem) {
+			for _, enemy := range bm.enemyParty {
+				if stats := enemy.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+					bm.availableTargets = append(bm.availableTargets, enemy)
+				}
+			}
+			break
+		}
+		// Items target living party members
+		for _, player := range bm.playerParty {
+			if stats := player.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+				bm.availableTargets = append(bm.availableTargets, player)
+			}
+		}
+	case ActionDefend, ActionEscape, ActionRowSwap:
+		// Defend, escape and row swap don't need a target
 		bm.availableTargets = []*ecs.Entity{}
 	}
 
@@ -614,10 +838,23 @@ func (bm *BattleManager) HandlePlayerInput(actionType ActionType) {
 		bm.updateAvailableTargets()
 	}
 
-	if actionType == ActionDefend {
-		// Defend doesn't need target selection, execute immediately
+	switch actionType {
+	case ActionDefend, ActionRowSwap:
+		// Defend and row swap don't need target selection, execute immediately
+		bm.selectedTarget = nil
 		bm.executePlayerAction()
-	} else {
+	case ActionEscape:
+		if bm.bossBattle {
+			bm.notify("You can't escape from this battle!")
+			return
+		}
+		bm.selectedTarget = nil
+		bm.executePlayerAction()
+	case ActionMagic:
+		bm.openSpellList()
+	case ActionItem:
+		bm.openItemList()
+	default:
 		// Switch to target selection mode
 		bm.state = BattleStateWaitingForTarget
 		if len(bm.availableTargets) > 0 {
@@ -628,7 +865,16 @@ func (bm *BattleManager) HandlePlayerInput(actionType ActionType) {
 
 // HandleTargetNavigation handles arrow key navigation for target selection
 func (bm *BattleManager) HandleTargetNavigation(direction int) {
-	if bm.state != BattleStateWaitingForTarget || len(bm.availableTargets) == 0 {
+	if bm.state != BattleStateWaitingForTarget {
+		return
+	}
+
+	// Multi-target spells cycle between groups instead of single targets
+	if len(bm.targetGroups) > 0 {
+		bm.navigateTargetGroups(direction)
+		return
+	}
+	if len(bm.availableTargets) == 0 {
 		return
 	}
 
@@ -699,6 +945,15 @@ func (bm *BattleManager) executePlayerAction() {
 		Speed:      playerSpeed,
 		Timestamp:  bm.battleTime,
 	}
+	if bm.selectedAction == ActionMagic {
+		action.Spell = bm.selectedSpell
+		if len(bm.targetGroups) > 0 {
+			action.Targets = bm.targetGroups[bm.targetIndex]
+		}
+	}
+	if bm.selectedAction == ActionItem {
+		action.Item = bm.selectedItem
+	}
 
 	// Add defend status if defending
 	if bm.selectedAction == ActionDefend {
@@ -708,10 +963,10 @@ func (bm *BattleManager) executePlayerAction() {
 
 	bm.actionQueue = append(bm.actionQueue, action)
 
-	// Update the player's next action time and re-sort queue
+	// Empty the player's gauge and re-sort queue
 	for _, entry := range bm.activityQueue {
 		if entry.Entity == bm.currentPlayerEntity {
-			entry.NextActionAt = bm.battleTime.Add(entry.ActionDelay)
+			entry.resetGauge()
 			break
 		}
 	}
@@ -720,6 +975,9 @@ func (bm *BattleManager) executePlayerAction() {
 	// Reset player turn state
 	bm.currentPlayerEntity = nil
 	bm.selectedTarget = nil
+	bm.selectedSpell = nil
+	bm.selectedItem = nil
+	bm.targetGroups = nil
 	bm.state = BattleStatePlayerTurn // Will return to normal processing
 
 	logger.Debug("✅ Player action queued: %v", bm.selectedAction)
@@ -727,6 +985,19 @@ func (bm *BattleManager) executePlayerAction() {
 
 // HandleBattleEndConfirmation handles player input to end the battle after victory/defeat
 func (bm *BattleManager) HandleBattleEndConfirmation() {
+	if bm.state == BattleStateEscaped {
+		bm.battleStarted = false
+		bm.state = BattleStateIdle
+
+		// Escaping is neither a victory nor a defeat
+		if bm.onEscape != nil {
+			bm.onEscape()
+		}
+
+		logger.Debug("🏃 Escaped, returning to exploration mode")
+		return
+	}
+
 	if bm.state == BattleStateVictory || bm.state == BattleStateDefeat {
 		victory := bm.state == BattleStateVictory
 		bm.battleStarted = false
@@ -751,7 +1022,14 @@ func (bm *BattleManager) IsWaitingForTarget() bool {
 	return bm.state == BattleStateWaitingForTarget
 }
 
-// IsShowingResult returns true if the battle is showing victory/defeat screen
+// IsShowingResult returns true if the battle is showing victory/defeat/escape screen
 func (bm *BattleManager) IsShowingResult() bool {
-	return bm.state == BattleStateVictory || bm.state == BattleStateDefeat
+	return bm.state == BattleStateVictory || bm.state == BattleStateDefeat || bm.state == BattleStateEscaped
+}
+
+// isWaitingForInput returns true while the battle waits for any player selection
+func (bm *BattleManager) isWaitingForInput() bool {
+	return bm.state == BattleStateWaitingForPlayerAction ||
+		bm.state == BattleStateWaitingForTarget ||
+		bm.IsSelectingFromList()
 }
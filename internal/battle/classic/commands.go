@@ -0,0 +1,505 @@
+package classic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// Spell is a learned active skill that can be cast with the Magic command
+type Spell struct {
+	SkillID string             // Skill that unlocks the spell
+	Skill   *components.Skill  // Learned skill, for cooldowns and charges (nil for the basic spell)
+	Name    string             // Display name
+	MPCost  int                // MP spent when the spell is cast
+	Power   int                // Extra damage added to the magic damage formula
+	Scale   float64            // Physical damage multiplier of weapon skills (0 = magic damage)
+	Heal    int                // HP restored to each target (healing spells target allies)
+	Scope   TargetScope        // Which targets the spell reaches
+	Hits    int                // Number of targets for TargetScopeRandomEnemies
+	Element components.Element // Element of the spell damage
+}
+
+// BattleItem is a consumable stack that can be used with the Item command
+type BattleItem struct {
+	Item     *components.Item // Consumable item
+	Quantity int              // Total quantity in the inventory
+}
+
+// GetSpells returns the spells an entity can cast, from the learned active skills of its current and secondary jobs
+func GetSpells(entity *ecs.Entity) []*Spell {
+	spells := make([]*Spell, 0)
+	skills := entity.Skills()
+	if skills == nil {
+		return spells
+	}
+
+	// Only the current and secondary jobs' active skills can be cast
+	for _, skill := range skills.GetUsableActiveSkills() {
+		for _, effect := range skill.Effects {
+			if effect.Type != "ability_unlock" {
+				continue
+			}
+			scope, err := ParseTargetScope(skill.Target)
+			if err != nil {
+				logger.Warn("Skill %s: %v, using single target", skill.ID, err)
+			}
+			element, err := components.ParseElement(skillDataString(effect.Data, "element"))
+			if err != nil {
+				logger.Warn("Skill %s: %v, using non-elemental damage", skill.ID, err)
+			}
+			heal := skillDataInt(effect.Data, "heal", 0)
+			if heal > 0 && scope == TargetScopeSingleEnemy {
+				scope = TargetScopeSingleAlly // Healing spells without a target heal one ally
+			}
+			spells = append(spells, &Spell{
+				SkillID: skill.ID,
+				Skill:   skill,
+				Name:    skill.Name,
+				MPCost:  skill.MPCost,
+				Power:   skillDataInt(effect.Data, "damage", 0),
+				Scale:   skillDataFloat(effect.Data, "damage_multiplier"),
+				Heal:    heal,
+				Scope:   scope,
+				Hits:    skillDataInt(effect.Data, "hits", 1),
+				Element: element,
+			})
+			break
+		}
+	}
+
+	// Learned skills are stored in a map, keep the list order stable
+	sort.Slice(spells, func(i, j int) bool {
+		return spells[i].Name < spells[j].Name
+	})
+
+	return spells
+}
+
+// SpellReady returns an error if the spell's ability is cooling down or out of charges
+func SpellReady(entity *ecs.Entity, spell *Spell) error {
+	skills := entity.Skills()
+	if spell.Skill == nil || skills == nil {
+		return nil
+	}
+	return skills.CanUseAbility(spell.Skill)
+}
+
+// SpellStatus returns the cooldown and charge summary of a spell, empty if it has none to show
+func SpellStatus(entity *ecs.Entity, spell *Spell) string {
+	skills := entity.Skills()
+	if spell.Skill == nil || skills == nil || (spell.Skill.Cooldown == 0 && spell.Skill.Charges == 0) {
+		return ""
+	}
+	return skills.GetAbilityStatus(spell.Skill)
+}
+
+// skillDataInt reads an integer value from skill effect data
+func skillDataInt(data interface{}, key string, defaultValue int) int {
+	values, ok := data.(map[string]interface{})
+	if !ok {
+		return defaultValue
+	}
+	switch value := values[key].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	default:
+		return defaultValue
+	}
+}
+
+// skillDataFloat reads a decimal value from skill effect data, 0 if it is missing
+func skillDataFloat(data interface{}, key string) float64 {
+	values, ok := data.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	switch value := values[key].(type) {
+	case int:
+		return float64(value)
+	case float64:
+		return value
+	default:
+		return 0
+	}
+}
+
+// skillDataString reads a string value from skill effect data
+func skillDataString(data interface{}, key string) string {
+	values, ok := data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := values[key].(string)
+	return value
+}
+
+// GetBattleItems returns the consumables in an entity's inventory, one entry per item
+func GetBattleItems(entity *ecs.Entity) []*BattleItem {
+	items := make([]*BattleItem, 0)
+	inventory := entity.Inventory()
+	if inventory == nil {
+		return items
+	}
+
+	byID := make(map[int]*BattleItem)
+	for i := range inventory.Slots {
+		slot := &inventory.Slots[i]
+		if slot.IsEmpty() || slot.Item.Type != components.ItemTypeConsumable {
+			continue
+		}
+		if entry, exists := byID[slot.Item.ID]; exists {
+			entry.Quantity += slot.Quantity
+			continue
+		}
+		entry := &BattleItem{Item: slot.Item, Quantity: slot.Quantity}
+		byID[slot.Item.ID] = entry
+		items = append(items, entry)
+	}
+
+	return items
+}
+
+// itemTargetsAlly returns true if the item is used on a chosen party member instead of the user
+func itemTargetsAlly(item *components.Item) bool {
+	for _, effect := range item.Effects {
+		if effect.Target == "ally" {
+			return true
+		}
+	}
+	return false
+}
+
+// itemTargetsEnemy returns true if the item is used on a chosen enemy
+func itemTargetsEnemy(item *components.Item) bool {
+	for _, effect := range item.Effects {
+		if effect.Target == "enemy" {
+			return true
+		}
+	}
+	return false
+}
+
+// itemElement returns the element of the first damage effect of an item
+func itemElement(item *components.Item) components.Element {
+	for _, effect := range item.Effects {
+		if effect.Type == "damage" {
+			return effect.Element
+		}
+	}
+	return components.ElementNone
+}
+
+// averageSpeed returns the average Speed of the living members of a party
+func averageSpeed(party []*ecs.Entity) int {
+	total, count := 0, 0
+	for _, member := range party {
+		if stats := member.RPGStats(); stats != nil && stats.IsAlive() {
+			total += stats.GetStat(components.StatSpeed)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
+
+// GetEscapeChance returns the escape chance (%) from the party and enemy average Speed
+func (bm *BattleManager) GetEscapeChance() int {
+	if bm.bossBattle {
+		return 0
+	}
+
+	chance := constants.EscapeBaseChance +
+		(averageSpeed(bm.playerParty)-averageSpeed(bm.enemyParty))*constants.EscapeSpeedFactor
+	if chance < constants.EscapeMinChance {
+		chance = constants.EscapeMinChance
+	}
+	if chance > constants.EscapeMaxChance {
+		chance = constants.EscapeMaxChance
+	}
+	return chance
+}
+
+// SetBossBattle marks the next battle as a boss battle, where escaping is refused
+func (bm *BattleManager) SetBossBattle(boss bool) {
+	bm.bossBattle = boss
+}
+
+// IsBossBattle returns true if escaping is refused in the current battle
+func (bm *BattleManager) IsBossBattle() bool {
+	return bm.bossBattle
+}
+
+// HasEscaped returns true if the party escaped from the current battle
+func (bm *BattleManager) HasEscaped() bool {
+	return bm.state == BattleStateEscaped
+}
+
+// executeEscapeAction rolls the escape chance and leaves the battle on success
+func (bm *BattleManager) executeEscapeAction(action *BattleAction) {
+	chance := bm.GetEscapeChance()
+	roll := bm.rng.Intn(100)
+	action.Succeeded = roll < chance
+
+	logger.Debug("🏃 %s tries to escape (chance: %d%%, roll: %d)", action.Entity.GetID(), chance, roll)
+
+	if action.Succeeded {
+		bm.state = BattleStateEscaped
+		bm.actionQueue = make([]*BattleAction, 0)
+		bm.tickStatusEffects()
+	}
+}
+
+// executeSpellAction spends the spell MP, starts its cooldown and casts it on the target
+func (bm *BattleManager) executeSpellAction(action *BattleAction) {
+	caster := action.Entity.RPGStats()
+	if caster == nil {
+		return
+	}
+
+	if caster.CurrentMP < action.Spell.MPCost {
+		action.Failure = "not enough MP"
+		return
+	}
+	if err := SpellReady(action.Entity, action.Spell); err != nil {
+		action.Failure = err.Error()
+		return
+	}
+	caster.CurrentMP -= action.Spell.MPCost
+	if skills := action.Entity.Skills(); skills != nil && action.Spell.Skill != nil {
+		skills.UseAbility(action.Spell.Skill)
+	}
+
+	// Random targets are rolled when the spell is cast
+	if action.Spell.Scope == TargetScopeRandomEnemies {
+		action.Targets = bm.randomTargets(bm.opponentsOf(action.Entity), action.Spell.Hits)
+		action.Target = nil
+	}
+
+	if action.Spell.Heal > 0 {
+		bm.executeHealAction(action)
+		return
+	}
+	// Weapon skills scale a physical attack instead of casting magic
+	bm.executeAttackAction(action, action.Spell.Scale == 0)
+}
+
+// executeItemAction consumes one item from the user inventory and applies its effects
+func (bm *BattleManager) executeItemAction(action *BattleAction) {
+	inventory := action.Entity.Inventory()
+	if action.Item == nil || inventory == nil || inventory.GetItemCount(action.Item.ID) == 0 {
+		action.Failure = "item not found"
+		return
+	}
+
+	target := action.Target
+	if target == nil {
+		target = action.Entity
+	}
+	var hpBefore int
+	if stats := target.RPGStats(); stats != nil {
+		hpBefore = stats.CurrentHP
+	}
+
+	if err := bm.consumables.UseConsumable(action.Item, action.Entity, target); err != nil {
+		logger.Debug("⚠️  Item %s failed: %v", action.Item.Name, err)
+		action.Failure = err.Error()
+		return
+	}
+	inventory.RemoveItem(action.Item.ID, 1)
+
+	// Item effects marked "self" always apply to the user
+	affected := target
+	if !itemTargetsAlly(action.Item) && !itemTargetsEnemy(action.Item) {
+		affected = action.Entity
+	}
+	action.Target = affected
+	if stats := affected.RPGStats(); stats != nil {
+		action.TargetHPAfter = stats.CurrentHP
+		action.TargetMaxHP = stats.GetMaxHP()
+	}
+
+	// Offensive items record their damage for threat and the battle log
+	if itemTargetsEnemy(action.Item) {
+		result := TargetResult{
+			Target:   affected,
+			HPAfter:  action.TargetHPAfter,
+			MaxHP:    action.TargetMaxHP,
+			Affinity: affected.GetElementAffinity(itemElement(action.Item)),
+		}
+		if lost := hpBefore - action.TargetHPAfter; lost >= 0 {
+			result.Damage = lost
+		} else {
+			result.Healed = -lost
+		}
+		bm.threat[action.Entity] += result.Damage
+		action.Results = append(action.Results, result)
+		action.DamageDealt = result.Damage
+		if action.TargetHPAfter <= 0 {
+			bm.removeFromActivityQueue(affected)
+		}
+	}
+}
+
+// GetSpellList returns the spells of the player whose turn it is
+func (bm *BattleManager) GetSpellList() []*Spell {
+	return bm.spellList
+}
+
+// GetItemList returns the consumables of the player whose turn it is
+func (bm *BattleManager) GetItemList() []*BattleItem {
+	return bm.itemList
+}
+
+// GetListIndex returns the cursor position in the spell or item list
+func (bm *BattleManager) GetListIndex() int {
+	return bm.listIndex
+}
+
+// IsSelectingFromList returns true if the player is choosing a spell or an item
+func (bm *BattleManager) IsSelectingFromList() bool {
+	return bm.state == BattleStateWaitingForSpell || bm.state == BattleStateWaitingForItem
+}
+
+// openSpellList shows the spell list of the current player
+func (bm *BattleManager) openSpellList() {
+	bm.spellList = GetSpells(bm.currentPlayerEntity)
+	if len(bm.spellList) == 0 {
+		bm.notify(fmt.Sprintf("%s has not learned any spells!", bm.currentPlayerName()))
+		return
+	}
+	bm.listIndex = 0
+	bm.state = BattleStateWaitingForSpell
+}
+
+// openItemList shows the consumables of the current player
+func (bm *BattleManager) openItemList() {
+	bm.itemList = GetBattleItems(bm.currentPlayerEntity)
+	if len(bm.itemList) == 0 {
+		bm.notify(fmt.Sprintf("%s has no usable items!", bm.currentPlayerName()))
+		return
+	}
+	bm.listIndex = 0
+	bm.state = BattleStateWaitingForItem
+}
+
+// HandleListNavigation moves the cursor in the spell or item list
+func (bm *BattleManager) HandleListNavigation(direction int) {
+	var count int
+	switch bm.state {
+	case BattleStateWaitingForSpell:
+		count = len(bm.spellList)
+	case BattleStateWaitingForItem:
+		count = len(bm.itemList)
+	default:
+		return
+	}
+	if count == 0 {
+		return
+	}
+
+	if direction > 0 {
+		bm.listIndex = (bm.listIndex + 1) % count
+	} else {
+		bm.listIndex = (bm.listIndex - 1 + count) % count
+	}
+}
+
+// ConfirmListSelection selects the spell or item under the cursor
+func (bm *BattleManager) ConfirmListSelection() {
+	switch bm.state {
+	case BattleStateWaitingForSpell:
+		if bm.listIndex >= len(bm.spellList) {
+			return
+		}
+		spell := bm.spellList[bm.listIndex]
+		if stats := bm.currentPlayerEntity.RPGStats(); stats != nil && stats.CurrentMP < spell.MPCost {
+			bm.notify(fmt.Sprintf("Not enough MP for %s!", spell.Name))
+			return
+		}
+		if err := SpellReady(bm.currentPlayerEntity, spell); err != nil {
+			bm.notify(err.Error() + "!")
+			return
+		}
+		bm.selectedSpell = spell
+		bm.enterTargetSelection()
+
+	case BattleStateWaitingForItem:
+		if bm.listIndex >= len(bm.itemList) {
+			return
+		}
+		bm.selectedItem = bm.itemList[bm.listIndex].Item
+		if itemTargetsAlly(bm.selectedItem) || itemTargetsEnemy(bm.selectedItem) {
+			bm.enterTargetSelection()
+			return
+		}
+		// Items used on the user do not need a target
+		bm.selectedTarget = bm.currentPlayerEntity
+		bm.executePlayerAction()
+	}
+}
+
+// CancelSelection goes back from a list or target selection to the action menu
+func (bm *BattleManager) CancelSelection() {
+	if bm.state != BattleStateWaitingForSpell &&
+		bm.state != BattleStateWaitingForItem &&
+		bm.state != BattleStateWaitingForTarget {
+		return
+	}
+
+	bm.selectedSpell = nil
+	bm.selectedItem = nil
+	bm.selectedTarget = nil
+	bm.targetGroups = nil
+	bm.state = BattleStateWaitingForPlayerAction
+}
+
+// enterTargetSelection switches to target or group selection for the selected action
+func (bm *BattleManager) enterTargetSelection() {
+	bm.targetIndex = 0
+	bm.targetGroups = nil
+
+	// Multi-target spells select a whole group
+	if bm.selectedAction == ActionMagic && bm.selectedSpell != nil && bm.selectedSpell.Scope.IsGroup() {
+		bm.targetGroups = bm.targetGroupsFor(bm.currentPlayerEntity, bm.selectedSpell)
+		if len(bm.targetGroups) == 0 {
+			return
+		}
+		bm.selectedTarget = nil
+		bm.state = BattleStateWaitingForTarget
+		return
+	}
+
+	bm.updateAvailableTargets()
+	if len(bm.availableTargets) == 0 {
+		return
+	}
+	bm.selectedTarget = bm.availableTargets[bm.targetIndex]
+	bm.state = BattleStateWaitingForTarget
+}
+
+// currentPlayerName returns the name of the player whose turn it is
+func (bm *BattleManager) currentPlayerName() string {
+	if bm.currentPlayerEntity != nil {
+		if stats := bm.currentPlayerEntity.RPGStats(); stats != nil {
+			return stats.Name
+		}
+	}
+	return "Player"
+}
+
+// notify sends a message to the battle log
+func (bm *BattleManager) notify(message string) {
+	logger.Debug("📣 %s", message)
+	if bm.onMessage != nil {
+		bm.onMessage(message)
+	}
+}
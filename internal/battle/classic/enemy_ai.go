@@ -0,0 +1,194 @@
+package classic
+
+import (
+	"math/rand"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// SetRNG sets the random source used for escape rolls and enemy decisions (the shared game RNG)
+func (bm *BattleManager) SetRNG(rng *rand.Rand) {
+	bm.rng = rng
+}
+
+// SetAutoBattle lets the battle AI choose the party's actions too (headless simulations)
+func (bm *BattleManager) SetAutoBattle(enabled bool) {
+	bm.autoBattle = enabled
+}
+
+// decideAIAction chooses between Attack, Magic and Defend for an AI-controlled entity and picks its target
+func (bm *BattleManager) decideAIAction(entity *ecs.Entity, entry *ActivityEntry) *BattleAction {
+	stats := entity.RPGStats()
+	if stats == nil {
+		return nil
+	}
+
+	action := &BattleAction{
+		Entity:     entity,
+		ActionType: ActionAttack,
+		Speed:      entry.Speed,
+		Timestamp:  bm.battleTime,
+	}
+
+	ai := entity.BattleAI()
+	if ai == nil {
+		// Entities without battle AI settings attack a random reachable target
+		action.Target = bm.selectRandomTarget(bm.reachableTargets(entity, bm.opponentsOf(entity)))
+		if action.Target == nil {
+			return nil
+		}
+		return action
+	}
+
+	// Defend when badly hurt
+	if stats.GetMaxHP() > 0 && stats.CurrentHP*100/stats.GetMaxHP() < ai.DefendHPPercent && bm.rng.Intn(100) < ai.DefendChance {
+		action.ActionType = ActionDefend
+		return action
+	}
+
+	// Cast magic when there is MP for it; magic reaches every row
+	if spell := bm.chooseEnemySpell(entity); spell != nil && bm.rng.Intn(100) < ai.MagicChance {
+		action.ActionType = ActionMagic
+		action.Spell = spell
+		if bm.aimAISpell(action, ai.Strategy) {
+			return action
+		}
+		action.ActionType = ActionAttack
+		action.Spell = nil
+	}
+
+	action.Target = bm.selectTargetByStrategy(ai.Strategy, bm.reachableTargets(entity, bm.opponentsOf(entity)))
+	if action.Target == nil {
+		return nil
+	}
+	return action
+}
+
+// aimAISpell picks the targets of an AI spell; returns false if the spell has nothing to hit
+func (bm *BattleManager) aimAISpell(action *BattleAction, strategy components.TargetStrategy) bool {
+	allies := bm.alliesOf(action.Entity)
+	opponents := bm.opponentsOf(action.Entity)
+
+	switch action.Spell.Scope {
+	case TargetScopeSingleAlly:
+		// Heal the most wounded ally
+		action.Target = bm.selectTargetByStrategy(components.TargetLowestHP, allies)
+		return action.Target != nil
+	case TargetScopeAllAllies:
+		action.Targets = livingEntities(allies)
+		return len(action.Targets) > 0
+	case TargetScopeAllEnemies, TargetScopeRandomEnemies:
+		// Random targets are rolled when the spell is cast
+		action.Targets = livingEntities(opponents)
+		return len(action.Targets) > 0
+	case TargetScopeEnemyRow:
+		// Aim at the row of the target the strategy prefers
+		target := bm.selectTargetByStrategy(strategy, opponents)
+		if target == nil {
+			return false
+		}
+		for _, group := range bm.targetGroupsFor(action.Entity, action.Spell) {
+			for _, member := range group {
+				if member == target {
+					action.Targets = group
+					return true
+				}
+			}
+		}
+		action.Target = target
+		return true
+	default:
+		action.Target = bm.selectTargetByStrategy(strategy, opponents)
+		return action.Target != nil
+	}
+}
+
+// chooseEnemySpell returns a random affordable and ready spell, or a basic spell if the enemy knows none
+func (bm *BattleManager) chooseEnemySpell(entity *ecs.Entity) *Spell {
+	stats := entity.RPGStats()
+
+	affordable := make([]*Spell, 0)
+	for _, spell := range GetSpells(entity) {
+		if stats.CurrentMP >= spell.MPCost && SpellReady(entity, spell) == nil {
+			affordable = append(affordable, spell)
+		}
+	}
+	if len(affordable) > 0 {
+		return affordable[bm.rng.Intn(len(affordable))]
+	}
+
+	if stats.CurrentMP >= constants.DefaultSpellMPCost {
+		return &Spell{Name: "Magic", MPCost: constants.DefaultSpellMPCost}
+	}
+	return nil
+}
+
+// selectTargetByStrategy picks a living target following an enemy targeting strategy
+func (bm *BattleManager) selectTargetByStrategy(strategy components.TargetStrategy, targets []*ecs.Entity) *ecs.Entity {
+	alive := make([]*ecs.Entity, 0, len(targets))
+	for _, target := range targets {
+		if stats := target.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+			alive = append(alive, target)
+		}
+	}
+	if len(alive) == 0 {
+		return nil
+	}
+
+	switch strategy {
+	case components.TargetLowestHP:
+		best := alive[0]
+		for _, target := range alive[1:] {
+			if target.RPGStats().CurrentHP < best.RPGStats().CurrentHP {
+				best = target
+			}
+		}
+		return best
+
+	case components.TargetHighestThreat:
+		best := alive[0]
+		for _, target := range alive[1:] {
+			if bm.threatOf(target) > bm.threatOf(best) {
+				best = target
+			}
+		}
+		return best
+
+	case components.TargetHealerFirst:
+		healers := make([]*ecs.Entity, 0)
+		for _, target := range alive {
+			if def := target.RPGStats().Job.Definition(); def != nil && def.Healer {
+				healers = append(healers, target)
+			}
+		}
+		if len(healers) > 0 {
+			return bm.selectRandomTarget(healers)
+		}
+
+	case components.TargetFrontRow:
+		front := make([]*ecs.Entity, 0)
+		for _, target := range alive {
+			if !bm.isInBackRow(target) {
+				front = append(front, target)
+			}
+		}
+		if len(front) > 0 {
+			return bm.selectRandomTarget(front)
+		}
+	}
+
+	return bm.selectRandomTarget(alive)
+}
+
+// threatOf returns the damage a participant dealt this battle, using Attack before anyone dealt damage
+func (bm *BattleManager) threatOf(entity *ecs.Entity) int {
+	if threat := bm.threat[entity]; threat > 0 {
+		return threat
+	}
+	if stats := entity.RPGStats(); stats != nil {
+		return stats.GetStat(components.StatAttack) / 10
+	}
+	return 0
+}
@@ -21,6 +21,7 @@ type Formation struct {
 	rows      int
 	maxPerRow int
 	isEnemy   bool
+	usesRows  bool // Rows follow each character's BackRow preference
 
 	// Screen positioning
 	screenX      float64
@@ -39,6 +40,7 @@ func NewPlayerFormation(players []*ecs.Entity) *Formation {
 		rows:         2,
 		maxPerRow:    2, // 2x2 formation for players
 		isEnemy:      false,
+		usesRows:     true,
 		screenX:      100, // Left side for players
 		screenY:      290, // Bottom of expanded battle area, above UI
 		entityWidth:  64,
@@ -73,6 +75,11 @@ func NewEnemyFormation(enemies []*ecs.Entity) *Formation {
 
 // arrangeEntities positions all entities in the formation
 func (f *Formation) arrangeEntities() {
+	if f.usesRows {
+		f.arrangeByRowPreference()
+		return
+	}
+
 	entityIndex := 0
 
 	for row := 0; row < f.rows && entityIndex < len(f.entities); row++ {
@@ -114,6 +121,41 @@ func (f *Formation) arrangeEntities() {
 	}
 }
 
+// arrangeByRowPreference places each entity in the row chosen by its BackRow preference
+func (f *Formation) arrangeByRowPreference() {
+	front := make([]*ecs.Entity, 0)
+	back := make([]*ecs.Entity, 0)
+	for _, entity := range f.entities {
+		if stats := entity.RPGStats(); stats != nil && stats.BackRow {
+			back = append(back, entity)
+		} else {
+			front = append(front, entity)
+		}
+	}
+
+	f.placeRow(0, front)
+	f.placeRow(f.rows-1, back)
+}
+
+// placeRow positions the given entities centered in a row
+func (f *Formation) placeRow(row int, entities []*ecs.Entity) {
+	startX := f.screenX - (float64(len(entities)-1) * f.spacing / 2)
+	rowY := f.screenY + float64(row)*f.rowSpacing
+	if f.isEnemy {
+		rowY = f.screenY - float64(row)*f.rowSpacing
+	}
+
+	for i, entity := range entities {
+		x := startX + float64(i)*f.spacing
+		f.positions[entity] = Position{X: x, Y: rowY, Row: row, Index: i}
+
+		if transform := entity.Transform(); transform != nil {
+			transform.X = x
+			transform.Y = rowY
+		}
+	}
+}
+
 // getEntitiesForRow calculates how many entities should be in a specific row
 func (f *Formation) getEntitiesForRow(row, startIndex int) int {
 	remainingEntities := len(f.entities) - startIndex
@@ -192,6 +234,39 @@ func (f *Formation) IsEntityInFrontRow(entity *ecs.Entity) bool {
 	return exists && pos.Row == 0
 }
 
+// IsEntityInBackRow checks if an entity is in the back row
+func (f *Formation) IsEntityInBackRow(entity *ecs.Entity) bool {
+	pos, exists := f.positions[entity]
+	return exists && pos.Row == f.rows-1 && f.rows > 1
+}
+
+// HasLivingFrontRow returns true while at least one front-row entity is alive
+func (f *Formation) HasLivingFrontRow() bool {
+	for _, entity := range f.GetFrontRowEntities() {
+		if stats := entity.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SwapRow moves an entity to the other row and rearranges the formation.
+// The choice is stored in the entity's BackRow preference so it persists between battles.
+func (f *Formation) SwapRow(entity *ecs.Entity) bool {
+	stats := entity.RPGStats()
+	if !f.usesRows || stats == nil {
+		return false
+	}
+	if _, exists := f.positions[entity]; !exists {
+		return false
+	}
+
+	stats.BackRow = !stats.BackRow
+	f.positions = make(map[*ecs.Entity]Position)
+	f.arrangeEntities()
+	return true
+}
+
 // GetEntitiesInRow returns all entities in a specific row, sorted by their index within the row
 func (f *Formation) GetEntitiesInRow(row int) []*ecs.Entity {
 	// Collect entities and their positions
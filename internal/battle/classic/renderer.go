@@ -1,3 +1,5 @@
+//go:build !headless
+
 package classic
 
 import (
@@ -92,6 +94,11 @@ func (br *BattleRenderer) Render(screen *ebiten.Image) {
 	br.drawActionPanel(screen)
 	br.drawCombinedLogPanel(screen)
 	br.drawBattleLogPanel(screen)
+
+	// Draw victory summary on top of everything
+	if br.battleManager.GetBattleState() == BattleStateVictory {
+		br.drawVictorySummary(screen)
+	}
 }
 
 // Helper to draw panel with border
@@ -149,23 +156,103 @@ func (br *BattleRenderer) drawActionPanel(screen *ebiten.Image) {
 			if stats := player.RPGStats(); stats != nil {
 				name = stats.Name
 			}
-			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s's Turn - Choose Action:", name), br.actionPanelX+10, baseY)
-			ebitenutil.DebugPrintAt(screen, "1. Physical Attack", br.actionPanelX+10, baseY+25)
-			ebitenutil.DebugPrintAt(screen, "2. Magical Attack", br.actionPanelX+10, baseY+45)
-			ebitenutil.DebugPrintAt(screen, "3. Defend", br.actionPanelX+10, baseY+65)
+			ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s's Turn:", name), br.actionPanelX+10, baseY)
+			ebitenutil.DebugPrintAt(screen, "1. Attack", br.actionPanelX+10, baseY+15)
+			ebitenutil.DebugPrintAt(screen, "2. Magic", br.actionPanelX+10, baseY+30)
+			ebitenutil.DebugPrintAt(screen, "3. Defend", br.actionPanelX+10, baseY+45)
+			ebitenutil.DebugPrintAt(screen, "4. Item", br.actionPanelX+10, baseY+60)
+			escapeText := fmt.Sprintf("5. Escape (%d%%)", br.battleManager.GetEscapeChance())
+			if br.battleManager.IsBossBattle() {
+				escapeText = "5. Escape (blocked)"
+			}
+			ebitenutil.DebugPrintAt(screen, escapeText, br.actionPanelX+10, baseY+75)
+			rowText := fmt.Sprintf("6. Row Swap (%s)", br.battleManager.GetRowName(player))
+			ebitenutil.DebugPrintAt(screen, rowText, br.actionPanelX+10, baseY+90)
 		}
 	case BattleStateWaitingForTarget:
-		ebitenutil.DebugPrintAt(screen, "Select Target:", br.actionPanelX+10, baseY)
+		title := "Select Target:"
+		if br.battleManager.IsSelectingGroup() {
+			title = "Select Group:"
+		}
+		ebitenutil.DebugPrintAt(screen, title, br.actionPanelX+10, baseY)
 		ebitenutil.DebugPrintAt(screen, "Use arrows, Enter to confirm", br.actionPanelX+10, baseY+20)
+		ebitenutil.DebugPrintAt(screen, "Esc to go back", br.actionPanelX+10, baseY+36)
+	case BattleStateWaitingForSpell:
+		lines := make([]string, 0)
+		caster := br.battleManager.GetCurrentPlayerEntity()
+		for _, spell := range br.battleManager.GetSpellList() {
+			line := fmt.Sprintf("%s (%d MP)", spell.Name, spell.MPCost)
+			if caster != nil {
+				if status := SpellStatus(caster, spell); status != "" {
+					line += " " + status
+				}
+			}
+			lines = append(lines, line)
+		}
+		br.drawSelectionList(screen, "Magic:", lines, baseY)
+	case BattleStateWaitingForItem:
+		lines := make([]string, 0)
+		for _, entry := range br.battleManager.GetItemList() {
+			lines = append(lines, fmt.Sprintf("%s x%d", entry.Item.Name, entry.Quantity))
+		}
+		br.drawSelectionList(screen, "Items:", lines, baseY)
 	case BattleStateVictory:
 		ebitenutil.DebugPrintAt(screen, "VICTORY!", br.actionPanelX+10, baseY)
-		ebitenutil.DebugPrintAt(screen, "Press any key to continue...", br.actionPanelX+10, baseY+20)
+		ebitenutil.DebugPrintAt(screen, "Press Enter to continue...", br.actionPanelX+10, baseY+20)
 	case BattleStateDefeat:
 		ebitenutil.DebugPrintAt(screen, "DEFEAT!", br.actionPanelX+10, baseY)
-		ebitenutil.DebugPrintAt(screen, "Press any key to continue...", br.actionPanelX+10, baseY+20)
+		ebitenutil.DebugPrintAt(screen, "Press Enter to continue...", br.actionPanelX+10, baseY+20)
+	case BattleStateEscaped:
+		ebitenutil.DebugPrintAt(screen, "ESCAPED!", br.actionPanelX+10, baseY)
+		ebitenutil.DebugPrintAt(screen, "Press Enter to continue...", br.actionPanelX+10, baseY+20)
 	}
 }
 
+// drawSelectionList draws a scrolling spell or item list with the cursor on the selected entry
+func (br *BattleRenderer) drawSelectionList(screen *ebiten.Image, title string, lines []string, baseY int) {
+	ebitenutil.DebugPrintAt(screen, title, br.actionPanelX+10, baseY)
+
+	// Scroll so the selected entry is always visible
+	index := br.battleManager.GetListIndex()
+	start := 0
+	if index >= constants.SpellListVisibleRows {
+		start = index - constants.SpellListVisibleRows + 1
+	}
+
+	y := baseY + 18
+	for i := start; i < len(lines) && i < start+constants.SpellListVisibleRows; i++ {
+		cursor := "  "
+		if i == index {
+			cursor = "> "
+		}
+		ebitenutil.DebugPrintAt(screen, cursor+lines[i], br.actionPanelX+10, y)
+		y += 16
+	}
+}
+
+// drawVictorySummary draws the rewards granted by the victory in a centered panel
+func (br *BattleRenderer) drawVictorySummary(screen *ebiten.Image) {
+	rewards := br.battleManager.GetRewards()
+	if rewards == nil {
+		return
+	}
+
+	lines := rewards.SummaryLines()
+	width := 360
+	height := 60 + len(lines)*18
+	x := (br.screenWidth - width) / 2
+	y := (br.screenHeight - height) / 2
+
+	br.drawPanel(screen, x, y, width, height, color.RGBA{30, 40, 30, 240}, "Victory!")
+
+	lineY := y + 30
+	for _, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x+15, lineY)
+		lineY += 18
+	}
+	ebitenutil.DebugPrintAt(screen, "Press Enter to continue...", x+15, lineY+6)
+}
+
 // drawCombinedLogPanel draws activity queue and player info in right-top
 func (br *BattleRenderer) drawCombinedLogPanel(screen *ebiten.Image) {
 	br.drawPanel(screen, br.combinedLogPanelX, br.combinedLogPanelY, br.combinedLogPanelWidth, br.combinedLogPanelHeight,
@@ -200,18 +287,23 @@ func (br *BattleRenderer) drawCombinedLogPanel(screen *ebiten.Image) {
 		}
 
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s %s", prefix, name), br.combinedLogPanelX+10, y)
+		br.drawGaugeBar(screen, float64(br.combinedLogPanelX+120), float64(y+5), 60, 6, br.battleManager.GetGauge(entry.Entity))
 		y += 18
 	}
 
+	// Battle time settings
+	modeText := fmt.Sprintf("%s x%.1f", br.battleManager.GetATBMode().String(), br.battleManager.GetBattleSpeed())
+	ebitenutil.DebugPrintAt(screen, modeText, br.combinedLogPanelX+10, baseY+10*18+5)
+
 	// Draw current player info below
-	if br.battleManager.GetBattleState() == BattleStateWaitingForPlayerAction {
+	if br.battleManager.IsWaitingForPlayerAction() || br.battleManager.IsSelectingFromList() {
 		if player := br.battleManager.GetCurrentPlayerEntity(); player != nil {
 			if stats := player.RPGStats(); stats != nil {
 				startY := br.combinedLogPanelY + 250
 				ebitenutil.DebugPrintAt(screen, "Current Player:", br.combinedLogPanelX+10, startY)
 				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Name: %s", stats.Name), br.combinedLogPanelX+10, startY+20)
-				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("HP: %d/%d", stats.CurrentHP, stats.MaxHP), br.combinedLogPanelX+10, startY+35)
-				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("MP: %d/%d", stats.CurrentMP, stats.MaxMP), br.combinedLogPanelX+10, startY+50)
+				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("HP: %d/%d", stats.CurrentHP, stats.GetMaxHP()), br.combinedLogPanelX+10, startY+35)
+				ebitenutil.DebugPrintAt(screen, fmt.Sprintf("MP: %d/%d", stats.CurrentMP, stats.GetMaxMP()), br.combinedLogPanelX+10, startY+50)
 			}
 		}
 	}
@@ -276,7 +368,10 @@ func (br *BattleRenderer) drawEntity(screen *ebiten.Image, entity *ecs.Entity, p
 		name = name[:8]
 	}
 	ebitenutil.DebugPrintAt(screen, name, int(pos.X), int(pos.Y-25))
-	br.drawHealthBar(screen, pos.X, pos.Y-10, 48, 6, stats.CurrentHP, stats.MaxHP)
+	br.drawHealthBar(screen, pos.X, pos.Y-10, 48, 6, stats.CurrentHP, stats.GetMaxHP())
+	if stats.CurrentHP > 0 {
+		br.drawGaugeBar(screen, pos.X, pos.Y+50, 48, 4, br.battleManager.GetGauge(entity))
+	}
 }
 
 // isSelectedTarget checks if entity is the selected target
@@ -286,6 +381,11 @@ func (br *BattleRenderer) isSelectedTarget(entity *ecs.Entity) bool {
 		return false
 	}
 
+	// Multi-target spells highlight the whole group
+	if br.battleManager.IsSelectingGroup() {
+		return br.battleManager.IsTargetSelected(entity)
+	}
+
 	targets := br.battleManager.GetAvailableTargets()
 	index := br.battleManager.GetTargetIndex()
 
@@ -300,6 +400,19 @@ func (br *BattleRenderer) isSelectedTarget(entity *ecs.Entity) bool {
 	return isSelected
 }
 
+// drawGaugeBar renders an ATB gauge; full gauges are drawn brighter
+func (br *BattleRenderer) drawGaugeBar(screen *ebiten.Image, x, y, width, height, fill float64) {
+	ebitenutil.DrawRect(screen, x, y, width, height, color.RGBA{40, 40, 80, 255})
+
+	gaugeColor := color.RGBA{80, 160, 255, 255}
+	if fill >= 1 {
+		gaugeColor = color.RGBA{255, 220, 80, 255}
+	}
+	if fill > 0 {
+		ebitenutil.DrawRect(screen, x, y, width*fill, height, gaugeColor)
+	}
+}
+
 // drawHealthBar renders health bar
 func (br *BattleRenderer) drawHealthBar(screen *ebiten.Image, x, y, width, height float64, currentHP, maxHP int) {
 	if maxHP <= 0 {
@@ -430,5 +543,8 @@ func (br *BattleRenderer) drawEntityInBox(screen *ebiten.Image, entity *ecs.Enti
 	// Draw health bar below sprite
 	healthBarY := spriteY + spriteSize + 5
 	healthBarWidth := constants.EntitySpriteBoxSize - 10 // Leave 5px margin on each side
-	br.drawHealthBar(screen, float64(x+5), float64(healthBarY), float64(healthBarWidth), 6, stats.CurrentHP, stats.MaxHP)
+	br.drawHealthBar(screen, float64(x+5), float64(healthBarY), float64(healthBarWidth), 6, stats.CurrentHP, stats.GetMaxHP())
+	if stats.CurrentHP > 0 {
+		br.drawGaugeBar(screen, float64(x+5), float64(healthBarY+8), float64(healthBarWidth), 4, br.battleManager.GetGauge(entity))
+	}
 }
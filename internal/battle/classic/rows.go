@@ -0,0 +1,70 @@
+package classic
+
+import (
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// isRangedAttacker returns true if the entity's physical attacks are ranged (not affected by rows)
+func isRangedAttacker(entity *ecs.Entity) bool {
+	stats := entity.RPGStats()
+	if stats == nil {
+		return false
+	}
+	def := stats.Job.Definition()
+	return def != nil && def.Ranged
+}
+
+// formationOf returns the formation the entity belongs to
+func (bm *BattleManager) formationOf(entity *ecs.Entity) *Formation {
+	if bm.isPlayerEntity(entity) {
+		return bm.playerFormation
+	}
+	return bm.enemyFormation
+}
+
+// isInBackRow returns true if the entity stands in the back row of its formation
+func (bm *BattleManager) isInBackRow(entity *ecs.Entity) bool {
+	formation := bm.formationOf(entity)
+	return formation != nil && formation.IsEntityInBackRow(entity)
+}
+
+// reachableTargets filters out back-row targets a melee attacker cannot reach yet
+func (bm *BattleManager) reachableTargets(attacker *ecs.Entity, targets []*ecs.Entity) []*ecs.Entity {
+	if attacker == nil || isRangedAttacker(attacker) || len(targets) == 0 {
+		return targets
+	}
+
+	// The back row is only exposed once the whole front row is down
+	formation := bm.formationOf(targets[0])
+	if formation == nil || !formation.HasLivingFrontRow() {
+		return targets
+	}
+
+	reachable := make([]*ecs.Entity, 0, len(targets))
+	for _, target := range targets {
+		if !formation.IsEntityInBackRow(target) {
+			reachable = append(reachable, target)
+		}
+	}
+	return reachable
+}
+
+// executeRowSwapAction moves the entity to the other row of its formation
+func (bm *BattleManager) executeRowSwapAction(action *BattleAction) {
+	formation := bm.formationOf(action.Entity)
+	if formation == nil || !formation.SwapRow(action.Entity) {
+		action.Failure = "cannot change rows"
+		return
+	}
+
+	logger.Debug("↕️  %s moves to the %s row", action.Entity.GetID(), bm.GetRowName(action.Entity))
+}
+
+// GetRowName returns "front" or "back" for the row the entity stands in
+func (bm *BattleManager) GetRowName(entity *ecs.Entity) string {
+	if bm.isInBackRow(entity) {
+		return "back"
+	}
+	return "front"
+}
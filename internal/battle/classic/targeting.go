@@ -0,0 +1,213 @@
+package classic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// TargetScope selects which targets a spell reaches
+type TargetScope int
+
+const (
+	TargetScopeSingleEnemy   TargetScope = iota // One chosen enemy
+	TargetScopeSingleAlly                       // One chosen party member
+	TargetScopeAllEnemies                       // Every living enemy
+	TargetScopeEnemyRow                         // Every living enemy in a chosen row
+	TargetScopeRandomEnemies                    // A number of random living enemies
+	TargetScopeAllAllies                        // Every living party member
+)
+
+func (ts TargetScope) String() string {
+	switch ts {
+	case TargetScopeSingleEnemy:
+		return "enemy"
+	case TargetScopeSingleAlly:
+		return "ally"
+	case TargetScopeAllEnemies:
+		return "all_enemies"
+	case TargetScopeEnemyRow:
+		return "row"
+	case TargetScopeRandomEnemies:
+		return "random"
+	case TargetScopeAllAllies:
+		return "all_allies"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseTargetScope converts a skill data "target" value into a TargetScope; empty means one enemy
+func ParseTargetScope(name string) (TargetScope, error) {
+	if name == "" {
+		return TargetScopeSingleEnemy, nil
+	}
+	for scope := TargetScopeSingleEnemy; scope <= TargetScopeAllAllies; scope++ {
+		if scope.String() == name {
+			return scope, nil
+		}
+	}
+	return TargetScopeSingleEnemy, fmt.Errorf("unknown target scope: %s", name)
+}
+
+// IsGroup returns true if the scope hits several targets at once
+func (ts TargetScope) IsGroup() bool {
+	return ts == TargetScopeAllEnemies || ts == TargetScopeEnemyRow ||
+		ts == TargetScopeRandomEnemies || ts == TargetScopeAllAllies
+}
+
+// TargetsAllies returns true if the scope targets the caster's own party
+func (ts TargetScope) TargetsAllies() bool {
+	return ts == TargetScopeSingleAlly || ts == TargetScopeAllAllies
+}
+
+// livingEntities returns the entities that are still alive
+func livingEntities(entities []*ecs.Entity) []*ecs.Entity {
+	alive := make([]*ecs.Entity, 0, len(entities))
+	for _, entity := range entities {
+		if stats := entity.RPGStats(); stats != nil && stats.CurrentHP > 0 {
+			alive = append(alive, entity)
+		}
+	}
+	return alive
+}
+
+// opponentsOf returns the party fighting against the entity
+func (bm *BattleManager) opponentsOf(entity *ecs.Entity) []*ecs.Entity {
+	if bm.isPlayerEntity(entity) {
+		return bm.enemyParty
+	}
+	return bm.playerParty
+}
+
+// alliesOf returns the party the entity belongs to
+func (bm *BattleManager) alliesOf(entity *ecs.Entity) []*ecs.Entity {
+	if bm.isPlayerEntity(entity) {
+		return bm.playerParty
+	}
+	return bm.enemyParty
+}
+
+// targetGroupsFor returns the groups a multi-target spell can be aimed at.
+// Rows give one group per non-empty row; random targets are rolled when the spell is cast.
+func (bm *BattleManager) targetGroupsFor(caster *ecs.Entity, spell *Spell) [][]*ecs.Entity {
+	groups := make([][]*ecs.Entity, 0)
+
+	switch spell.Scope {
+	case TargetScopeAllEnemies, TargetScopeRandomEnemies:
+		if alive := livingEntities(bm.opponentsOf(caster)); len(alive) > 0 {
+			groups = append(groups, alive)
+		}
+	case TargetScopeAllAllies:
+		if alive := livingEntities(bm.alliesOf(caster)); len(alive) > 0 {
+			groups = append(groups, alive)
+		}
+	case TargetScopeEnemyRow:
+		opponents := bm.opponentsOf(caster)
+		if len(opponents) == 0 {
+			break
+		}
+		formation := bm.formationOf(opponents[0])
+		if formation == nil {
+			break
+		}
+		for row := 0; row < formation.rows; row++ {
+			if alive := livingEntities(formation.GetEntitiesInRow(row)); len(alive) > 0 {
+				groups = append(groups, alive)
+			}
+		}
+	}
+
+	return groups
+}
+
+// randomTargets picks up to count distinct living targets
+func (bm *BattleManager) randomTargets(targets []*ecs.Entity, count int) []*ecs.Entity {
+	alive := livingEntities(targets)
+	if count > len(alive) {
+		count = len(alive)
+	}
+
+	picked := make([]*ecs.Entity, 0, count)
+	for _, index := range bm.rng.Perm(len(alive))[:count] {
+		picked = append(picked, alive[index])
+	}
+	return picked
+}
+
+// navigateTargetGroups moves the group selection left or right
+func (bm *BattleManager) navigateTargetGroups(direction int) {
+	now := time.Now()
+	if now.Sub(bm.lastTargetChangeTime) < 150*time.Millisecond {
+		return
+	}
+
+	count := len(bm.targetGroups)
+	if direction > 0 {
+		bm.targetIndex = (bm.targetIndex + 1) % count
+	} else {
+		bm.targetIndex = (bm.targetIndex - 1 + count) % count
+	}
+	bm.lastTargetChangeTime = now
+}
+
+// IsSelectingGroup returns true while the player is choosing a group for a multi-target spell
+func (bm *BattleManager) IsSelectingGroup() bool {
+	return bm.state == BattleStateWaitingForTarget && len(bm.targetGroups) > 0
+}
+
+// GetSelectedTargets returns every entity the current selection would hit
+func (bm *BattleManager) GetSelectedTargets() []*ecs.Entity {
+	if bm.state != BattleStateWaitingForTarget {
+		return nil
+	}
+	if len(bm.targetGroups) > 0 {
+		return bm.targetGroups[bm.targetIndex]
+	}
+	if bm.selectedTarget != nil {
+		return []*ecs.Entity{bm.selectedTarget}
+	}
+	return nil
+}
+
+// IsTargetSelected returns true if the entity is part of the current target selection
+func (bm *BattleManager) IsTargetSelected(entity *ecs.Entity) bool {
+	for _, target := range bm.GetSelectedTargets() {
+		if target == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// executeHealAction restores HP to every living target of a healing spell
+func (bm *BattleManager) executeHealAction(action *BattleAction) {
+	caster := action.Entity.RPGStats()
+	targets := action.Targets
+	if len(targets) == 0 && action.Target != nil {
+		targets = []*ecs.Entity{action.Target}
+	}
+
+	for _, target := range livingEntities(targets) {
+		stats := target.RPGStats()
+		amount := action.Spell.Heal + caster.GetStat(components.StatMagicAttack)/4
+		if stats.CurrentHP+amount > stats.GetMaxHP() {
+			amount = stats.GetMaxHP() - stats.CurrentHP
+		}
+		stats.CurrentHP += amount
+
+		action.Results = append(action.Results, TargetResult{
+			Target:  target,
+			Healed:  amount,
+			HPAfter: stats.CurrentHP,
+			MaxHP:   stats.GetMaxHP(),
+		})
+
+		logger.Debug("💚 %s recovers %d HP! HP: %d/%d", target.GetID(), amount, stats.CurrentHP, stats.GetMaxHP())
+	}
+
+	action.recordFirstResult()
+}
@@ -177,19 +177,11 @@ func (tc *TacticalCombat) calculateInitiative(stats *components.RPGStatsComponen
 
 // calculateMoveRange determines how far a unit can move
 func (tc *TacticalCombat) calculateMoveRange(stats *components.RPGStatsComponent) int {
-	// Base movement range based on job/speed
-	baseMove := 3 // Default movement range
-
-	switch stats.Job {
-	case components.JobRogue:
-		baseMove = 4 // Rogues are faster
-	case components.JobMage:
-		baseMove = 2 // Mages are slower
-	case components.JobWarrior:
-		baseMove = 3 // Balanced
+	// Movement range comes from the job definition
+	if def := stats.Job.Definition(); def != nil {
+		return def.MoveRange
 	}
-
-	return baseMove
+	return constants.DefaultMoveRange // Default fallback
 }
 
 // FindStartingPosition finds an available starting position for an entity
@@ -234,20 +234,10 @@ func (cbm *TurnBasedCombatManager) initializeEntityForCombat(entity *ecs.Entity)
 
 // getMaxAPForJob returns the maximum AP for a given job class
 func (cbm *TurnBasedCombatManager) getMaxAPForJob(job components.JobType) int {
-	switch job {
-	case components.JobWarrior:
-		return constants.WarriorMaxAP
-	case components.JobMage:
-		return constants.MageMaxAP
-	case components.JobRogue:
-		return constants.RogueMaxAP
-	case components.JobCleric:
-		return constants.ClericMaxAP
-	case components.JobArcher:
-		return constants.ArcherMaxAP
-	default:
-		return constants.WarriorMaxAP // Default fallback
+	if def := job.Definition(); def != nil {
+		return def.MaxAP
 	}
+	return constants.DefaultMaxAP // Default fallback
 }
 
 // calculateEntityInitiative calculates initiative for a single entity
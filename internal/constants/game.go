@@ -18,12 +18,57 @@ const (
 	// Exploration Mode Movement
 	PlayerSpeed = 2.0 // Pixels per frame movement speed
 
-	// Tactical Movement Ranges by Job Class
-	WarriorMoveRange = 3
-	MageMoveRange    = 2
-	RogueMoveRange   = 5
-	ClericMoveRange  = 3
-	ArcherMoveRange  = 4
+	// Tactical movement range for jobs without one in their definition
+	DefaultMoveRange = 3
+)
+
+// Job Data Constants
+const (
+	JobDataFile          = "assets/data/jobs.json"           // Job definitions: stats, growth, move range, AP, equipment
+	SkillDataFile        = "assets/data/skills.json"         // Skill trees: skills, prerequisites, layout and effects
+	ShopDataFile         = "assets/data/shops.json"          // Shops: stock, quantities, restock timers and prices
+	RecipeDataFile       = "assets/data/recipes.json"        // Crafting recipes and the equipment upgrade rule
+	EquipmentSetDataFile = "assets/data/equipment_sets.json" // Equipment sets: members and 2/3/4 piece bonuses
+	AffixDataFile        = "assets/data/affixes.json"        // Loot rarity tiers and equipment prefix/suffix tables
+
+	// Passive skill effects read by game code
+	PassiveHPRegen = "HP_Regen" // HP regenerated at the start of each tactical turn
+
+	// Level progression for jobs without an XP curve or skill points in their definition
+	DefaultExpCurveBase        = 100 // Experience from level 1 to 2
+	DefaultExpCurveExponent    = 1.0 // Experience to next level = base * level^exponent
+	DefaultSkillPointsPerLevel = 2   // Skill points granted per level gained
+	InitialSkillPoints         = 5   // Skill points a character starts with
+
+	// Skill respec: refunding skill points costs gold per point, or one Tome of Forgetting
+	SkillRespecGoldPerPoint = 20  // Gold paid per refunded skill point
+	SkillRespecItemID       = 230 // Tome of Forgetting, consumed instead of paying gold
+)
+
+// Shop Constants
+const (
+	ShopPricePercent = 100 // Buy price as a percentage of the item value, for shops without one
+	ShopSellPercent  = 50  // Sell-back price as a percentage of the item value, for shops without one
+)
+
+// Chest Constants
+const (
+	ChestKeyItemID            = 240 // Iron Key, opens chests without their own key item
+	LockpickBaseChance        = 40  // Lockpick success percentage of a level 0 Rogue on a tier 0 lock
+	LockpickChancePerLevel    = 5   // Lockpick success percentage gained per Rogue level
+	LockpickChancePerLockTier = 15  // Lockpick success percentage lost per lock tier
+	LockpickMinChance         = 5   // Lockpick success percentage floor
+	LockpickMaxChance         = 95  // Lockpick success percentage ceiling
+)
+
+// Crafting Constants
+const (
+	CraftingSuccessRate = 100 // Success percentage of recipes without one
+)
+
+// Wallet Constants
+const (
+	WalletHistoryLimit = 50 // Transactions kept in the party wallet history
 )
 
 // Party and Combat Constants
@@ -59,33 +104,87 @@ const (
 
 // Action Point Constants for Turn-Based Combat
 const (
-	// Action Point Maximums by Job Class
-	WarriorMaxAP = 4
-	MageMaxAP    = 3
-	RogueMaxAP   = 5
-	ClericMaxAP  = 4
-	ArcherMaxAP  = 4
+	// Action Point maximum for jobs without one in their definition
+	DefaultMaxAP = 4
 
 	// Action Point Costs
 	MovementAPCost = 1 // 1 AP per tile moved
 	AttackAPCost   = 2 // 2 AP per attack action
 	ItemAPCost     = 1 // 1 AP per item used
+	AbilityAPCost  = 2 // AP per ability used when its skill declares no "ap_cost"
 	EndTurnAPCost  = 0 // Free action
 	WaitAPCost     = 0 // Free action
+
+	// Range in tiles of abilities whose skill declares no "range"
+	DefaultAbilityRange = 1
+)
+
+// Charge Time Constants for individual (CT-based) initiative
+const (
+	ChargeTimeThreshold  = 100 // CT a unit needs to take its turn
+	ChargeTimeBaseCost   = 60  // CT consumed by a turn where no AP was spent
+	ChargeTimeActionCost = 40  // Extra CT consumed when all AP is spent (scaled by AP used)
+
+	// Tactical initiative is Speed plus a random bonus below this value
+	InitiativeRandomRange = 10
+)
+
+// Battle Reward Constants
+const (
+	ExperiencePerEnemyLevel = 20 // Experience granted per level of a defeated enemy
+	GoldPerEnemyLevel       = 3  // Gold granted per level of an enemy without a loot table
+
+	// Defeat handling
+	DefeatGoldPenaltyPercent = 25 // Percentage of party gold lost when reviving at a rest point
+)
+
+// Stat Modifier Constants
+const (
+	ConsumableBuffBattles = 1 // Battles a consumable stat buff lasts (used outside battle: the next one)
+)
+
+// Classic Battle Command Constants
+const (
+	EscapeBaseChance     = 50  // Escape chance (%) when party and enemies are equally fast
+	EscapeSpeedFactor    = 2   // Escape chance (%) gained per point of average Speed advantage
+	EscapeMinChance      = 10  // Lowest escape chance (%) outside boss battles
+	EscapeMaxChance      = 95  // Highest escape chance (%)
+	EscapeGraceSeconds   = 3.0 // Seconds after an escape during which battle events do not trigger
+	DefaultSpellMPCost   = 5   // MP cost of the basic Magic of characters without learned spells
+	SpellListVisibleRows = 5   // Spell and item entries shown at once in the action panel
+
+	// Formation rows
+	BackRowMeleeDamagePercent = 50 // Melee damage (%) dealt and taken by back-row characters
+
+	// Active-time battle gauges
+	ATBGaugeMax        = 100.0 // Gauge value at which an entity can act
+	ATBFillPerSpeed    = 0.5   // Gauge points filled per second for each point of speed
+	ATBStartGaugeMax   = 50.0  // Highest random gauge value at the start of a battle
+	DefaultBattleSpeed = 1.0   // Default battle speed multiplier
+	BattleSpeedMin     = 0.5   // Slowest battle speed multiplier
+	BattleSpeedMax     = 3.0   // Fastest battle speed multiplier
+	BattleSpeedStep    = 0.5   // Battle speed change per key press
+)
+
+// Elemental Damage Constants
+const (
+	ElementWeakDamagePercent   = 150 // Damage (%) taken from an element the target is weak to
+	ElementResistDamagePercent = 50  // Damage (%) taken from an element the target resists
 )
 
 // Event System Color Constants
 // These colors are used for event entities when no custom sprite is provided
 var (
 	// Event Type Colors (RGB values)
-	EventColorBattle   = [3]uint8{200, 50, 50}   // Red-ish color for battle events
-	EventColorDialog   = [3]uint8{50, 150, 200}  // Blue-ish color for dialog events
-	EventColorChest    = [3]uint8{200, 200, 50}  // Yellow-ish color for chest events
-	EventColorDoor     = [3]uint8{150, 100, 50}  // Brown-ish color for door events
-	EventColorTrap     = [3]uint8{150, 50, 150}  // Purple-ish color for trap events
-	EventColorInfo     = [3]uint8{100, 200, 100} // Green-ish color for info events
-	EventColorQuest    = [3]uint8{255, 165, 0}   // Orange color for quest events
-	EventColorCutscene = [3]uint8{100, 100, 200} // Purple-blue for cutscene events
-	EventColorShop     = [3]uint8{200, 200, 200} // Silver-ish color for shop events
-	EventColorRest     = [3]uint8{100, 150, 255} // Light blue for rest events
+	EventColorBattle    = [3]uint8{200, 50, 50}   // Red-ish color for battle events
+	EventColorDialog    = [3]uint8{50, 150, 200}  // Blue-ish color for dialog events
+	EventColorChest     = [3]uint8{200, 200, 50}  // Yellow-ish color for chest events
+	EventColorDoor      = [3]uint8{150, 100, 50}  // Brown-ish color for door events
+	EventColorTrap      = [3]uint8{150, 50, 150}  // Purple-ish color for trap events
+	EventColorInfo      = [3]uint8{100, 200, 100} // Green-ish color for info events
+	EventColorQuest     = [3]uint8{255, 165, 0}   // Orange color for quest events
+	EventColorCutscene  = [3]uint8{100, 100, 200} // Purple-blue for cutscene events
+	EventColorShop      = [3]uint8{200, 200, 200} // Silver-ish color for shop events
+	EventColorRest      = [3]uint8{100, 150, 255} // Light blue for rest events
+	EventColorJobChange = [3]uint8{230, 230, 255} // Pale white for job shrine events
 )
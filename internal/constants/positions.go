@@ -24,6 +24,18 @@ const (
 	Enemy5StartY = 250.0
 	Enemy6StartX = 550.0
 	Enemy6StartY = 300.0
+
+	// Job Shrine Position
+	JobShrineX = 600.0
+	JobShrineY = 250.0
+
+	// Shop Position
+	ShopX = 650.0
+	ShopY = 300.0
+
+	// Locked Chest Position
+	LockedChestX = 700.0
+	LockedChestY = 250.0
 )
 
 // Exploration Mode Positioning
@@ -0,0 +1,148 @@
+// Package crafting provides recipes that turn materials into items and equipment upgrades,
+// both with configurable success rates and failure outcomes
+package crafting
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// Wallet holds the gold crafting and upgrades are paid with
+type Wallet interface {
+	GetGold() int
+	SpendGold(amount int, reason string) error
+}
+
+// FailureOutcome is what a failed craft or upgrade costs on top of its gold
+type FailureOutcome string
+
+const (
+	FailureKeepMaterials FailureOutcome = "keep_materials" // Nothing but the gold is lost
+	FailureLoseHalf      FailureOutcome = "lose_half"      // Half of each ingredient (rounded up) is lost
+	FailureLoseMaterials FailureOutcome = "lose_materials" // Every ingredient is lost
+	FailureDowngrade     FailureOutcome = "downgrade"      // Upgrades only: the equipment loses one level
+	FailureDestroy       FailureOutcome = "destroy"        // Upgrades only: the equipment is destroyed
+)
+
+// Result describes the outcome of a craft or upgrade attempt that was paid for
+type Result struct {
+	Success   bool   // Whether the attempt succeeded
+	Destroyed bool   // Whether a failed upgrade destroyed the equipment
+	Message   string // Outcome shown to the player
+}
+
+// Ingredient is an item and quantity consumed by a recipe
+type Ingredient struct {
+	Item     *components.Item // Item registry template
+	Quantity int              // Units consumed
+}
+
+// Recipe turns ingredients and gold into an item
+type Recipe struct {
+	ID             string           // Recipe ID
+	Name           string           // Display name
+	Ingredients    []Ingredient     // Items consumed
+	Result         *components.Item // Item template crafted
+	ResultQuantity int              // Units crafted
+	GoldCost       int              // Gold paid per attempt
+	SuccessRate    int              // Success percentage (1-100)
+	Failure        FailureOutcome   // What a failed attempt costs
+}
+
+// MissingIngredients returns a line per ingredient the inventory lacks, formatted as
+// "Name owned/needed"
+func (r *Recipe) MissingIngredients(inventory *components.InventoryComponent) []string {
+	missing := make([]string, 0)
+	for _, ingredient := range r.Ingredients {
+		if owned := inventory.GetItemCount(ingredient.Item.ID); owned < ingredient.Quantity {
+			missing = append(missing, fmt.Sprintf("%s %d/%d", ingredient.Item.Name, owned, ingredient.Quantity))
+		}
+	}
+	return missing
+}
+
+// CanCraft returns an error if the recipe cannot be attempted with an inventory and wallet
+func (r *Recipe) CanCraft(inventory *components.InventoryComponent, wallet Wallet) error {
+	if missing := r.MissingIngredients(inventory); len(missing) > 0 {
+		return fmt.Errorf("missing %s", missing[0])
+	}
+	if wallet.GetGold() < r.GoldCost {
+		return fmt.Errorf("not enough gold (%d needed)", r.GoldCost)
+	}
+	return nil
+}
+
+// Craft attempts the recipe. On success the ingredients are consumed and the result is
+// added to the inventory; on failure the recipe's failure outcome is applied. The gold is
+// paid either way, unless the crafted items do not fit in the inventory.
+func (r *Recipe) Craft(inventory *components.InventoryComponent, wallet Wallet, rng *rand.Rand) (*Result, error) {
+	if err := r.CanCraft(inventory, wallet); err != nil {
+		return nil, err
+	}
+
+	if !roll(rng, r.SuccessRate) {
+		if err := r.pay(wallet); err != nil {
+			return nil, err
+		}
+		lost := r.applyFailure(inventory)
+		return &Result{Message: fmt.Sprintf("Crafting %s failed%s", r.Result.Name, lost)}, nil
+	}
+
+	r.consume(inventory, 1, 1)
+	if overflow := inventory.AddItem(components.GlobalItemRegistry.CreateItem(r.Result.ID), r.ResultQuantity); overflow > 0 {
+		// Undo the craft so nothing is lost to a full inventory
+		inventory.RemoveItem(r.Result.ID, r.ResultQuantity-overflow)
+		r.restore(inventory)
+		return nil, fmt.Errorf("inventory is full")
+	}
+	if err := r.pay(wallet); err != nil {
+		return nil, err
+	}
+	return &Result{Success: true, Message: fmt.Sprintf("Crafted %d x %s", r.ResultQuantity, r.Result.Name)}, nil
+}
+
+// pay takes the recipe's gold cost from the wallet
+func (r *Recipe) pay(wallet Wallet) error {
+	if r.GoldCost == 0 {
+		return nil
+	}
+	return wallet.SpendGold(r.GoldCost, fmt.Sprintf("Crafted %s", r.Result.Name))
+}
+
+// applyFailure consumes the ingredients lost by a failed attempt and describes the loss
+func (r *Recipe) applyFailure(inventory *components.InventoryComponent) string {
+	switch r.Failure {
+	case FailureLoseMaterials:
+		r.consume(inventory, 1, 1)
+		return ", the materials were lost"
+	case FailureLoseHalf:
+		r.consume(inventory, 1, 2)
+		return ", half of the materials were lost"
+	default:
+		return ", the materials were kept"
+	}
+}
+
+// consume removes a fraction (num/den, rounded up) of every ingredient
+func (r *Recipe) consume(inventory *components.InventoryComponent, num, den int) {
+	for _, ingredient := range r.Ingredients {
+		inventory.RemoveItem(ingredient.Item.ID, (ingredient.Quantity*num+den-1)/den)
+	}
+}
+
+// restore gives back the ingredients removed by consume
+func (r *Recipe) restore(inventory *components.InventoryComponent) {
+	for _, ingredient := range r.Ingredients {
+		inventory.AddItem(components.GlobalItemRegistry.CreateItem(ingredient.Item.ID), ingredient.Quantity)
+	}
+}
+
+// roll returns true with a percentage chance
+func roll(rng *rand.Rand, chance int) bool {
+	if chance >= 100 {
+		return true
+	}
+	return rng.Intn(100) < chance
+}
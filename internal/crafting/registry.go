@@ -0,0 +1,272 @@
+// Package crafting provides the crafting registry loaded from the recipe data file
+package crafting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// CraftingRegistry holds every recipe, keyed by recipe ID, and the equipment upgrade rule
+type CraftingRegistry struct {
+	recipes map[string]*Recipe
+	upgrade *UpgradeRule
+}
+
+// NewCraftingRegistry creates an empty crafting registry without upgrades
+func NewCraftingRegistry() *CraftingRegistry {
+	return &CraftingRegistry{
+		recipes: make(map[string]*Recipe),
+	}
+}
+
+// RegisterRecipe adds a recipe to the registry
+func (cr *CraftingRegistry) RegisterRecipe(recipe *Recipe) error {
+	if _, exists := cr.recipes[recipe.ID]; exists {
+		return fmt.Errorf("recipe %s already exists", recipe.ID)
+	}
+	cr.recipes[recipe.ID] = recipe
+	return nil
+}
+
+// GetRecipe returns a recipe by ID
+func (cr *CraftingRegistry) GetRecipe(id string) (*Recipe, bool) {
+	recipe, exists := cr.recipes[id]
+	return recipe, exists
+}
+
+// GetAllRecipes returns every recipe sorted by name
+func (cr *CraftingRegistry) GetAllRecipes() []*Recipe {
+	recipes := make([]*Recipe, 0, len(cr.recipes))
+	for _, recipe := range cr.recipes {
+		recipes = append(recipes, recipe)
+	}
+	sort.Slice(recipes, func(i, j int) bool {
+		return recipes[i].Name < recipes[j].Name
+	})
+	return recipes
+}
+
+// GetUpgradeRule returns the equipment upgrade rule, nil if upgrades are not defined
+func (cr *CraftingRegistry) GetUpgradeRule() *UpgradeRule {
+	return cr.upgrade
+}
+
+// ingredientData is the layout of a recipe ingredient in the recipe data file
+type ingredientData struct {
+	ItemID   int `json:"item_id"`  // Item registry ID
+	Quantity int `json:"quantity"` // Units consumed (default: 1)
+}
+
+// recipeData is the layout of a recipe in the recipe data file
+type recipeData struct {
+	ID             string           `json:"id"`              // Recipe ID
+	Name           string           `json:"name"`            // Display name (default: result item name)
+	Ingredients    []ingredientData `json:"ingredients"`     // Items consumed
+	ResultID       int              `json:"result_id"`       // Item registry ID of the crafted item
+	ResultQuantity int              `json:"result_quantity"` // Units crafted (default: 1)
+	GoldCost       int              `json:"gold_cost"`       // Gold paid per attempt
+	SuccessRate    int              `json:"success_rate"`    // Success percentage (default: constants.CraftingSuccessRate)
+	Failure        string           `json:"failure"`         // keep_materials, lose_half or lose_materials (default: keep_materials)
+}
+
+// upgradeData is the layout of the upgrade rule in the recipe data file
+type upgradeData struct {
+	MaxLevel         int    `json:"max_level"`          // Highest upgrade level
+	GoldPerLevel     int    `json:"gold_per_level"`     // Gold per target level
+	MaterialID       int    `json:"material_id"`        // Item registry ID of the material (0 = none)
+	MaterialPerLevel int    `json:"material_per_level"` // Material units per target level
+	StatPercent      int    `json:"stat_percent"`       // Percentage of the base bonuses gained per level
+	SuccessRates     []int  `json:"success_rates"`      // Success percentage per target level
+	Failure          string `json:"failure"`            // keep_materials, lose_materials, downgrade or destroy
+}
+
+// recipeDataFile is the layout of the recipe data file
+type recipeDataFile struct {
+	Recipes []recipeData `json:"recipes"`
+	Upgrade *upgradeData `json:"upgrade"`
+}
+
+// recipeFailures are the failure outcomes a recipe can use
+var recipeFailures = map[FailureOutcome]bool{
+	FailureKeepMaterials: true,
+	FailureLoseHalf:      true,
+	FailureLoseMaterials: true,
+}
+
+// upgradeFailures are the failure outcomes the upgrade rule can use
+var upgradeFailures = map[FailureOutcome]bool{
+	FailureKeepMaterials: true,
+	FailureLoseMaterials: true,
+	FailureDowngrade:     true,
+	FailureDestroy:       true,
+}
+
+// LoadFile registers every recipe and the upgrade rule of a recipe data file. Items are
+// looked up in the global item registry, which must be initialized first.
+func (cr *CraftingRegistry) LoadFile(path string) error {
+	if components.GlobalItemRegistry == nil {
+		return fmt.Errorf("item registry not initialized")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read recipe data: %v", err)
+	}
+
+	var file recipeDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse recipe data: %v", err)
+	}
+
+	for _, entry := range file.Recipes {
+		recipe, err := entry.toRecipe()
+		if err != nil {
+			return err
+		}
+		if err := cr.RegisterRecipe(recipe); err != nil {
+			return err
+		}
+	}
+
+	if file.Upgrade != nil {
+		upgrade, err := file.Upgrade.toUpgradeRule()
+		if err != nil {
+			return err
+		}
+		cr.upgrade = upgrade
+	}
+	return nil
+}
+
+// toRecipe converts a data file recipe into a recipe
+func (rd *recipeData) toRecipe() (*Recipe, error) {
+	if rd.ID == "" {
+		return nil, fmt.Errorf("recipe without id")
+	}
+	result := components.GlobalItemRegistry.GetItem(rd.ResultID)
+	if result == nil {
+		return nil, fmt.Errorf("recipe %s: unknown result item %d", rd.ID, rd.ResultID)
+	}
+	if len(rd.Ingredients) == 0 {
+		return nil, fmt.Errorf("recipe %s: no ingredients", rd.ID)
+	}
+	if rd.GoldCost < 0 || rd.ResultQuantity < 0 || rd.SuccessRate < 0 || rd.SuccessRate > 100 {
+		return nil, fmt.Errorf("recipe %s: invalid cost, quantity or success rate", rd.ID)
+	}
+
+	recipe := &Recipe{
+		ID:             rd.ID,
+		Name:           rd.Name,
+		Ingredients:    make([]Ingredient, 0, len(rd.Ingredients)),
+		Result:         result,
+		ResultQuantity: rd.ResultQuantity,
+		GoldCost:       rd.GoldCost,
+		SuccessRate:    rd.SuccessRate,
+		Failure:        FailureOutcome(rd.Failure),
+	}
+	if recipe.Name == "" {
+		recipe.Name = result.Name
+	}
+	if recipe.ResultQuantity == 0 {
+		recipe.ResultQuantity = 1
+	}
+	if recipe.SuccessRate == 0 {
+		recipe.SuccessRate = constants.CraftingSuccessRate
+	}
+	if recipe.Failure == "" {
+		recipe.Failure = FailureKeepMaterials
+	}
+	if !recipeFailures[recipe.Failure] {
+		return nil, fmt.Errorf("recipe %s: unknown failure outcome %s", rd.ID, rd.Failure)
+	}
+
+	for _, ingredient := range rd.Ingredients {
+		item := components.GlobalItemRegistry.GetItem(ingredient.ItemID)
+		if item == nil {
+			return nil, fmt.Errorf("recipe %s: unknown ingredient %d", rd.ID, ingredient.ItemID)
+		}
+		quantity := ingredient.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		if quantity < 0 {
+			return nil, fmt.Errorf("recipe %s: ingredient %d has a negative quantity", rd.ID, ingredient.ItemID)
+		}
+		recipe.Ingredients = append(recipe.Ingredients, Ingredient{Item: item, Quantity: quantity})
+	}
+	return recipe, nil
+}
+
+// toUpgradeRule converts the data file upgrade rule into an upgrade rule
+func (ud *upgradeData) toUpgradeRule() (*UpgradeRule, error) {
+	if ud.MaxLevel <= 0 {
+		return nil, fmt.Errorf("upgrade: max_level must be positive")
+	}
+	if ud.GoldPerLevel < 0 || ud.MaterialPerLevel < 0 || ud.StatPercent <= 0 {
+		return nil, fmt.Errorf("upgrade: invalid cost or stat percent")
+	}
+	for _, rate := range ud.SuccessRates {
+		if rate <= 0 || rate > 100 {
+			return nil, fmt.Errorf("upgrade: success rate %d out of range", rate)
+		}
+	}
+
+	rule := &UpgradeRule{
+		MaxLevel:         ud.MaxLevel,
+		GoldPerLevel:     ud.GoldPerLevel,
+		MaterialPerLevel: ud.MaterialPerLevel,
+		StatPercent:      ud.StatPercent,
+		SuccessRates:     ud.SuccessRates,
+		Failure:          FailureOutcome(ud.Failure),
+	}
+	if rule.Failure == "" {
+		rule.Failure = FailureKeepMaterials
+	}
+	if !upgradeFailures[rule.Failure] {
+		return nil, fmt.Errorf("upgrade: unknown failure outcome %s", ud.Failure)
+	}
+	if ud.MaterialID != 0 {
+		rule.Material = components.GlobalItemRegistry.GetItem(ud.MaterialID)
+		if rule.Material == nil {
+			return nil, fmt.Errorf("upgrade: unknown material %d", ud.MaterialID)
+		}
+	}
+	return rule, nil
+}
+
+// Global crafting registry instance
+var GlobalCraftingRegistry *CraftingRegistry
+
+// LoadCraftingRegistry loads the global crafting registry from a recipe data file
+func LoadCraftingRegistry(path string) error {
+	registry := NewCraftingRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalCraftingRegistry = registry
+	return nil
+}
+
+// InitializeCraftingRegistry loads the global crafting registry from the default recipe
+// data file, leaving it empty if the file cannot be loaded
+func InitializeCraftingRegistry() {
+	if err := LoadCraftingRegistry(constants.RecipeDataFile); err != nil {
+		logger.Warn("Failed to load recipe data: %v", err)
+		GlobalCraftingRegistry = NewCraftingRegistry()
+	}
+}
+
+// GetGlobalCraftingRegistry returns the global crafting registry, loading the default recipe
+// data file if needed
+func GetGlobalCraftingRegistry() *CraftingRegistry {
+	if GlobalCraftingRegistry == nil {
+		InitializeCraftingRegistry()
+	}
+	return GlobalCraftingRegistry
+}
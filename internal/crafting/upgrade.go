@@ -0,0 +1,136 @@
+// Package crafting provides equipment upgrades that raise equipment stats one level at a time
+package crafting
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// upgradeSuffix matches the " +N" suffix of upgraded equipment names
+var upgradeSuffix = regexp.MustCompile(` \+\d+$`)
+
+// UpgradeRule defines how equipment is upgraded: costs, stat gains and success rates
+type UpgradeRule struct {
+	MaxLevel         int              // Highest upgrade level
+	GoldPerLevel     int              // Gold per target level (+3 costs 3x)
+	Material         *components.Item // Material consumed per attempt (nil = none)
+	MaterialPerLevel int              // Material units per target level
+	StatPercent      int              // Percentage of the base bonuses gained per level
+	SuccessRates     []int            // Success percentage per target level (+1 first), the last one repeats
+	Failure          FailureOutcome   // What a failed upgrade costs: keep_materials, lose_materials, downgrade or destroy
+}
+
+// Cost returns the gold and material units paid to upgrade equipment to its next level
+func (ur *UpgradeRule) Cost(equipment *components.Equipment) (int, int) {
+	next := equipment.UpgradeLevel + 1
+	return ur.GoldPerLevel * next, ur.MaterialPerLevel * next
+}
+
+// SuccessRate returns the success percentage of upgrading equipment to its next level
+func (ur *UpgradeRule) SuccessRate(equipment *components.Equipment) int {
+	if len(ur.SuccessRates) == 0 {
+		return 100
+	}
+	index := equipment.UpgradeLevel
+	if index >= len(ur.SuccessRates) {
+		index = len(ur.SuccessRates) - 1
+	}
+	return ur.SuccessRates[index]
+}
+
+// CanUpgrade returns an error if the equipment cannot be upgraded with an inventory and wallet
+func (ur *UpgradeRule) CanUpgrade(equipment *components.Equipment, inventory *components.InventoryComponent, wallet Wallet) error {
+	if equipment.UpgradeLevel >= ur.MaxLevel {
+		return fmt.Errorf("%s is fully upgraded", equipment.Name)
+	}
+	gold, materials := ur.Cost(equipment)
+	if ur.Material != nil && materials > 0 {
+		if owned := inventory.GetItemCount(ur.Material.ID); owned < materials {
+			return fmt.Errorf("missing %s %d/%d", ur.Material.Name, owned, materials)
+		}
+	}
+	if wallet.GetGold() < gold {
+		return fmt.Errorf("not enough gold (%d needed)", gold)
+	}
+	return nil
+}
+
+// Upgrade pays for and attempts an upgrade of the equipment to its next level. On success
+// the equipment gains the upgrade step of its base bonuses; on failure the rule's failure
+// outcome is applied. A destroyed piece of equipment must be removed by the caller.
+func (ur *UpgradeRule) Upgrade(equipment *components.Equipment, inventory *components.InventoryComponent, wallet Wallet, rng *rand.Rand) (*Result, error) {
+	if err := ur.CanUpgrade(equipment, inventory, wallet); err != nil {
+		return nil, err
+	}
+
+	gold, materials := ur.Cost(equipment)
+	if gold > 0 {
+		if err := wallet.SpendGold(gold, fmt.Sprintf("Upgraded %s", equipment.Name)); err != nil {
+			return nil, err
+		}
+	}
+	keepMaterials := ur.Failure == FailureKeepMaterials
+	success := roll(rng, ur.SuccessRate(equipment))
+	if ur.Material != nil && materials > 0 && (success || !keepMaterials) {
+		inventory.RemoveItem(ur.Material.ID, materials)
+	}
+
+	if success {
+		ur.setLevel(equipment, equipment.UpgradeLevel+1)
+		return &Result{Success: true, Message: fmt.Sprintf("Upgraded to %s", equipment.Name)}, nil
+	}
+
+	switch ur.Failure {
+	case FailureDestroy:
+		return &Result{Destroyed: true, Message: fmt.Sprintf("Upgrade failed, %s was destroyed", equipment.Name)}, nil
+	case FailureDowngrade:
+		if equipment.UpgradeLevel > 0 {
+			ur.setLevel(equipment, equipment.UpgradeLevel-1)
+			return &Result{Message: fmt.Sprintf("Upgrade failed, dropped to %s", equipment.Name)}, nil
+		}
+	}
+	return &Result{Message: fmt.Sprintf("Upgrade of %s failed", equipment.Name)}, nil
+}
+
+// setLevel moves equipment to an upgrade level, adding or removing the upgrade step of its
+// base bonuses for every level changed and renaming it "Name +N". Upgraded equipment becomes
+// an item instance, so it no longer matches unmodified copies.
+func (ur *UpgradeRule) setLevel(equipment *components.Equipment, level int) {
+	if equipment.InstanceID == 0 {
+		equipment.InstanceID = components.NewInstanceID()
+	}
+	step := ur.UpgradeStep(equipment)
+	for equipment.UpgradeLevel < level {
+		equipment.Stats = equipment.Stats.Add(step)
+		equipment.UpgradeLevel++
+	}
+	for equipment.UpgradeLevel > level {
+		equipment.Stats = equipment.Stats.Sub(step)
+		equipment.UpgradeLevel--
+	}
+	equipment.Name = UpgradedName(equipment.Name, equipment.UpgradeLevel)
+}
+
+// UpgradeStep returns the bonuses gained per level, computed from the registered item so
+// every level adds the same amount
+func (ur *UpgradeRule) UpgradeStep(equipment *components.Equipment) components.EquipmentStats {
+	base := equipment.Stats
+	if components.GlobalItemRegistry != nil {
+		if template := components.GlobalItemRegistry.GetItem(equipment.ID); template != nil && template.Equipment != nil {
+			base = template.Equipment.Stats
+		}
+	}
+	return base.UpgradeStep(ur.StatPercent)
+}
+
+// UpgradedName returns an equipment name with the " +N" suffix of an upgrade level
+func UpgradedName(name string, level int) string {
+	name = upgradeSuffix.ReplaceAllString(name, "")
+	if level <= 0 {
+		return name
+	}
+	return fmt.Sprintf("%s +%d", name, level)
+}
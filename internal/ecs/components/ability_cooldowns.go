@@ -0,0 +1,125 @@
+// Package components provides the per-character cooldown and charge tracking of active abilities
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AbilityCooldowns tracks the turns left before each active ability can be used again
+// and the charges used in the current battle
+type AbilityCooldowns struct {
+	Remaining   map[string]int // Turns left on cooldown, keyed by skill ID
+	ChargesUsed map[string]int // Uses in the current battle, keyed by skill ID
+}
+
+// NewAbilityCooldowns creates a tracker with every ability ready
+func NewAbilityCooldowns() *AbilityCooldowns {
+	return &AbilityCooldowns{
+		Remaining:   make(map[string]int),
+		ChargesUsed: make(map[string]int),
+	}
+}
+
+// Reset makes every ability ready and restores all charges, called when a battle starts
+func (ac *AbilityCooldowns) Reset() {
+	ac.Remaining = make(map[string]int)
+	ac.ChargesUsed = make(map[string]int)
+}
+
+// Tick counts a turn of the character, bringing every cooldown one turn closer to ready
+func (ac *AbilityCooldowns) Tick() {
+	for skillID, turns := range ac.Remaining {
+		if turns <= 1 {
+			delete(ac.Remaining, skillID)
+		} else {
+			ac.Remaining[skillID] = turns - 1
+		}
+	}
+}
+
+// GetRemaining returns the turns left before an ability can be used again, 0 if ready
+func (ac *AbilityCooldowns) GetRemaining(skillID string) int {
+	return ac.Remaining[skillID]
+}
+
+// GetChargesLeft returns the uses of an ability left in this battle, -1 for unlimited
+func (ac *AbilityCooldowns) GetChargesLeft(skill *Skill) int {
+	if skill.Charges <= 0 {
+		return -1
+	}
+	left := skill.Charges - ac.ChargesUsed[skill.ID]
+	if left < 0 {
+		left = 0
+	}
+	return left
+}
+
+// CanUse returns an error if an ability is cooling down or out of charges
+func (ac *AbilityCooldowns) CanUse(skill *Skill) error {
+	if turns := ac.GetRemaining(skill.ID); turns > 0 {
+		return fmt.Errorf("%s is cooling down (%d turns)", skill.Name, turns)
+	}
+	if ac.GetChargesLeft(skill) == 0 {
+		return fmt.Errorf("%s has no charges left", skill.Name)
+	}
+	return nil
+}
+
+// Use starts an ability's cooldown and spends one of its charges
+func (ac *AbilityCooldowns) Use(skill *Skill) {
+	if skill.Cooldown > 0 {
+		ac.Remaining[skill.ID] = skill.Cooldown
+	}
+	if skill.Charges > 0 {
+		ac.ChargesUsed[skill.ID]++
+	}
+}
+
+// Status returns a short cooldown and charge summary of an ability ("Ready", "CD 2", "1/2")
+func (ac *AbilityCooldowns) Status(skill *Skill) string {
+	parts := make([]string, 0, 2)
+	if turns := ac.GetRemaining(skill.ID); turns > 0 {
+		parts = append(parts, fmt.Sprintf("CD %d", turns))
+	}
+	if left := ac.GetChargesLeft(skill); left >= 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d", left, skill.Charges))
+	}
+	if len(parts) == 0 {
+		return "Ready"
+	}
+	return strings.Join(parts, " ")
+}
+
+// CanUseAbility returns an error if a learned active ability is cooling down or out of charges
+func (sc *SkillsComponent) CanUseAbility(skill *Skill) error {
+	return sc.cooldowns().CanUse(skill)
+}
+
+// UseAbility starts the cooldown of an ability and spends one of its charges
+func (sc *SkillsComponent) UseAbility(skill *Skill) {
+	sc.cooldowns().Use(skill)
+}
+
+// TickCooldowns counts a turn of the character for every ability cooldown
+func (sc *SkillsComponent) TickCooldowns() {
+	sc.cooldowns().Tick()
+}
+
+// ResetCooldowns makes every ability ready with full charges for a new battle
+func (sc *SkillsComponent) ResetCooldowns() {
+	sc.cooldowns().Reset()
+}
+
+// GetAbilityStatus returns the cooldown and charge summary of an ability
+func (sc *SkillsComponent) GetAbilityStatus(skill *Skill) string {
+	return sc.cooldowns().Status(skill)
+}
+
+// cooldowns returns the cooldown tracker, creating it for components built without one
+func (sc *SkillsComponent) cooldowns() *AbilityCooldowns {
+	if sc.Cooldowns == nil {
+		sc.Cooldowns = NewAbilityCooldowns()
+	}
+	return sc.Cooldowns
+}
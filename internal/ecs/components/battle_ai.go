@@ -0,0 +1,66 @@
+// Package components provides battle AI settings that drive enemy decisions in classic battles
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TargetStrategy selects which party member an enemy attacks
+type TargetStrategy int
+
+const (
+	TargetRandom        TargetStrategy = iota // Any living target
+	TargetLowestHP                            // Target with the lowest current HP
+	TargetHighestThreat                       // Target that dealt the most damage this battle
+	TargetHealerFirst                         // Clerics first, then any target
+	TargetFrontRow                            // Front-row targets first, then any target
+)
+
+// String returns the string representation of a TargetStrategy
+func (ts TargetStrategy) String() string {
+	switch ts {
+	case TargetRandom:
+		return "random"
+	case TargetLowestHP:
+		return "lowest_hp"
+	case TargetHighestThreat:
+		return "highest_threat"
+	case TargetHealerFirst:
+		return "healer_first"
+	case TargetFrontRow:
+		return "front_row"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseTargetStrategy converts a strategy name from enemy definitions into a TargetStrategy
+func ParseTargetStrategy(name string) (TargetStrategy, error) {
+	for _, strategy := range []TargetStrategy{
+		TargetRandom, TargetLowestHP, TargetHighestThreat, TargetHealerFirst, TargetFrontRow,
+	} {
+		if strategy.String() == strings.ToLower(strings.TrimSpace(name)) {
+			return strategy, nil
+		}
+	}
+	return TargetRandom, fmt.Errorf("unknown target strategy: %s", name)
+}
+
+// BattleAIComponent describes how an enemy picks its actions and targets
+type BattleAIComponent struct {
+	Strategy        TargetStrategy // How targets are chosen
+	MagicChance     int            // Chance (%) to cast magic when MP allows it
+	DefendHPPercent int            // Defending is considered below this HP percentage
+	DefendChance    int            // Chance (%) to defend when HP is low
+}
+
+// NewBattleAIComponent creates a battle AI with the given strategy and default action chances
+func NewBattleAIComponent(strategy TargetStrategy) *BattleAIComponent {
+	return &BattleAIComponent{
+		Strategy:        strategy,
+		MagicChance:     30,
+		DefendHPPercent: 25,
+		DefendChance:    40,
+	}
+}
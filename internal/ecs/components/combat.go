@@ -46,6 +46,7 @@ type CombatStateComponent struct {
 	Team       Team // Which team does this unit belong to
 	Initiative int  // Initiative value for turn order
 	CanAct     bool // Can this unit still act (not stunned, etc.)
+	ChargeTime int  // Charge time gauge for CT-based initiative
 }
 
 // Team represents which side a unit fights for
@@ -0,0 +1,207 @@
+// Package components provides elemental damage types and the affinities that scale them
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+)
+
+// Element is the elemental type of a skill, weapon or consumable
+type Element int
+
+const (
+	ElementNone      Element = iota // Non-elemental damage
+	ElementFire                     // Fire damage
+	ElementIce                      // Ice damage
+	ElementLightning                // Lightning damage
+	ElementHoly                     // Holy damage
+	ElementDark                     // Dark damage
+	ElementPoison                   // Poison damage
+	elementCount                    // Number of elements
+)
+
+func (e Element) String() string {
+	switch e {
+	case ElementNone:
+		return "None"
+	case ElementFire:
+		return "Fire"
+	case ElementIce:
+		return "Ice"
+	case ElementLightning:
+		return "Lightning"
+	case ElementHoly:
+		return "Holy"
+	case ElementDark:
+		return "Dark"
+	case ElementPoison:
+		return "Poison"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseElement converts an element name from data files (case-insensitive) to an Element.
+// An empty name is ElementNone.
+func ParseElement(name string) (Element, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ElementNone, nil
+	}
+	for element := ElementNone; element < elementCount; element++ {
+		if strings.EqualFold(element.String(), name) {
+			return element, nil
+		}
+	}
+	return ElementNone, fmt.Errorf("unknown element: %s", name)
+}
+
+// ElementAffinity tells how a character reacts to an element
+type ElementAffinity int
+
+const (
+	AffinityNormal ElementAffinity = iota // Full damage
+	AffinityWeak                          // Increased damage
+	AffinityResist                        // Reduced damage
+	AffinityImmune                        // No damage
+	AffinityAbsorb                        // Damage heals instead
+)
+
+func (a ElementAffinity) String() string {
+	switch a {
+	case AffinityNormal:
+		return "Normal"
+	case AffinityWeak:
+		return "Weak"
+	case AffinityResist:
+		return "Resist"
+	case AffinityImmune:
+		return "Immune"
+	case AffinityAbsorb:
+		return "Absorb"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseElementAffinity converts an affinity name from data files (case-insensitive) to an ElementAffinity
+func ParseElementAffinity(name string) (ElementAffinity, error) {
+	for affinity := AffinityNormal; affinity <= AffinityAbsorb; affinity++ {
+		if strings.EqualFold(affinity.String(), strings.TrimSpace(name)) {
+			return affinity, nil
+		}
+	}
+	return AffinityNormal, fmt.Errorf("unknown element affinity: %s", name)
+}
+
+// protection ranks affinities from the most damage taken to the least
+func (a ElementAffinity) protection() int {
+	switch a {
+	case AffinityWeak:
+		return 0
+	case AffinityResist:
+		return 2
+	case AffinityImmune:
+		return 3
+	case AffinityAbsorb:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// ApplyDamage scales damage by the affinity. A negative result is HP the target absorbs.
+func (a ElementAffinity) ApplyDamage(damage int) int {
+	switch a {
+	case AffinityWeak:
+		return damage * constants.ElementWeakDamagePercent / 100
+	case AffinityResist:
+		return damage * constants.ElementResistDamagePercent / 100
+	case AffinityImmune:
+		return 0
+	case AffinityAbsorb:
+		return -damage
+	default:
+		return damage
+	}
+}
+
+// BattleLogTag returns the battle log callout of the affinity, empty for normal damage
+func (a ElementAffinity) BattleLogTag() string {
+	switch a {
+	case AffinityWeak:
+		return "Weak!"
+	case AffinityResist:
+		return "Resist"
+	case AffinityImmune:
+		return "Immune"
+	case AffinityAbsorb:
+		return "Absorb"
+	default:
+		return ""
+	}
+}
+
+// BattleLogCallout returns the battle log tag of the affinity prefixed by a space, to append
+// to a damage line ("deals 12 damage Weak!"), empty for normal damage
+func (a ElementAffinity) BattleLogCallout() string {
+	if tag := a.BattleLogTag(); tag != "" {
+		return " " + tag
+	}
+	return ""
+}
+
+// ElementProfile maps elements to affinities, elements not listed are normal
+type ElementProfile map[Element]ElementAffinity
+
+// ParseElementProfile converts element/affinity names from data files to an ElementProfile
+func ParseElementProfile(names map[string]string) (ElementProfile, error) {
+	profile := make(ElementProfile)
+	for elementName, affinityName := range names {
+		element, err := ParseElement(elementName)
+		if err != nil {
+			return nil, err
+		}
+		affinity, err := ParseElementAffinity(affinityName)
+		if err != nil {
+			return nil, err
+		}
+		profile[element] = affinity
+	}
+	return profile, nil
+}
+
+// CombineAffinities returns the most protective of the affinities: absorb beats immune,
+// immune beats resist, and any listed protection overrides a weakness
+func CombineAffinities(affinities ...ElementAffinity) ElementAffinity {
+	result := AffinityNormal
+	listed := false
+	for _, affinity := range affinities {
+		if affinity == AffinityNormal {
+			continue
+		}
+		if !listed || affinity.protection() > result.protection() {
+			result = affinity
+			listed = true
+		}
+	}
+	return result
+}
+
+// SetElementAffinity sets how the character reacts to an element
+func (r *RPGStatsComponent) SetElementAffinity(element Element, affinity ElementAffinity) {
+	if r.ElementAffinities == nil {
+		r.ElementAffinities = make(ElementProfile)
+	}
+	r.ElementAffinities[element] = affinity
+}
+
+// GetElementAffinity returns the character's own affinity to an element, without equipment
+func (r *RPGStatsComponent) GetElementAffinity(element Element) ElementAffinity {
+	if element == ElementNone {
+		return AffinityNormal
+	}
+	return r.ElementAffinities[element]
+}
@@ -1,6 +1,9 @@
 package components
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // EquipmentSlot represents different equipment slots on a character
 type EquipmentSlot int
@@ -40,6 +43,16 @@ func (s EquipmentSlot) String() string {
 	}
 }
 
+// ParseEquipmentSlot converts a slot name from data files (case-insensitive) into an EquipmentSlot
+func ParseEquipmentSlot(name string) (EquipmentSlot, error) {
+	for slot := SlotHead; slot <= SlotAccessory2; slot++ {
+		if strings.EqualFold(slot.String(), strings.TrimSpace(name)) {
+			return slot, nil
+		}
+	}
+	return SlotHead, fmt.Errorf("unknown equipment slot: %s", name)
+}
+
 // EquipmentRarity represents the rarity/quality of equipment
 type EquipmentRarity int
 
@@ -100,14 +113,23 @@ type Equipment struct {
 	Rarity      EquipmentRarity // Rarity/quality level
 	Stats       EquipmentStats  // Stat bonuses provided by this equipment
 
+	// Elemental properties
+	Element    Element        // Element of a weapon's attacks (ElementNone = physical)
+	Affinities ElementProfile // Element affinities granted while equipped
+
 	// Requirements and restrictions
 	LevelRequirement int       // Minimum character level to equip
 	JobRestrictions  []JobType // Jobs that can equip this item (empty = all jobs)
 
 	// Item properties
-	Value  int // Gold value of the equipment
-	IconID int // ID for the equipment icon/sprite
-	SetID  int // Equipment set ID (0 = no set)
+	Value        int // Gold value of the equipment
+	IconID       int // ID for the equipment icon/sprite
+	SetID        int // Equipment set ID (0 = no set)
+	UpgradeLevel int // Upgrade level (+N), raised by crafting upgrades
+
+	// Instance data of generated or upgraded equipment
+	InstanceID int         // Instance ID of the item holding this equipment (0 = registry copy)
+	Affixes    []ItemAffix // Rolled prefixes and suffixes, already included in Stats
 }
 
 // CanEquip checks if a character with given level and job can equip this equipment
@@ -117,6 +139,11 @@ func (e *Equipment) CanEquip(level int, job JobType) bool {
 		return false
 	}
 
+	// Check the slots the job can use
+	if def := job.Definition(); def != nil && !def.CanEquipSlot(e.Slot) {
+		return false
+	}
+
 	// Check job restrictions (empty list means all jobs can equip)
 	if len(e.JobRestrictions) == 0 {
 		return true
@@ -182,9 +209,92 @@ func (e *Equipment) GetStatDescription() string {
 		description += fmt.Sprintf("Action Points: %+d\n", stats.APBonus)
 	}
 
+	// Elemental properties
+	if e.Element != ElementNone {
+		description += fmt.Sprintf("Element: %s\n", e.Element.String())
+	}
+	for element := ElementFire; element < elementCount; element++ {
+		if affinity, exists := e.Affinities[element]; exists && affinity != AffinityNormal {
+			description += fmt.Sprintf("%s: %s\n", element.String(), affinity.String())
+		}
+	}
+
 	return description
 }
 
+// Add returns the sum of two sets of stat bonuses
+func (es EquipmentStats) Add(other EquipmentStats) EquipmentStats {
+	return EquipmentStats{
+		AttackBonus:     es.AttackBonus + other.AttackBonus,
+		DefenseBonus:    es.DefenseBonus + other.DefenseBonus,
+		MagicPowerBonus: es.MagicPowerBonus + other.MagicPowerBonus,
+		MagicDefBonus:   es.MagicDefBonus + other.MagicDefBonus,
+		SpeedBonus:      es.SpeedBonus + other.SpeedBonus,
+		HPBonus:         es.HPBonus + other.HPBonus,
+		MPBonus:         es.MPBonus + other.MPBonus,
+		CritChanceBonus: es.CritChanceBonus + other.CritChanceBonus,
+		CritDamageBonus: es.CritDamageBonus + other.CritDamageBonus,
+		AccuracyBonus:   es.AccuracyBonus + other.AccuracyBonus,
+		EvasionBonus:    es.EvasionBonus + other.EvasionBonus,
+		MovementBonus:   es.MovementBonus + other.MovementBonus,
+		APBonus:         es.APBonus + other.APBonus,
+	}
+}
+
+// Sub returns the stat bonuses minus another set of stat bonuses
+func (es EquipmentStats) Sub(other EquipmentStats) EquipmentStats {
+	return es.Add(other.Scale(-100))
+}
+
+// Scale returns every stat bonus multiplied by a percentage
+func (es EquipmentStats) Scale(percent int) EquipmentStats {
+	scale := func(value int) int {
+		return value * percent / 100
+	}
+	return EquipmentStats{
+		AttackBonus:     scale(es.AttackBonus),
+		DefenseBonus:    scale(es.DefenseBonus),
+		MagicPowerBonus: scale(es.MagicPowerBonus),
+		MagicDefBonus:   scale(es.MagicDefBonus),
+		SpeedBonus:      scale(es.SpeedBonus),
+		HPBonus:         scale(es.HPBonus),
+		MPBonus:         scale(es.MPBonus),
+		CritChanceBonus: scale(es.CritChanceBonus),
+		CritDamageBonus: scale(es.CritDamageBonus),
+		AccuracyBonus:   scale(es.AccuracyBonus),
+		EvasionBonus:    scale(es.EvasionBonus),
+		MovementBonus:   scale(es.MovementBonus),
+		APBonus:         scale(es.APBonus),
+	}
+}
+
+// UpgradeStep returns the bonuses gained per upgrade level: a percentage of every positive
+// bonus, at least 1. Movement and AP bonuses are not raised.
+func (es EquipmentStats) UpgradeStep(percent int) EquipmentStats {
+	step := func(value int) int {
+		if value <= 0 {
+			return 0
+		}
+		if gain := value * percent / 100; gain > 1 {
+			return gain
+		}
+		return 1
+	}
+	return EquipmentStats{
+		AttackBonus:     step(es.AttackBonus),
+		DefenseBonus:    step(es.DefenseBonus),
+		MagicPowerBonus: step(es.MagicPowerBonus),
+		MagicDefBonus:   step(es.MagicDefBonus),
+		SpeedBonus:      step(es.SpeedBonus),
+		HPBonus:         step(es.HPBonus),
+		MPBonus:         step(es.MPBonus),
+		CritChanceBonus: step(es.CritChanceBonus),
+		CritDamageBonus: step(es.CritDamageBonus),
+		AccuracyBonus:   step(es.AccuracyBonus),
+		EvasionBonus:    step(es.EvasionBonus),
+	}
+}
+
 // EquipmentComponent manages the equipment worn by a character
 type EquipmentComponent struct {
 	Equipment map[EquipmentSlot]*Equipment // Currently equipped items by slot
@@ -226,26 +336,41 @@ func (ec *EquipmentComponent) GetTotalStats() EquipmentStats {
 
 	for _, equipment := range ec.Equipment {
 		if equipment != nil {
-			stats := equipment.Stats
-			total.AttackBonus += stats.AttackBonus
-			total.DefenseBonus += stats.DefenseBonus
-			total.MagicPowerBonus += stats.MagicPowerBonus
-			total.MagicDefBonus += stats.MagicDefBonus
-			total.SpeedBonus += stats.SpeedBonus
-			total.HPBonus += stats.HPBonus
-			total.MPBonus += stats.MPBonus
-			total.CritChanceBonus += stats.CritChanceBonus
-			total.CritDamageBonus += stats.CritDamageBonus
-			total.AccuracyBonus += stats.AccuracyBonus
-			total.EvasionBonus += stats.EvasionBonus
-			total.MovementBonus += stats.MovementBonus
-			total.APBonus += stats.APBonus
+			total = total.Add(equipment.Stats)
 		}
 	}
 
 	return total
 }
 
+// GetStatModifiers returns the stat modifiers of every equipped item
+func (ec *EquipmentComponent) GetStatModifiers() []StatModifier {
+	modifiers := make([]StatModifier, 0)
+	for _, equipment := range ec.GetEquipmentList() {
+		modifiers = append(modifiers, equipment.Stats.ToModifiers(SourceEquipment, equipment.Name)...)
+	}
+	return modifiers
+}
+
+// GetElementAffinities returns the affinities every equipped item grants to an element
+func (ec *EquipmentComponent) GetElementAffinities(element Element) []ElementAffinity {
+	affinities := make([]ElementAffinity, 0)
+	for _, equipment := range ec.GetEquipmentList() {
+		if affinity, exists := equipment.Affinities[element]; exists {
+			affinities = append(affinities, affinity)
+		}
+	}
+	return affinities
+}
+
+// GetWeaponElement returns the element of the equipped weapon, ElementNone without one
+func (ec *EquipmentComponent) GetWeaponElement() Element {
+	if weapon := ec.GetEquipped(SlotWeapon); weapon != nil {
+		return weapon.Element
+	}
+	return ElementNone
+}
+
 // GetEquipmentList returns a list of all currently equipped items
 func (ec *EquipmentComponent) GetEquipmentList() []*Equipment {
 	var equipped []*Equipment
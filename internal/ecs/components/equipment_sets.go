@@ -0,0 +1,380 @@
+// Package components provides equipment sets: pieces sharing a SetID grant bonuses when
+// enough of them are equipped together
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// SetBonus is granted while at least Pieces members of its set are equipped
+type SetBonus struct {
+	Pieces      int            // Equipped pieces needed
+	Stats       EquipmentStats // Flat stat bonuses
+	Skills      []*Skill       // Passive skills granted
+	Description string         // Human readable description
+}
+
+// EquipmentSet is a group of equipment granting bonuses when worn together
+type EquipmentSet struct {
+	ID      int        // Set ID, matches Equipment.SetID and Item.SetID
+	Name    string     // Display name
+	Members []int      // Item registry IDs of the set pieces
+	Bonuses []SetBonus // Bonuses sorted by pieces needed
+}
+
+// GetMemberNames returns the names of the set pieces, falling back to the item ID for
+// items missing from the item registry
+func (es *EquipmentSet) GetMemberNames() []string {
+	names := make([]string, 0, len(es.Members))
+	for _, id := range es.Members {
+		if GlobalItemRegistry != nil {
+			if item := GlobalItemRegistry.GetItem(id); item != nil {
+				names = append(names, item.Name)
+				continue
+			}
+		}
+		names = append(names, fmt.Sprintf("Item %d", id))
+	}
+	return names
+}
+
+// GetActiveBonuses returns the bonuses granted by a number of equipped pieces
+func (es *EquipmentSet) GetActiveBonuses(pieces int) []SetBonus {
+	active := make([]SetBonus, 0)
+	for _, bonus := range es.Bonuses {
+		if bonus.Pieces <= pieces {
+			active = append(active, bonus)
+		}
+	}
+	return active
+}
+
+// SetProgress is the number of pieces of a set a character has equipped
+type SetProgress struct {
+	Set    *EquipmentSet // Set worn
+	Pieces int           // Pieces of the set equipped
+}
+
+// GetActiveBonuses returns the bonuses the equipped pieces grant
+func (sp SetProgress) GetActiveBonuses() []SetBonus {
+	return sp.Set.GetActiveBonuses(sp.Pieces)
+}
+
+// setSkillData is the layout of a passive skill granted by a set bonus
+type setSkillData struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Effects     []struct {
+		Type        string `json:"type"`        // "stat_bonus", "stat_percent" or "passive_effect"
+		Target      string `json:"target"`      // Stat or passive effect name
+		Value       int    `json:"value"`       // Numeric value for the effect
+		Description string `json:"description"` // Human readable description
+	} `json:"effects"`
+}
+
+// setBonusData is the layout of a set bonus in the equipment set data file
+type setBonusData struct {
+	Pieces      int            `json:"pieces"`      // Equipped pieces needed (2-4)
+	Stats       map[string]int `json:"stats"`       // Flat bonuses by stat name ("attack", "hp", ...)
+	Skills      []setSkillData `json:"skills"`      // Passive skills granted
+	Description string         `json:"description"` // Human readable description
+}
+
+// equipmentSetData is the layout of a set in the equipment set data file
+type equipmentSetData struct {
+	ID      int            `json:"id"`
+	Name    string         `json:"name"`
+	Members []int          `json:"members"`
+	Bonuses []setBonusData `json:"bonuses"`
+}
+
+// equipmentSetDataFile is the layout of the equipment set data file
+type equipmentSetDataFile struct {
+	Sets []equipmentSetData `json:"sets"`
+}
+
+// SetRegistry holds every equipment set, keyed by set ID
+type SetRegistry struct {
+	sets map[int]*EquipmentSet
+}
+
+// NewSetRegistry creates an empty set registry
+func NewSetRegistry() *SetRegistry {
+	return &SetRegistry{
+		sets: make(map[int]*EquipmentSet),
+	}
+}
+
+// RegisterSet adds a set to the registry
+func (sr *SetRegistry) RegisterSet(set *EquipmentSet) error {
+	if set.ID <= 0 {
+		return fmt.Errorf("equipment set %s: id must be positive", set.Name)
+	}
+	if _, exists := sr.sets[set.ID]; exists {
+		return fmt.Errorf("equipment set %d already exists", set.ID)
+	}
+	sort.Slice(set.Bonuses, func(i, j int) bool {
+		return set.Bonuses[i].Pieces < set.Bonuses[j].Pieces
+	})
+	sr.sets[set.ID] = set
+	return nil
+}
+
+// GetSet returns a set by ID
+func (sr *SetRegistry) GetSet(id int) (*EquipmentSet, bool) {
+	set, exists := sr.sets[id]
+	return set, exists
+}
+
+// GetAllSets returns every set sorted by ID
+func (sr *SetRegistry) GetAllSets() []*EquipmentSet {
+	sets := make([]*EquipmentSet, 0, len(sr.sets))
+	for _, set := range sr.sets {
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].ID < sets[j].ID
+	})
+	return sets
+}
+
+// LoadFile registers every set of an equipment set data file. Members found in the global
+// item registry get the set ID, members already in another set are rejected.
+func (sr *SetRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read equipment set data: %v", err)
+	}
+
+	var file equipmentSetDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse equipment set data: %v", err)
+	}
+
+	for _, entry := range file.Sets {
+		set, err := entry.toEquipmentSet()
+		if err != nil {
+			return err
+		}
+		if err := sr.RegisterSet(set); err != nil {
+			return err
+		}
+		if err := assignSetMembers(set); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toEquipmentSet converts a data file set into an equipment set
+func (sd *equipmentSetData) toEquipmentSet() (*EquipmentSet, error) {
+	if sd.Name == "" {
+		sd.Name = fmt.Sprintf("Set %d", sd.ID)
+	}
+	if len(sd.Members) < 2 {
+		return nil, fmt.Errorf("equipment set %s: needs at least 2 members", sd.Name)
+	}
+
+	set := &EquipmentSet{
+		ID:      sd.ID,
+		Name:    sd.Name,
+		Members: sd.Members,
+		Bonuses: make([]SetBonus, 0, len(sd.Bonuses)),
+	}
+	for _, bonusData := range sd.Bonuses {
+		if bonusData.Pieces < 2 || bonusData.Pieces > len(sd.Members) {
+			return nil, fmt.Errorf("equipment set %s: bonus needs %d pieces, set has %d", sd.Name, bonusData.Pieces, len(sd.Members))
+		}
+
+		bonus := SetBonus{
+			Pieces:      bonusData.Pieces,
+			Skills:      make([]*Skill, 0, len(bonusData.Skills)),
+			Description: bonusData.Description,
+		}
+		for name, value := range bonusData.Stats {
+			stat, err := ParseStatType(name)
+			if err != nil {
+				return nil, fmt.Errorf("equipment set %s: %v", sd.Name, err)
+			}
+			bonus.Stats = bonus.Stats.AddStat(stat, value)
+		}
+		for _, skillData := range bonusData.Skills {
+			bonus.Skills = append(bonus.Skills, skillData.toSkill())
+		}
+		set.Bonuses = append(set.Bonuses, bonus)
+	}
+	return set, nil
+}
+
+// toSkill converts a data file set skill into a passive skill
+func (sd *setSkillData) toSkill() *Skill {
+	skill := &Skill{
+		ID:          sd.ID,
+		Name:        sd.Name,
+		Description: sd.Description,
+		Type:        SkillTypePassive,
+		Effects:     make([]SkillEffect, 0, len(sd.Effects)),
+		IsLearned:   true,
+	}
+	for _, effect := range sd.Effects {
+		skill.Effects = append(skill.Effects, SkillEffect{
+			Type:        effect.Type,
+			Target:      effect.Target,
+			Value:       effect.Value,
+			Description: effect.Description,
+		})
+	}
+	return skill
+}
+
+// assignSetMembers gives the set ID to the registered items of a set's members
+func assignSetMembers(set *EquipmentSet) error {
+	if GlobalItemRegistry == nil {
+		return nil
+	}
+	for _, id := range set.Members {
+		item := GlobalItemRegistry.GetItem(id)
+		if item == nil || item.Equipment == nil {
+			return fmt.Errorf("equipment set %s: member %d is not equipment", set.Name, id)
+		}
+		if item.SetID != 0 && item.SetID != set.ID {
+			return fmt.Errorf("equipment set %s: %s already belongs to set %d", set.Name, item.Name, item.SetID)
+		}
+		item.SetID = set.ID
+		item.Equipment.SetID = set.ID
+	}
+	return nil
+}
+
+// AddStat returns the stats with a value added to the bonus of a stat
+func (es EquipmentStats) AddStat(stat StatType, value int) EquipmentStats {
+	switch stat {
+	case StatAttack:
+		es.AttackBonus += value
+	case StatDefense:
+		es.DefenseBonus += value
+	case StatMagicAttack:
+		es.MagicPowerBonus += value
+	case StatMagicDefense:
+		es.MagicDefBonus += value
+	case StatSpeed:
+		es.SpeedBonus += value
+	case StatMaxHP:
+		es.HPBonus += value
+	case StatMaxMP:
+		es.MPBonus += value
+	case StatCritRate:
+		es.CritChanceBonus += value
+	case StatCritDamage:
+		es.CritDamageBonus += value
+	case StatAccuracy:
+		es.AccuracyBonus += value
+	case StatEvasion:
+		es.EvasionBonus += value
+	case StatMoveRange:
+		es.MovementBonus += value
+	case StatAP:
+		es.APBonus += value
+	}
+	return es
+}
+
+// GlobalSetRegistry holds the equipment sets used by the game
+var GlobalSetRegistry *SetRegistry
+
+// LoadSetRegistry loads the global set registry from an equipment set data file
+func LoadSetRegistry(path string) error {
+	registry := NewSetRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalSetRegistry = registry
+	return nil
+}
+
+// GetSetRegistry returns the global set registry, loading the default equipment set data
+// file if needed. A failed load is logged and leaves the game without set bonuses, as at startup.
+func GetSetRegistry() *SetRegistry {
+	if GlobalSetRegistry == nil {
+		if err := LoadSetRegistry(constants.EquipmentSetDataFile); err != nil {
+			logger.Warn("Failed to load equipment set data: %v", err)
+			GlobalSetRegistry = NewSetRegistry()
+		}
+	}
+	return GlobalSetRegistry
+}
+
+// GetSetProgress returns every set with at least one equipped piece, sorted by set ID
+func (ec *EquipmentComponent) GetSetProgress() []SetProgress {
+	pieces := make(map[int]int)
+	for _, equipment := range ec.GetEquipmentList() {
+		if equipment.SetID != 0 {
+			pieces[equipment.SetID]++
+		}
+	}
+
+	progress := make([]SetProgress, 0, len(pieces))
+	registry := GetSetRegistry()
+	for id, count := range pieces {
+		if set, exists := registry.GetSet(id); exists {
+			progress = append(progress, SetProgress{Set: set, Pieces: count})
+		}
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		return progress[i].Set.ID < progress[j].Set.ID
+	})
+	return progress
+}
+
+// GetActiveSetBonuses returns the bonuses granted by the equipped sets
+func (ec *EquipmentComponent) GetActiveSetBonuses() []SetBonus {
+	bonuses := make([]SetBonus, 0)
+	for _, progress := range ec.GetSetProgress() {
+		bonuses = append(bonuses, progress.GetActiveBonuses()...)
+	}
+	return bonuses
+}
+
+// GetSetSkills returns the passive skills granted by the equipped sets
+func (ec *EquipmentComponent) GetSetSkills() []*Skill {
+	skills := make([]*Skill, 0)
+	for _, bonus := range ec.GetActiveSetBonuses() {
+		skills = append(skills, bonus.Skills...)
+	}
+	return skills
+}
+
+// GetSetStatModifiers returns the stat modifiers of the active set bonuses and of the
+// passive skills they grant
+func (ec *EquipmentComponent) GetSetStatModifiers() []StatModifier {
+	modifiers := make([]StatModifier, 0)
+	for _, progress := range ec.GetSetProgress() {
+		for _, bonus := range progress.GetActiveBonuses() {
+			modifiers = append(modifiers, bonus.Stats.ToModifiers(SourceSet, progress.Set.Name)...)
+			for _, skill := range bonus.Skills {
+				modifiers = append(modifiers, skill.GetStatModifiers(SourceSet)...)
+			}
+		}
+	}
+	return modifiers
+}
+
+// GetSetPassiveEffect returns the total value of a "passive_effect" granted by the equipped sets
+func (ec *EquipmentComponent) GetSetPassiveEffect(name string) int {
+	total := 0
+	for _, skill := range ec.GetSetSkills() {
+		for _, effect := range skill.Effects {
+			if effect.Type == "passive_effect" && effect.Target == name {
+				total += effect.Value
+			}
+		}
+	}
+	return total
+}
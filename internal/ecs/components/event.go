@@ -23,16 +23,17 @@ const (
 type EventType int
 
 const (
-	EventBattle   EventType = iota // Start a tactical battle
-	EventDialog                    // Open dialog with NPC
-	EventChest                     // Open a chest/container
-	EventDoor                      // Move to another area/room
-	EventTrap                      // Trigger a trap
-	EventInfo                      // Display information to player
-	EventQuest                     // Trigger quest-related actions
-	EventCutscene                  // Play a cutscene or story sequence
-	EventShop                      // Open shop interface
-	EventRest                      // Trigger rest/save point
+	EventBattle    EventType = iota // Start a tactical battle
+	EventDialog                     // Open dialog with NPC
+	EventChest                      // Open a chest/container
+	EventDoor                       // Move to another area/room
+	EventTrap                       // Trigger a trap
+	EventInfo                       // Display information to player
+	EventQuest                      // Trigger quest-related actions
+	EventCutscene                   // Play a cutscene or story sequence
+	EventShop                       // Open shop interface
+	EventRest                       // Trigger rest/save point
+	EventJobChange                  // Change jobs at a job shrine
 )
 
 // EventState tracks the current state of an event
@@ -73,6 +74,12 @@ type EventData struct {
 	// Battle event data
 	Enemies   []string `json:"enemies,omitempty"`    // Enemy IDs to spawn in battle
 	BattleMap string   `json:"battle_map,omitempty"` // Battle map to use
+	Boss      bool     `json:"boss,omitempty"`       // Boss battle, the party cannot escape
+
+	// Battle objectives (nil = defeat all enemies / lose when the party falls)
+	Victory *BattleConditionSpec `json:"victory,omitempty"` // Condition that wins the battle
+	Defeat  *BattleConditionSpec `json:"defeat,omitempty"`  // Extra condition that loses the battle
+	Script  []BattleScriptSpec   `json:"script,omitempty"`  // Scripted mid-battle events
 
 	// Dialog event data
 	NPCID    string `json:"npc_id,omitempty"`    // NPC to talk to
@@ -82,6 +89,8 @@ type EventData struct {
 	Items    []string `json:"items,omitempty"`     // Item IDs in chest
 	Gold     int      `json:"gold,omitempty"`      // Gold amount
 	IsLocked bool     `json:"is_locked,omitempty"` // Whether chest is locked
+	KeyID    int      `json:"key_id,omitempty"`    // Item that unlocks the chest (0 = constants.ChestKeyItemID)
+	LockTier int      `json:"lock_tier,omitempty"` // Lock difficulty, lowers the Rogue lockpick chance
 
 	// Door event data
 	TargetMap string  `json:"target_map,omitempty"` // Map to transition to
@@ -103,6 +112,52 @@ type EventData struct {
 	ShopID string `json:"shop_id,omitempty"` // Shop inventory ID
 }
 
+// BattleConditionSpec declares a battle victory or defeat condition in data form.
+// Type is one of "defeat_all", "party_defeated", "defeat_boss", "survive_rounds",
+// "reach_tile", "protect_unit", "turn_limit", "all" or "any".
+type BattleConditionSpec struct {
+	Type        string                `json:"type"`                  // Condition type
+	Description string                `json:"description,omitempty"` // Optional text shown in the combat UI
+	Target      string                `json:"target,omitempty"`      // Unit name for boss/protect conditions
+	Rounds      int                   `json:"rounds,omitempty"`      // Round count for survive/turn limit conditions
+	TileX       int                   `json:"tile_x,omitempty"`      // Grid X for reach_tile
+	TileY       int                   `json:"tile_y,omitempty"`      // Grid Y for reach_tile
+	Conditions  []BattleConditionSpec `json:"conditions,omitempty"`  // Child conditions for all/any
+}
+
+// BattleScriptSpec declares a scripted mid-battle event: when the trigger fires, the actions run in order
+type BattleScriptSpec struct {
+	Trigger BattleTriggerSpec  `json:"trigger"`          // When the event fires
+	Actions []BattleActionSpec `json:"actions"`          // What happens when it fires
+	Repeat  bool               `json:"repeat,omitempty"` // Fire again each time the trigger becomes true
+}
+
+// BattleTriggerSpec declares when a scripted event fires.
+// Type is one of "round", "unit_hp", "tile_entered" or "unit_death".
+type BattleTriggerSpec struct {
+	Type      string `json:"type"`                 // Trigger type
+	Round     int    `json:"round,omitempty"`      // Round number for round triggers
+	Target    string `json:"target,omitempty"`     // Unit name for unit_hp/unit_death triggers
+	HPPercent int    `json:"hp_percent,omitempty"` // HP threshold (percent of max) for unit_hp triggers
+	TileX     int    `json:"tile_x,omitempty"`     // Grid X for tile_entered triggers
+	TileY     int    `json:"tile_y,omitempty"`     // Grid Y for tile_entered triggers
+}
+
+// BattleActionSpec declares an action run by a scripted event.
+// Type is one of "spawn_wave", "dialog", "terrain" or "ai_profile".
+type BattleActionSpec struct {
+	Type    string   `json:"type"`              // Action type
+	Units   []string `json:"units,omitempty"`   // Unit IDs to spawn for spawn_wave
+	Edge    string   `json:"edge,omitempty"`    // Grid edge for spawn_wave: "left", "right", "top" or "bottom"
+	Speaker string   `json:"speaker,omitempty"` // Speaker name for dialog
+	Text    string   `json:"text,omitempty"`    // Line of text for dialog
+	TileX   int      `json:"tile_x,omitempty"`  // Grid X for terrain
+	TileY   int      `json:"tile_y,omitempty"`  // Grid Y for terrain
+	Terrain string   `json:"terrain,omitempty"` // Terrain for terrain: "floor", "wall", "water", "pit" or "elevated"
+	Target  string   `json:"target,omitempty"`  // Unit name for ai_profile (empty = all enemies)
+	Profile string   `json:"profile,omitempty"` // AI profile for ai_profile: "defensive", "aggressive" or "passive"
+}
+
 // EventConditionData contains data for different trigger conditions
 type EventConditionData struct {
 	Duration   time.Duration `json:"duration,omitempty"`    // For timeout triggers
@@ -164,6 +219,8 @@ func NewEventComponent(id, name string, triggerCondition TriggerCondition, event
 		defaultColor = constants.EventColorShop
 	case EventRest:
 		defaultColor = constants.EventColorRest
+	case EventJobChange:
+		defaultColor = constants.EventColorJobChange
 	default:
 		defaultColor = [3]uint8{128, 128, 128} // Gray for unknown
 	}
@@ -319,6 +376,8 @@ func (e *EventComponent) GetEventTypeName() string {
 		return "Shop"
 	case EventRest:
 		return "Rest"
+	case EventJobChange:
+		return "Job Shrine"
 	default:
 		return "Unknown"
 	}
@@ -1,6 +1,12 @@
 package components
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+)
 
 // ItemType represents the category of an item
 type ItemType int
@@ -83,6 +89,8 @@ type ConsumableEffect struct {
 	Type   string // "heal_hp", "heal_mp", "buff_stat", "cure_status", etc.
 	Value  int    // Amount of healing, stat bonus, etc.
 	Target string // "self", "ally", "enemy", "area"
+
+	Element Element // Element of "damage" effects (ElementNone = non-elemental)
 }
 
 // Item represents any item in the game (equipment, consumables, materials, etc.)
@@ -111,6 +119,9 @@ type Item struct {
 	QuestItem bool // Is this a quest item (cannot be sold/dropped)
 	Unique    bool // Only one can be owned at a time
 	SetID     int  // Item set ID (0 = no set)
+
+	// Instance identity (0 = registry copy, stacks with other copies)
+	InstanceID int // Unique ID of a generated or modified item, see item_instances.go
 }
 
 // CanUse checks if a character with given level and job can use this item
@@ -210,8 +221,8 @@ func (slot *InventorySlot) CanAddItem(item *Item, quantity int) bool {
 		return true
 	}
 
-	// Non-empty slot can only accept same stackable items
-	if slot.Item.ID == item.ID && item.Stackable {
+	// Non-empty slot can only accept same stackable items, instances never stack with others
+	if slot.Item.IsSameItem(item) && item.Stackable {
 		// Check if adding quantity would exceed max stack
 		if item.MaxStack > 0 {
 			return slot.Quantity+quantity <= item.MaxStack
@@ -312,11 +323,12 @@ func (inv *InventoryComponent) GetSlotByIndex(index int) *InventorySlot {
 	return &inv.Slots[index]
 }
 
-// FindItem searches for an item by ID and returns the first slot containing it
+// FindItem searches for a registry item by ID and returns the first slot containing a copy.
+// Item instances are only found with FindInstance.
 func (inv *InventoryComponent) FindItem(itemID int) *InventorySlot {
 	for i := range inv.Slots {
 		slot := &inv.Slots[i]
-		if !slot.IsEmpty() && slot.Item.ID == itemID {
+		if !slot.IsEmpty() && slot.Item.ID == itemID && !slot.Item.IsInstance() {
 			return slot
 		}
 	}
@@ -343,7 +355,7 @@ func (inv *InventoryComponent) AddItem(item *Item, quantity int) int {
 	if item.Stackable {
 		for i := range inv.Slots {
 			slot := &inv.Slots[i]
-			if !slot.IsEmpty() && slot.Item.ID == item.ID {
+			if !slot.IsEmpty() && slot.Item.IsSameItem(item) {
 				overflow := slot.AddItem(item, remaining)
 				remaining = overflow
 				if remaining <= 0 {
@@ -366,7 +378,8 @@ func (inv *InventoryComponent) AddItem(item *Item, quantity int) int {
 	return remaining
 }
 
-// RemoveItem removes the specified quantity of an item from inventory
+// RemoveItem removes the specified quantity of copies of a registry item from inventory,
+// leaving item instances alone (see RemoveInstance)
 // Returns the quantity that was actually removed
 func (inv *InventoryComponent) RemoveItem(itemID int, quantity int) int {
 	removed := 0
@@ -374,7 +387,7 @@ func (inv *InventoryComponent) RemoveItem(itemID int, quantity int) int {
 
 	for i := range inv.Slots {
 		slot := &inv.Slots[i]
-		if !slot.IsEmpty() && slot.Item.ID == itemID {
+		if !slot.IsEmpty() && slot.Item.ID == itemID && !slot.Item.IsInstance() {
 			slotRemoved := slot.RemoveItem(remaining)
 			removed += slotRemoved
 			remaining -= slotRemoved
@@ -387,12 +400,12 @@ func (inv *InventoryComponent) RemoveItem(itemID int, quantity int) int {
 	return removed
 }
 
-// GetItemCount returns the total quantity of an item in the inventory
+// GetItemCount returns the total quantity of copies of a registry item in the inventory
 func (inv *InventoryComponent) GetItemCount(itemID int) int {
 	count := 0
 	for i := range inv.Slots {
 		slot := &inv.Slots[i]
-		if !slot.IsEmpty() && slot.Item.ID == itemID {
+		if !slot.IsEmpty() && slot.Item.ID == itemID && !slot.Item.IsInstance() {
 			count += slot.Quantity
 		}
 	}
@@ -433,8 +446,38 @@ func NewItemRegistry() *ItemRegistry {
 	}
 }
 
-// RegisterItem adds an item to the registry
+// RegisterItem adds an item to the registry. Equipment fields left empty are filled from
+// the item, so equipment keeps its identity when it is equipped and unequipped.
 func (ir *ItemRegistry) RegisterItem(item *Item) {
+	if equipment := item.Equipment; equipment != nil {
+		if equipment.ID == 0 {
+			equipment.ID = item.ID
+		}
+		if equipment.Name == "" {
+			equipment.Name = item.Name
+		}
+		if equipment.Description == "" {
+			equipment.Description = item.Description
+		}
+		if equipment.Rarity == 0 {
+			equipment.Rarity = EquipmentRarity(item.Rarity)
+		}
+		if equipment.Value == 0 {
+			equipment.Value = item.Value
+		}
+		if equipment.IconID == 0 {
+			equipment.IconID = item.IconID
+		}
+		if equipment.SetID == 0 {
+			equipment.SetID = item.SetID
+		}
+		if equipment.LevelRequirement == 0 {
+			equipment.LevelRequirement = item.LevelRequirement
+		}
+		if len(equipment.JobRestrictions) == 0 {
+			equipment.JobRestrictions = item.JobRestrictions
+		}
+	}
 	ir.items[item.ID] = item
 	ir.byName[item.Name] = item
 }
@@ -449,6 +492,43 @@ func (ir *ItemRegistry) GetItemByName(name string) *Item {
 	return ir.byName[name]
 }
 
+// ResolveItem finds an item from a data file reference: a numeric item ID, the item name
+// or the item name in snake case ("iron_sword")
+func (ir *ItemRegistry) ResolveItem(ref string) *Item {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return ir.GetItem(id)
+	}
+	if item := ir.GetItemByName(ref); item != nil {
+		return item
+	}
+	for _, item := range ir.items {
+		if strings.ReplaceAll(strings.ToLower(item.Name), " ", "_") == strings.ToLower(ref) {
+			return item
+		}
+	}
+	return nil
+}
+
+// NewEquipmentItem wraps a piece of equipment, such as an unequipped one, in an inventory item
+func NewEquipmentItem(equipment *Equipment) *Item {
+	return &Item{
+		ID:               equipment.ID,
+		Name:             equipment.Name,
+		Description:      equipment.Description,
+		Type:             ItemTypeEquipment,
+		Rarity:           ItemRarity(equipment.Rarity),
+		Value:            equipment.Value,
+		IconID:           equipment.IconID,
+		Equipment:        equipment,
+		Stackable:        false,
+		MaxStack:         1,
+		LevelRequirement: equipment.LevelRequirement,
+		JobRestrictions:  equipment.JobRestrictions,
+		SetID:            equipment.SetID,
+		InstanceID:       equipment.InstanceID,
+	}
+}
+
 // CreateItem creates a new item instance (copy of registered item)
 func (ir *ItemRegistry) CreateItem(id int) *Item {
 	template := ir.items[id]
@@ -521,6 +601,52 @@ func registerBaseItems() {
 	}
 	GlobalItemRegistry.RegisterItem(manaPotion)
 
+	// Fire Bomb
+	fireBomb := &Item{
+		ID:          220,
+		Name:        "Fire Bomb",
+		Description: "Deals 40 fire damage to an enemy.",
+		Type:        ItemTypeConsumable,
+		Rarity:      ItemRarityUncommon,
+		Value:       40,
+		IconID:      220,
+		Stackable:   true,
+		MaxStack:    10,
+		Effects: []ConsumableEffect{
+			{Type: "damage", Value: 40, Target: "enemy", Element: ElementFire},
+		},
+		LevelRequirement: 1,
+	}
+	GlobalItemRegistry.RegisterItem(fireBomb)
+
+	// Tome of Forgetting
+	tomeOfForgetting := &Item{
+		ID:          constants.SkillRespecItemID,
+		Name:        "Tome of Forgetting",
+		Description: "Consumed to refund learned skills without paying gold.",
+		Type:        ItemTypeMiscellaneous,
+		Rarity:      ItemRarityRare,
+		Value:       150,
+		IconID:      constants.SkillRespecItemID,
+		Stackable:   true,
+		MaxStack:    5,
+	}
+	GlobalItemRegistry.RegisterItem(tomeOfForgetting)
+
+	// Iron Key
+	ironKey := &Item{
+		ID:          constants.ChestKeyItemID,
+		Name:        "Iron Key",
+		Description: "Opens a locked chest. Consumed when used.",
+		Type:        ItemTypeMiscellaneous,
+		Rarity:      ItemRarityUncommon,
+		Value:       40,
+		IconID:      constants.ChestKeyItemID,
+		Stackable:   true,
+		MaxStack:    10,
+	}
+	GlobalItemRegistry.RegisterItem(ironKey)
+
 	// Iron Sword
 	ironSword := &Item{
 		ID:          1,
@@ -544,6 +670,121 @@ func registerBaseItems() {
 	}
 	GlobalItemRegistry.RegisterItem(ironSword)
 
+	// Flame Sword
+	flameSword := &Item{
+		ID:          11,
+		Name:        "Flame Sword",
+		Description: "A blade wreathed in fire. Its attacks deal fire damage.",
+		Type:        ItemTypeEquipment,
+		Rarity:      ItemRarityRare,
+		Value:       250,
+		IconID:      11,
+		Stackable:   false,
+		MaxStack:    1,
+		Equipment: &Equipment{
+			Slot: SlotWeapon,
+			Stats: EquipmentStats{
+				AttackBonus: 10,
+			},
+			Element:    ElementFire,
+			Affinities: ElementProfile{ElementFire: AffinityResist, ElementIce: AffinityWeak},
+		},
+		LevelRequirement: 3,
+		JobRestrictions:  []JobType{JobWarrior},
+	}
+	GlobalItemRegistry.RegisterItem(flameSword)
+
+	// Guardian Helm
+	guardianHelm := &Item{
+		ID:          20,
+		Name:        "Guardian Helm",
+		Description: "A visored steel helm worn by the Guardians of the old keep.",
+		Type:        ItemTypeEquipment,
+		Rarity:      ItemRarityUncommon,
+		Value:       60,
+		IconID:      20,
+		Stackable:   false,
+		MaxStack:    1,
+		Equipment: &Equipment{
+			Slot: SlotHead,
+			Stats: EquipmentStats{
+				DefenseBonus: 4,
+				HPBonus:      10,
+			},
+		},
+		LevelRequirement: 2,
+		SetID:            1, // Guardian set
+	}
+	GlobalItemRegistry.RegisterItem(guardianHelm)
+
+	// Guardian Mail
+	guardianMail := &Item{
+		ID:          21,
+		Name:        "Guardian Mail",
+		Description: "Heavy chainmail of the Guardians. Strongest when worn with the rest of the set.",
+		Type:        ItemTypeEquipment,
+		Rarity:      ItemRarityUncommon,
+		Value:       120,
+		IconID:      21,
+		Stackable:   false,
+		MaxStack:    1,
+		Equipment: &Equipment{
+			Slot: SlotChest,
+			Stats: EquipmentStats{
+				DefenseBonus:  8,
+				MagicDefBonus: 2,
+			},
+		},
+		LevelRequirement: 2,
+		SetID:            1, // Guardian set
+	}
+	GlobalItemRegistry.RegisterItem(guardianMail)
+
+	// Guardian Greaves
+	guardianGreaves := &Item{
+		ID:          22,
+		Name:        "Guardian Greaves",
+		Description: "Plated leg guards of the Guardians.",
+		Type:        ItemTypeEquipment,
+		Rarity:      ItemRarityUncommon,
+		Value:       80,
+		IconID:      22,
+		Stackable:   false,
+		MaxStack:    1,
+		Equipment: &Equipment{
+			Slot: SlotLegs,
+			Stats: EquipmentStats{
+				DefenseBonus: 5,
+			},
+		},
+		LevelRequirement: 2,
+		SetID:            1, // Guardian set
+	}
+	GlobalItemRegistry.RegisterItem(guardianGreaves)
+
+	// Guardian Boots
+	guardianBoots := &Item{
+		ID:          23,
+		Name:        "Guardian Boots",
+		Description: "Iron-shod boots of the Guardians.",
+		Type:        ItemTypeEquipment,
+		Rarity:      ItemRarityUncommon,
+		Value:       50,
+		IconID:      23,
+		Stackable:   false,
+		MaxStack:    1,
+		Equipment: &Equipment{
+			Slot: SlotFeet,
+			Stats: EquipmentStats{
+				DefenseBonus: 2,
+				SpeedBonus:   1,
+			},
+		},
+		LevelRequirement: 2,
+		SetID:            1, // Guardian set
+	}
+	GlobalItemRegistry.RegisterItem(guardianBoots)
+
 	// Magic Crystal
 	magicCrystal := &Item{
 		ID:               301,
@@ -558,6 +799,34 @@ func registerBaseItems() {
 		LevelRequirement: 10,
 	}
 	GlobalItemRegistry.RegisterItem(magicCrystal)
+
+	// Iron Ore
+	ironOre := &Item{
+		ID:          302,
+		Name:        "Iron Ore",
+		Description: "Raw iron used to forge weapons and upgrade equipment.",
+		Type:        ItemTypeMaterial,
+		Rarity:      ItemRarityCommon,
+		Value:       12,
+		IconID:      302,
+		Stackable:   true,
+		MaxStack:    50,
+	}
+	GlobalItemRegistry.RegisterItem(ironOre)
+
+	// Healing Herb
+	healingHerb := &Item{
+		ID:          303,
+		Name:        "Healing Herb",
+		Description: "A fragrant herb brewed into potions.",
+		Type:        ItemTypeMaterial,
+		Rarity:      ItemRarityCommon,
+		Value:       6,
+		IconID:      303,
+		Stackable:   true,
+		MaxStack:    50,
+	}
+	GlobalItemRegistry.RegisterItem(healingHerb)
 }
 
 // GetAllItems returns a copy of all registered items
@@ -0,0 +1,117 @@
+// Package components provides item instances: generated or modified items with their own
+// identity, such as equipment with rolled affixes, that never stack with other copies
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// lastInstanceID is the last item instance ID handed out
+var lastInstanceID int64
+
+// NewInstanceID returns a new unique item instance ID
+func NewInstanceID() int {
+	return int(atomic.AddInt64(&lastInstanceID, 1))
+}
+
+// ItemAffix is a prefix or suffix rolled on a piece of equipment
+type ItemAffix struct {
+	ID     string         `json:"id"`     // Affix table ID
+	Name   string         `json:"name"`   // Name added to the item ("Swift", "of the Bear")
+	Prefix bool           `json:"prefix"` // Whether the name goes before the item name
+	Stats  EquipmentStats `json:"stats"`  // Rolled stat bonuses
+}
+
+// Description returns the affix name and its rolled bonuses ("Swift: Speed: +2")
+func (a ItemAffix) Description() string {
+	bonuses := strings.Split(strings.TrimSpace((&Equipment{Stats: a.Stats}).GetStatDescription()), "\n")
+	return fmt.Sprintf("%s: %s", a.Name, strings.Join(bonuses, ", "))
+}
+
+// ParseItemRarity converts a rarity name from data files (case-insensitive) into an ItemRarity
+func ParseItemRarity(name string) (ItemRarity, error) {
+	for rarity := ItemRarityCommon; rarity <= ItemRarityLegendary; rarity++ {
+		if strings.EqualFold(rarity.String(), name) {
+			return rarity, nil
+		}
+	}
+	return ItemRarityCommon, fmt.Errorf("unknown item rarity: %s", name)
+}
+
+// IsInstance returns true if the item has its own identity instead of being a registry copy
+func (i *Item) IsInstance() bool {
+	return i.InstanceID != 0
+}
+
+// IsSameItem returns true if two items are copies of the same registry item or the same instance
+func (i *Item) IsSameItem(other *Item) bool {
+	return other != nil && i.ID == other.ID && i.InstanceID == other.InstanceID
+}
+
+// MakeInstance gives the item, and its equipment, a new instance identity unless it has one
+func (i *Item) MakeInstance() {
+	if i.InstanceID == 0 {
+		i.InstanceID = NewInstanceID()
+	}
+	if i.Equipment != nil {
+		i.Equipment.InstanceID = i.InstanceID
+	}
+}
+
+// AffixedName returns an item name with the prefixes before it and the suffixes after it
+func AffixedName(name string, affixes []ItemAffix) string {
+	parts := make([]string, 0, len(affixes)+1)
+	for _, affix := range affixes {
+		if affix.Prefix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	parts = append(parts, name)
+	for _, affix := range affixes {
+		if !affix.Prefix {
+			parts = append(parts, affix.Name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// FindInstance returns the slot holding an item instance, or the first copy of a registry item
+func (inv *InventoryComponent) FindInstance(item *Item) *InventorySlot {
+	for i := range inv.Slots {
+		slot := &inv.Slots[i]
+		if !slot.IsEmpty() && slot.Item.IsSameItem(item) {
+			return slot
+		}
+	}
+	return nil
+}
+
+// GetInstanceCount returns the quantity held of an item instance, or of a registry item
+func (inv *InventoryComponent) GetInstanceCount(item *Item) int {
+	count := 0
+	for i := range inv.Slots {
+		slot := &inv.Slots[i]
+		if !slot.IsEmpty() && slot.Item.IsSameItem(item) {
+			count += slot.Quantity
+		}
+	}
+	return count
+}
+
+// RemoveInstance removes a quantity of an item instance, or of a registry item
+// Returns the quantity that was actually removed
+func (inv *InventoryComponent) RemoveInstance(item *Item, quantity int) int {
+	removed := 0
+	for i := range inv.Slots {
+		slot := &inv.Slots[i]
+		if !slot.IsEmpty() && slot.Item.IsSameItem(item) {
+			removed += slot.RemoveItem(quantity - removed)
+			if removed >= quantity {
+				break
+			}
+		}
+	}
+	return removed
+}
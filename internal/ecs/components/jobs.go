@@ -0,0 +1,211 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// JobStatValues holds one value per character stat, used for job base stats and growth
+type JobStatValues struct {
+	HP           float64 `json:"hp"`
+	MP           float64 `json:"mp"`
+	Attack       float64 `json:"attack"`
+	Defense      float64 `json:"defense"`
+	MagicAttack  float64 `json:"magic_attack"`
+	MagicDefense float64 `json:"magic_defense"`
+	Speed        float64 `json:"speed"`
+	Accuracy     float64 `json:"accuracy"`
+	CritRate     float64 `json:"crit_rate"`
+}
+
+// ExpCurve defines the experience needed to level up: base * level^exponent
+type ExpCurve struct {
+	Base     int     `json:"base"`     // Experience from level 1 to 2
+	Exponent float64 `json:"exponent"` // Curve steepness (1 = linear)
+}
+
+// JobDefinition describes a job loaded from the job data file
+type JobDefinition struct {
+	ID          JobType       `json:"id"`           // Job identifier used by characters and data files
+	Name        string        `json:"name"`         // Display name
+	Description string        `json:"description"`  // Short description for menus
+	Base        JobStatValues `json:"base"`         // Stats at level 1
+	Growth      JobStatValues `json:"growth"`       // Stats gained per level (fractions accumulate)
+	MoveRange   int           `json:"move_range"`   // Tactical movement range in tiles
+	MaxAP       int           `json:"max_ap"`       // Tactical action points per turn
+	ExpCurve    ExpCurve      `json:"exp_curve"`    // Experience needed per level
+	SkillPoints int           `json:"skill_points"` // Skill points granted per level gained
+	Equipment   []string      `json:"equipment"`    // Equipment slots the job can use (empty = all)
+	SkillTree   string        `json:"skill_tree"`   // Skill tree ID (empty = the job ID)
+	Ranged      bool          `json:"ranged"`       // Attacks from the back row without penalty
+	Healer      bool          `json:"healer"`       // Targeted first by healer-hunting enemies
+}
+
+// StatAt returns a stat value at the given level from its base value and growth
+func (jd *JobDefinition) StatAt(base, growth float64, level int) int {
+	return int(base + growth*float64(level-1))
+}
+
+// ExpToNext returns the experience needed to go from a level to the next one
+func (jd *JobDefinition) ExpToNext(level int) int {
+	return int(float64(jd.ExpCurve.Base) * math.Pow(float64(level), jd.ExpCurve.Exponent))
+}
+
+// CanEquipSlot returns true if the job can use equipment in the slot
+func (jd *JobDefinition) CanEquipSlot(slot EquipmentSlot) bool {
+	if len(jd.Equipment) == 0 {
+		return true
+	}
+	for _, name := range jd.Equipment {
+		if strings.EqualFold(name, slot.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSkillTreeID returns the ID of the job's skill tree
+func (jd *JobDefinition) GetSkillTreeID() string {
+	if jd.SkillTree != "" {
+		return jd.SkillTree
+	}
+	return string(jd.ID)
+}
+
+// jobDataFile is the layout of the job data file
+type jobDataFile struct {
+	Jobs []*JobDefinition `json:"jobs"`
+}
+
+// JobRegistry holds every job definition, in data file order
+type JobRegistry struct {
+	jobs  map[JobType]*JobDefinition
+	order []JobType
+}
+
+// NewJobRegistry creates an empty job registry
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{
+		jobs:  make(map[JobType]*JobDefinition),
+		order: make([]JobType, 0),
+	}
+}
+
+// RegisterJob adds or replaces a job definition
+func (jr *JobRegistry) RegisterJob(def *JobDefinition) error {
+	if def.ID == "" {
+		return fmt.Errorf("job definition without id")
+	}
+	if def.Name == "" {
+		def.Name = string(def.ID)
+	}
+
+	// Stats every job starts with unless the data says otherwise
+	if def.Base.Speed == 0 {
+		def.Base.Speed = DefaultRPGStateSpeed
+	}
+	if def.Base.Accuracy == 0 {
+		def.Base.Accuracy = DefaultRPGStateAccuracy
+	}
+	if def.Base.CritRate == 0 {
+		def.Base.CritRate = DefaultRPGStatCritRate
+	}
+	if def.MoveRange <= 0 {
+		def.MoveRange = constants.DefaultMoveRange
+	}
+	if def.MaxAP <= 0 {
+		def.MaxAP = constants.DefaultMaxAP
+	}
+	if def.ExpCurve.Base <= 0 {
+		def.ExpCurve.Base = constants.DefaultExpCurveBase
+	}
+	if def.ExpCurve.Exponent <= 0 {
+		def.ExpCurve.Exponent = constants.DefaultExpCurveExponent
+	}
+	if def.SkillPoints <= 0 {
+		def.SkillPoints = constants.DefaultSkillPointsPerLevel
+	}
+	for _, name := range def.Equipment {
+		if _, err := ParseEquipmentSlot(name); err != nil {
+			return fmt.Errorf("job %s: %v", def.ID, err)
+		}
+	}
+
+	if _, exists := jr.jobs[def.ID]; !exists {
+		jr.order = append(jr.order, def.ID)
+	}
+	jr.jobs[def.ID] = def
+	return nil
+}
+
+// LoadFile registers every job of a job data file
+func (jr *JobRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read job data: %v", err)
+	}
+
+	var file jobDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse job data: %v", err)
+	}
+
+	for _, def := range file.Jobs {
+		if err := jr.RegisterJob(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetJob returns the definition of a job
+func (jr *JobRegistry) GetJob(job JobType) (*JobDefinition, bool) {
+	def, exists := jr.jobs[job]
+	return def, exists
+}
+
+// GetAllJobs returns every job definition in data file order
+func (jr *JobRegistry) GetAllJobs() []*JobDefinition {
+	jobs := make([]*JobDefinition, 0, len(jr.order))
+	for _, id := range jr.order {
+		jobs = append(jobs, jr.jobs[id])
+	}
+	return jobs
+}
+
+// GlobalJobRegistry holds the job definitions used by the game
+var GlobalJobRegistry *JobRegistry
+
+// LoadJobRegistry loads the global job registry from a job data file
+func LoadJobRegistry(path string) error {
+	registry := NewJobRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalJobRegistry = registry
+	return nil
+}
+
+// GetJobRegistry returns the global job registry, loading the default job data file if needed.
+// A failed load is logged and leaves an empty registry; the game loads it explicitly at startup.
+func GetJobRegistry() *JobRegistry {
+	if GlobalJobRegistry == nil {
+		if err := LoadJobRegistry(constants.JobDataFile); err != nil {
+			logger.Error("Failed to load job definitions: %v", err)
+			GlobalJobRegistry = NewJobRegistry()
+		}
+	}
+	return GlobalJobRegistry
+}
+
+// Definition returns the job definition, nil for unknown jobs
+func (j JobType) Definition() *JobDefinition {
+	def, _ := GetJobRegistry().GetJob(j)
+	return def
+}
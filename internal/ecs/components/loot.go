@@ -0,0 +1,47 @@
+// Package components provides loot components describing what defeated enemies drop
+package components
+
+// DropEntry is a single line of an enemy drop table
+type DropEntry struct {
+	ItemID      int // Item registry ID of the dropped item
+	Chance      int // Drop chance percentage (0-100)
+	MinQuantity int // Minimum quantity dropped
+	MaxQuantity int // Maximum quantity dropped
+}
+
+// LootComponent holds the rewards an enemy grants when defeated
+type LootComponent struct {
+	Experience int         // Experience granted (0 = derived from the enemy level)
+	Gold       int         // Gold granted
+	Drops      []DropEntry // Drop table rolled once per defeated enemy
+}
+
+// NewLootComponent creates a new loot component with the given gold and drop table
+func NewLootComponent(gold int, drops ...DropEntry) *LootComponent {
+	return &LootComponent{
+		Gold:  gold,
+		Drops: drops,
+	}
+}
+
+// NewDropEntry creates a drop table entry, clamping the chance and quantities to valid values
+func NewDropEntry(itemID, chance, minQuantity, maxQuantity int) DropEntry {
+	if chance < 0 {
+		chance = 0
+	} else if chance > 100 {
+		chance = 100
+	}
+	if minQuantity < 1 {
+		minQuantity = 1
+	}
+	if maxQuantity < minQuantity {
+		maxQuantity = minQuantity
+	}
+
+	return DropEntry{
+		ItemID:      itemID,
+		Chance:      chance,
+		MinQuantity: minQuantity,
+		MaxQuantity: maxQuantity,
+	}
+}
@@ -263,6 +263,31 @@ func (qj *QuestJournalComponent) UpdateQuestProgress(objectiveType ObjectiveType
 	return completedObjectives
 }
 
+// UpdateLevelProgress sets the progress of "reach level" objectives to a character's new level.
+// Objectives without a target count any job, otherwise the target is the job ID.
+func (qj *QuestJournalComponent) UpdateLevelProgress(job JobType, level int) []string {
+	var completedObjectives []string
+
+	for _, quest := range qj.ActiveQuests {
+		for _, obj := range quest.Objectives {
+			if obj.Type != ObjectiveLevel || obj.IsCompleted() {
+				continue
+			}
+			if obj.Target != "" && JobType(obj.Target) != job {
+				continue
+			}
+			if level > obj.Current {
+				obj.UpdateProgress(level - obj.Current)
+			}
+			if obj.IsCompleted() {
+				completedObjectives = append(completedObjectives, quest.Title+" - "+obj.Description)
+			}
+		}
+	}
+
+	return completedObjectives
+}
+
 // HasQuest checks if a quest is in the journal (active or completed)
 func (qj *QuestJournalComponent) HasQuest(questID string) bool {
 	_, activeExists := qj.ActiveQuests[questID]
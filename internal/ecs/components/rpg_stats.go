@@ -1,6 +1,11 @@
 package components
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+)
 
 // MoveRecord represents a single movement for undo functionality
 type MoveRecord struct {
@@ -9,15 +14,17 @@ type MoveRecord struct {
 	Distance     int // Movement cost
 }
 
-// JobType represents different character classes/jobs in the RPG
-type JobType int
+// JobType identifies a character class/job. Jobs are defined in the job data file
+// and looked up in the job registry.
+type JobType string
 
+// Built-in jobs referenced by game code
 const (
-	JobWarrior JobType = iota // High HP and Defense, moderate Attack
-	JobMage                   // High Magic Power, low HP and Defense
-	JobRogue                  // High Speed and Crit, moderate HP
-	JobCleric                 // High Magic Defense and Healing, moderate HP
-	JobArcher                 // High Range Attack and Speed, low Defense
+	JobWarrior JobType = "warrior" // High HP and Defense, moderate Attack
+	JobMage    JobType = "mage"    // High Magic Power, low HP and Defense
+	JobRogue   JobType = "rogue"   // High Speed and Crit, moderate HP
+	JobCleric  JobType = "cleric"  // High Magic Defense and Healing, moderate HP
+	JobArcher  JobType = "archer"  // High Range Attack and Speed, low Defense
 )
 
 const (
@@ -26,22 +33,39 @@ const (
 	DefaultRPGStatCritRate  = 5
 )
 
-// String returns the string representation of a JobType
+// String returns the display name of a JobType
 func (j JobType) String() string {
-	switch j {
-	case JobWarrior:
-		return "Warrior"
-	case JobMage:
-		return "Mage"
-	case JobRogue:
-		return "Rogue"
-	case JobCleric:
-		return "Cleric"
-	case JobArcher:
-		return "Archer"
-	default:
-		return "Unknown"
+	if def := j.Definition(); def != nil {
+		return def.Name
 	}
+	return "Unknown"
+}
+
+// ParseJobType converts a job ID or name from data files (case-insensitive) into a JobType
+func ParseJobType(name string) (JobType, error) {
+	name = strings.TrimSpace(name)
+	for _, def := range GetJobRegistry().GetAllJobs() {
+		if strings.EqualFold(string(def.ID), name) || strings.EqualFold(def.Name, name) {
+			return def.ID, nil
+		}
+	}
+	return JobWarrior, fmt.Errorf("unknown job: %s", name)
+}
+
+// JobProgress records the level and experience a character reached in a job
+type JobProgress struct {
+	Level      int `json:"level"`
+	Experience int `json:"experience"`
+	ExpToNext  int `json:"exp_to_next"`
+}
+
+// LevelUpEvent describes a level gained by a character
+type LevelUpEvent struct {
+	Name        string           // Character display name
+	Job         JobType          // Job that gained the level
+	Level       int              // New level
+	Gains       map[StatType]int // Base stat increases, only stats that changed
+	SkillPoints int              // Skill points granted for the level
 }
 
 // RPGStatsComponent represents the RPG statistics of a character entity.
@@ -72,9 +96,20 @@ type RPGStatsComponent struct {
 	MovesRemaining int          // Remaining moves this turn
 	MoveHistory    []MoveRecord // History of moves this turn for undo functionality
 
+	// Classic Battle Formation
+	BackRow bool // Prefers the back row in classic battle formations
+
+	// Stat Modifiers (base stats above are never changed by them)
+	Modifiers     []StatModifier  // Equipment and skill modifiers, refreshed when they change
+	StatusEffects []*StatusEffect // Temporary effects such as consumable buffs
+
+	// Elemental Affinities (equipment affinities are combined with these in battle)
+	ElementAffinities ElementProfile // Weaknesses, resistances, immunities and absorbs
+
 	// Character Info
-	Job  JobType // Character class/job
-	Name string  // Character display name
+	Job       JobType                 // Character class/job
+	JobLevels map[JobType]JobProgress // Progress in the other jobs the character has trained
+	Name      string                  // Character display name
 }
 
 // NewRPGStatsComponent creates a new RPG stats component with default values for a specific job
@@ -84,66 +119,15 @@ func NewRPGStatsComponent(name string, job JobType, level int) *RPGStatsComponen
 		Job:        job,
 		Level:      level,
 		Experience: 0,
-		ExpToNext:  level * 100, // Simple EXP formula
+		ExpToNext:  expToNextLevel(job, level),
 		Speed:      DefaultRPGStateSpeed,
 		Accuracy:   DefaultRPGStateAccuracy,
 		CritRate:   DefaultRPGStatCritRate,
+		MoveRange:  constants.DefaultMoveRange,
 	}
 
-	// Apply job-specific base stats
-	switch job {
-	case JobWarrior:
-		stats.MaxHP = 120 + (level-1)*15
-		stats.MaxMP = 30 + (level-1)*3
-		stats.Attack = 18 + (level-1)*3
-		stats.Defense = 15 + (level-1)*2
-		stats.MagicAttack = 8 + (level-1)*1
-		stats.MagicDefense = 12 + (level-1)*2
-		stats.Speed = 40 + (level-1)*1
-		stats.MoveRange = 3 // Warriors are slower but steady
-
-	case JobMage:
-		stats.MaxHP = 80 + (level-1)*8
-		stats.MaxMP = 100 + (level-1)*12
-		stats.Attack = 10 + (level-1)*1
-		stats.Defense = 8 + (level-1)*1
-		stats.MagicAttack = 25 + (level-1)*4
-		stats.MagicDefense = 20 + (level-1)*3
-		stats.Speed = 45 + (level-1)*2
-		stats.MoveRange = 2 // Mages are slow and fragile
-
-	case JobRogue:
-		stats.MaxHP = 95 + (level-1)*10
-		stats.MaxMP = 50 + (level-1)*5
-		stats.Attack = 20 + (level-1)*3
-		stats.Defense = 10 + (level-1)*1
-		stats.MagicAttack = 12 + (level-1)*1
-		stats.MagicDefense = 10 + (level-1)*1
-		stats.Speed = 65 + (level-1)*3
-		stats.CritRate = 15 + (level-1)/2
-		stats.MoveRange = 5 // Rogues are very mobile
-
-	case JobCleric:
-		stats.MaxHP = 100 + (level-1)*12
-		stats.MaxMP = 90 + (level-1)*10
-		stats.Attack = 12 + (level-1)*1
-		stats.Defense = 12 + (level-1)*2
-		stats.MagicAttack = 20 + (level-1)*3
-		stats.MagicDefense = 25 + (level-1)*4
-		stats.Speed = 35 + (level-1)*1
-		stats.MoveRange = 3 // Clerics have moderate mobility
-
-	case JobArcher:
-		stats.MaxHP = 85 + (level-1)*9
-		stats.MaxMP = 40 + (level-1)*4
-		stats.Attack = 22 + (level-1)*3
-		stats.Defense = 9 + (level-1)*1
-		stats.MagicAttack = 10 + (level-1)*1
-		stats.MagicDefense = 8 + (level-1)*1
-		stats.Speed = 60 + (level-1)*3
-		stats.Accuracy = 95 + (level-1)/5
-		stats.MoveRange = 4 // Archers need positioning flexibility
-	}
+	// Apply the job's base stats and growth
+	stats.applyJobStats()
 
 	// Set current HP/MP to max
 	stats.CurrentHP = stats.MaxHP
@@ -156,6 +140,83 @@ func NewRPGStatsComponent(name string, job JobType, level int) *RPGStatsComponen
 	return stats
 }
 
+// applyJobStats sets the stats given by the job definition at the current level
+func (r *RPGStatsComponent) applyJobStats() {
+	def := r.Job.Definition()
+	if def == nil {
+		return
+	}
+
+	r.MaxHP = def.StatAt(def.Base.HP, def.Growth.HP, r.Level)
+	r.MaxMP = def.StatAt(def.Base.MP, def.Growth.MP, r.Level)
+	r.Attack = def.StatAt(def.Base.Attack, def.Growth.Attack, r.Level)
+	r.Defense = def.StatAt(def.Base.Defense, def.Growth.Defense, r.Level)
+	r.MagicAttack = def.StatAt(def.Base.MagicAttack, def.Growth.MagicAttack, r.Level)
+	r.MagicDefense = def.StatAt(def.Base.MagicDefense, def.Growth.MagicDefense, r.Level)
+	r.Speed = def.StatAt(def.Base.Speed, def.Growth.Speed, r.Level)
+	r.Accuracy = def.StatAt(def.Base.Accuracy, def.Growth.Accuracy, r.Level)
+	r.CritRate = def.StatAt(def.Base.CritRate, def.Growth.CritRate, r.Level)
+	r.MoveRange = def.MoveRange
+}
+
+// ChangeJob switches to another job. The current job keeps its level and experience,
+// the new job resumes its own (level 1 if never trained) and stats follow its growth curve.
+func (r *RPGStatsComponent) ChangeJob(job JobType) error {
+	if job == r.Job {
+		return fmt.Errorf("%s is already a %s", r.Name, job.String())
+	}
+	def := job.Definition()
+	if def == nil {
+		return fmt.Errorf("unknown job: %s", job)
+	}
+
+	if r.JobLevels == nil {
+		r.JobLevels = make(map[JobType]JobProgress)
+	}
+	r.JobLevels[r.Job] = JobProgress{Level: r.Level, Experience: r.Experience, ExpToNext: r.ExpToNext}
+
+	progress, trained := r.JobLevels[job]
+	if !trained {
+		progress = JobProgress{Level: 1, Experience: 0}
+	}
+	delete(r.JobLevels, job)
+
+	// Experience to the next level follows the new job's own curve
+	r.Job = job
+	r.Level = progress.Level
+	r.Experience = progress.Experience
+	r.ExpToNext = def.ExpToNext(progress.Level)
+	r.applyJobStats()
+
+	// Current HP/MP never exceed the new maximums
+	r.clampToMax()
+	r.ResetMovement()
+
+	return nil
+}
+
+// GetJobLevel returns the character's level in a job, 0 if never trained
+func (r *RPGStatsComponent) GetJobLevel(job JobType) int {
+	if job == r.Job {
+		return r.Level
+	}
+	if progress, trained := r.JobLevels[job]; trained {
+		return progress.Level
+	}
+	return 0
+}
+
+// GetTrainedJobs returns the current job followed by every other trained job
+func (r *RPGStatsComponent) GetTrainedJobs() []JobType {
+	jobs := []JobType{r.Job}
+	for _, def := range GetJobRegistry().GetAllJobs() {
+		if _, trained := r.JobLevels[def.ID]; trained {
+			jobs = append(jobs, def.ID)
+		}
+	}
+	return jobs
+}
+
 // IsAlive returns true if the character has HP remaining
 func (r *RPGStatsComponent) IsAlive() bool {
 	return r.CurrentHP > 0
@@ -172,8 +233,8 @@ func (r *RPGStatsComponent) TakeDamage(damage int) {
 // Heal increases current HP by the specified amount, not exceeding max HP
 func (r *RPGStatsComponent) Heal(amount int) {
 	r.CurrentHP += amount
-	if r.CurrentHP > r.MaxHP {
-		r.CurrentHP = r.MaxHP
+	if maxHP := r.GetMaxHP(); r.CurrentHP > maxHP {
+		r.CurrentHP = maxHP
 	}
 }
 
@@ -189,47 +250,71 @@ func (r *RPGStatsComponent) UseMana(amount int) bool {
 // RestoreMana increases current MP by the specified amount, not exceeding max MP
 func (r *RPGStatsComponent) RestoreMana(amount int) {
 	r.CurrentMP += amount
-	if r.CurrentMP > r.MaxMP {
-		r.CurrentMP = r.MaxMP
+	if maxMP := r.GetMaxMP(); r.CurrentMP > maxMP {
+		r.CurrentMP = maxMP
+	}
+}
+
+// expToNextLevel returns the experience a job needs to go from a level to the next one
+func expToNextLevel(job JobType, level int) int {
+	if def := job.Definition(); def != nil {
+		return def.ExpToNext(level)
 	}
+	return level * constants.DefaultExpCurveBase
 }
 
-// GainExperience adds experience and handles level ups
-func (r *RPGStatsComponent) GainExperience(exp int) bool {
+// GainExperience adds experience and handles level ups, including several levels at once.
+// It returns one event per level gained.
+func (r *RPGStatsComponent) GainExperience(exp int) []LevelUpEvent {
 	r.Experience += exp
-	if r.Experience >= r.ExpToNext {
-		r.LevelUp()
-		return true // Level up occurred
+	events := make([]LevelUpEvent, 0)
+	for r.ExpToNext > 0 && r.Experience >= r.ExpToNext {
+		events = append(events, r.LevelUp())
 	}
-	return false
+	return events
 }
 
-// LevelUp increases the character's level and recalculates stats
-func (r *RPGStatsComponent) LevelUp() {
+// LevelUp increases the character's level and recalculates stats from the job's growth curve
+func (r *RPGStatsComponent) LevelUp() LevelUpEvent {
 	r.Level++
 	r.Experience -= r.ExpToNext
-	r.ExpToNext = r.Level * 100
-
-	// Recalculate stats based on new level (this is a simple approach)
-	// In a real game, you might want more sophisticated stat growth
-	oldMaxHP := r.MaxHP
-	oldMaxMP := r.MaxMP
-
-	// Create a new stats component with updated level to get new max values
-	newStats := NewRPGStatsComponent(r.Name, r.Job, r.Level)
-
-	// Update max stats
-	r.MaxHP = newStats.MaxHP
-	r.MaxMP = newStats.MaxMP
-	r.Attack = newStats.Attack
-	r.Defense = newStats.Defense
-	r.MagicAttack = newStats.MagicAttack
-	r.MagicDefense = newStats.MagicDefense
-	r.Speed = newStats.Speed
-
-	// Restore HP/MP proportionally or fully heal on level up
-	r.CurrentHP += (r.MaxHP - oldMaxHP) // Add the HP increase
-	r.CurrentMP += (r.MaxMP - oldMaxMP) // Add the MP increase
+	if r.Experience < 0 {
+		r.Experience = 0
+	}
+	r.ExpToNext = expToNextLevel(r.Job, r.Level)
+
+	growthStats := []StatType{
+		StatMaxHP, StatMaxMP, StatAttack, StatDefense, StatMagicAttack,
+		StatMagicDefense, StatSpeed, StatAccuracy, StatCritRate,
+	}
+	oldStats := make(map[StatType]int, len(growthStats))
+	for _, stat := range growthStats {
+		oldStats[stat] = r.GetBaseStat(stat)
+	}
+
+	r.applyJobStats()
+
+	event := LevelUpEvent{
+		Name:        r.Name,
+		Job:         r.Job,
+		Level:       r.Level,
+		Gains:       make(map[StatType]int),
+		SkillPoints: constants.DefaultSkillPointsPerLevel,
+	}
+	if def := r.Job.Definition(); def != nil {
+		event.SkillPoints = def.SkillPoints
+	}
+	for _, stat := range growthStats {
+		if gain := r.GetBaseStat(stat) - oldStats[stat]; gain != 0 {
+			event.Gains[stat] = gain
+		}
+	}
+
+	// The HP/MP increase is also restored
+	r.CurrentHP += event.Gains[StatMaxHP]
+	r.CurrentMP += event.Gains[StatMaxMP]
+
+	return event
 }
 
 // ConsumeMovement reduces remaining movement by the specified distance
@@ -243,7 +328,7 @@ func (r *RPGStatsComponent) ConsumeMovement(distance int) bool {
 
 // ResetMovement restores movement for a new turn
 func (r *RPGStatsComponent) ResetMovement() {
-	r.MovesRemaining = r.MoveRange
+	r.MovesRemaining = r.GetStat(StatMoveRange)
 	r.MoveHistory = make([]MoveRecord, 0) // Clear movement history
 }
 
@@ -295,8 +380,8 @@ func (r *RPGStatsComponent) TryUndoMove(toX, toZ int) (bool, int) {
 
 			// Restore movement points
 			r.MovesRemaining += movesToRecover
-			if r.MovesRemaining > r.MoveRange {
-				r.MovesRemaining = r.MoveRange
+			if moveRange := r.GetStat(StatMoveRange); r.MovesRemaining > moveRange {
+				r.MovesRemaining = moveRange
 			}
 
 			return true, movesToRecover
@@ -1,5 +1,11 @@
 package components
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // SkillType represents different types of skills
 type SkillType int
 
@@ -25,9 +31,19 @@ func (st SkillType) String() string {
 	}
 }
 
+// ParseSkillType converts a skill type name from data files (case-insensitive) to a SkillType
+func ParseSkillType(name string) (SkillType, error) {
+	for skillType := SkillTypePassive; skillType <= SkillTypeUpgrade; skillType++ {
+		if strings.EqualFold(skillType.String(), strings.TrimSpace(name)) {
+			return skillType, nil
+		}
+	}
+	return SkillTypePassive, fmt.Errorf("unknown skill type: %s", name)
+}
+
 // SkillEffect represents the effect a skill has when learned
 type SkillEffect struct {
-	Type        string      // "stat_bonus", "ability_unlock", "passive_effect"
+	Type        string      // "stat_bonus", "stat_percent", "ability_unlock", "passive_effect"
 	Target      string      // What is affected (HP, Attack, etc.)
 	Value       int         // Numeric value for the effect
 	Description string      // Human readable description
@@ -46,8 +62,17 @@ type Skill struct {
 	SkillPoints   int           // Cost in skill points to learn
 	Effects       []SkillEffect // What this skill does when learned
 	IconPath      string        // Path to skill icon
-	IsLearned     bool          // Whether the character has learned this skill
-	IsAvailable   bool          // Whether the skill can be learned now
+
+	// Active ability costs and targeting (active skills only)
+	MPCost   int    // MP spent per use
+	APCost   int    // Tactical action points spent per use
+	Cooldown int    // Turns before the ability can be used again (0 = every turn)
+	Charges  int    // Uses per battle (0 = unlimited)
+	Range    int    // Tactical range in tiles
+	Target   string // Target type: "enemy", "ally", "all_enemies", "all_allies", "random" or "row"
+
+	IsLearned   bool // Whether the character has learned this skill
+	IsAvailable bool // Whether the skill can be learned now
 }
 
 // SkillNode represents a skill in the visual skill tree
@@ -71,9 +96,13 @@ type SkillsComponent struct {
 	AvailablePoints int                    // Unspent skill points
 	TotalPoints     int                    // Total skill points ever earned
 	LearnedSkills   map[string]*Skill      // Skills the character has learned
-	SkillTrees      map[JobType]*SkillTree // Available skill trees for this character
+	SkillTrees      map[JobType]*SkillTree // Skill trees of every job the character has trained
 	ActiveAbilities []string               // Currently equipped active skills
 	MaxActiveSlots  int                    // Maximum active abilities that can be equipped
+	CurrentJob      JobType                // Job whose active skills can be used
+	SecondaryJob    JobType                // Trained job whose learned active skills can also be used ("" = none)
+	PassiveEffects  map[string]int         // Totals of the learned "passive_effect" effects (e.g. HP_Regen)
+	Cooldowns       *AbilityCooldowns      // Active ability cooldowns and charges used in the current battle
 }
 
 // NewSkillsComponent creates a new skills component for a character
@@ -85,7 +114,84 @@ func NewSkillsComponent(jobClass JobType) *SkillsComponent {
 		SkillTrees:      make(map[JobType]*SkillTree),
 		ActiveAbilities: make([]string, 0),
 		MaxActiveSlots:  4, // Default 4 active ability slots
+		CurrentJob:      jobClass,
+		PassiveEffects:  make(map[string]int),
+		Cooldowns:       NewAbilityCooldowns(),
+	}
+}
+
+// TrainJob records the skill tree of a job the character has trained (nil if the job has no tree)
+func (sc *SkillsComponent) TrainJob(job JobType, tree *SkillTree) {
+	if existing, trained := sc.SkillTrees[job]; !trained || existing == nil {
+		sc.SkillTrees[job] = tree
+	}
+}
+
+// ChangeJob makes another job the current one; learned skills of every job are kept
+func (sc *SkillsComponent) ChangeJob(job JobType, tree *SkillTree) {
+	sc.TrainJob(job, tree)
+	sc.CurrentJob = job
+	if sc.SecondaryJob == job {
+		sc.SecondaryJob = ""
+	}
+	sc.unequipUnusableAbilities()
+}
+
+// SetSecondaryJob selects the trained job whose learned active skills can also be used ("" = none)
+func (sc *SkillsComponent) SetSecondaryJob(job JobType) error {
+	if job != "" {
+		if job == sc.CurrentJob {
+			return fmt.Errorf("%s is the current job", job.String())
+		}
+		if _, trained := sc.SkillTrees[job]; !trained {
+			return fmt.Errorf("%s has not been trained", job.String())
+		}
+	}
+	sc.SecondaryJob = job
+	sc.unequipUnusableAbilities()
+	return nil
+}
+
+// skillTreeID returns the ID of the skill tree a job uses
+func skillTreeID(job JobType) string {
+	if def := job.Definition(); def != nil {
+		return def.GetSkillTreeID()
+	}
+	return string(job)
+}
+
+// CanUseSkill returns true if a learned active skill belongs to the current or secondary job
+func (sc *SkillsComponent) CanUseSkill(skill *Skill) bool {
+	if skill.Type != SkillTypeActive || sc.CurrentJob == "" {
+		return true
+	}
+	tree := skillTreeID(skill.JobClass)
+	if tree == skillTreeID(sc.CurrentJob) {
+		return true
+	}
+	return sc.SecondaryJob != "" && tree == skillTreeID(sc.SecondaryJob)
+}
+
+// GetUsableActiveSkills returns the learned active skills of the current and secondary jobs
+func (sc *SkillsComponent) GetUsableActiveSkills() []*Skill {
+	var skills []*Skill
+	for _, skill := range sc.LearnedSkills {
+		if skill.Type == SkillTypeActive && sc.CanUseSkill(skill) {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
+
+// unequipUnusableAbilities removes equipped abilities the current jobs cannot use
+func (sc *SkillsComponent) unequipUnusableAbilities() {
+	equipped := make([]string, 0, len(sc.ActiveAbilities))
+	for _, skillID := range sc.ActiveAbilities {
+		if skill, learned := sc.LearnedSkills[skillID]; learned && sc.CanUseSkill(skill) {
+			equipped = append(equipped, skillID)
+		}
 	}
+	sc.ActiveAbilities = equipped
 }
 
 // CanLearnSkill checks if a skill can be learned by this character
@@ -110,7 +216,8 @@ func (sc *SkillsComponent) CanLearnSkill(skill *Skill) bool {
 	return true
 }
 
-// LearnSkill learns a skill and applies its effects
+// LearnSkill spends the skill points and marks the skill as learned. The skill effects are
+// applied by the skill effect handlers (skills.LearnSkill).
 func (sc *SkillsComponent) LearnSkill(skill *Skill) bool {
 	if !sc.CanLearnSkill(skill) {
 		return false
@@ -133,6 +240,89 @@ func (sc *SkillsComponent) AddSkillPoints(points int) {
 	sc.TotalPoints += points
 }
 
+// GetDependentSkills returns the learned skills that require a skill as prerequisite
+func (sc *SkillsComponent) GetDependentSkills(skillID string) []string {
+	dependents := make([]string, 0)
+	for _, skill := range sc.LearnedSkills {
+		for _, prereqID := range skill.Prerequisites {
+			if prereqID == skillID {
+				dependents = append(dependents, skill.ID)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// CanForgetSkill returns an error unless the skill is learned and no learned skill depends on it
+func (sc *SkillsComponent) CanForgetSkill(skillID string) error {
+	skill, learned := sc.LearnedSkills[skillID]
+	if !learned {
+		return fmt.Errorf("skill %s is not learned", skillID)
+	}
+	if dependents := sc.GetDependentSkills(skillID); len(dependents) > 0 {
+		return fmt.Errorf("%s is required by %s", skill.Name, strings.Join(dependents, ", "))
+	}
+	return nil
+}
+
+// ForgetSkill unlearns a leaf skill, refunding its skill points and unequipping it. The skill
+// effects are removed by the skill effect handlers (skills.ForgetSkill).
+func (sc *SkillsComponent) ForgetSkill(skillID string) (*Skill, error) {
+	if err := sc.CanForgetSkill(skillID); err != nil {
+		return nil, err
+	}
+	skill := sc.LearnedSkills[skillID]
+	delete(sc.LearnedSkills, skillID)
+	sc.UnequipActiveAbility(skillID)
+	sc.AvailablePoints += skill.SkillPoints
+	return skill, nil
+}
+
+// ResetSkills unlearns every skill, refunds all skill points ever earned and clears the
+// equipped abilities. It returns the forgotten skills, whose effects the skill effect
+// handlers remove (skills.ResetSkills).
+func (sc *SkillsComponent) ResetSkills() []*Skill {
+	forgotten := make([]*Skill, 0, len(sc.LearnedSkills))
+	for _, skill := range sc.LearnedSkills {
+		forgotten = append(forgotten, skill)
+	}
+	sort.Slice(forgotten, func(i, j int) bool {
+		return forgotten[i].ID < forgotten[j].ID
+	})
+
+	sc.LearnedSkills = make(map[string]*Skill)
+	sc.ActiveAbilities = make([]string, 0)
+	sc.AvailablePoints = sc.TotalPoints
+	return forgotten
+}
+
+// GetSpentPoints returns the skill points spent on learned skills
+func (sc *SkillsComponent) GetSpentPoints() int {
+	spent := 0
+	for _, skill := range sc.LearnedSkills {
+		spent += skill.SkillPoints
+	}
+	return spent
+}
+
+// AddPassiveEffect adds a value to a passive effect total, removing the effect when it drops to zero
+func (sc *SkillsComponent) AddPassiveEffect(name string, value int) {
+	if sc.PassiveEffects == nil {
+		sc.PassiveEffects = make(map[string]int)
+	}
+	sc.PassiveEffects[name] += value
+	if sc.PassiveEffects[name] == 0 {
+		delete(sc.PassiveEffects, name)
+	}
+}
+
+// GetPassiveEffect returns the total value of a passive effect, 0 if no learned skill grants it
+func (sc *SkillsComponent) GetPassiveEffect(name string) int {
+	return sc.PassiveEffects[name]
+}
+
 // GetLearnedSkillsByType returns all learned skills of a specific type
 func (sc *SkillsComponent) GetLearnedSkillsByType(skillType SkillType) []*Skill {
 	var skills []*Skill
@@ -144,6 +334,51 @@ func (sc *SkillsComponent) GetLearnedSkillsByType(skillType SkillType) []*Skill
 	return skills
 }
 
+// GetStatModifiers returns the stat modifiers of the learned skills' "stat_bonus" (flat)
+// and "stat_percent" effects, in skill ID order
+func (sc *SkillsComponent) GetStatModifiers() []StatModifier {
+	ids := make([]string, 0, len(sc.LearnedSkills))
+	for id := range sc.LearnedSkills {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	modifiers := make([]StatModifier, 0)
+	for _, id := range ids {
+		modifiers = append(modifiers, sc.LearnedSkills[id].GetStatModifiers(SourceSkill)...)
+	}
+	return modifiers
+}
+
+// GetStatModifiers returns the stat modifiers of the skill's "stat_bonus" (flat) and
+// "stat_percent" effects
+func (s *Skill) GetStatModifiers(source ModifierSource) []StatModifier {
+	modifiers := make([]StatModifier, 0)
+	for _, effect := range s.Effects {
+		modifierType := ModifierFlat
+		switch effect.Type {
+		case "stat_bonus":
+		case "stat_percent":
+			modifierType = ModifierPercent
+		default:
+			continue
+		}
+
+		stat, err := ParseStatType(effect.Target)
+		if err != nil {
+			continue
+		}
+		modifiers = append(modifiers, StatModifier{
+			Stat:       stat,
+			Type:       modifierType,
+			Value:      effect.Value,
+			Source:     source,
+			SourceName: s.Name,
+		})
+	}
+	return modifiers
+}
+
 // GetActiveAbilities returns currently equipped active abilities
 func (sc *SkillsComponent) GetActiveAbilities() []*Skill {
 	var abilities []*Skill
@@ -157,9 +392,9 @@ func (sc *SkillsComponent) GetActiveAbilities() []*Skill {
 
 // EquipActiveAbility equips an active ability if there's a free slot
 func (sc *SkillsComponent) EquipActiveAbility(skillID string) bool {
-	// Check if skill is learned and is active type
+	// Check if skill is learned, is active type and belongs to the current or secondary job
 	skill, learned := sc.LearnedSkills[skillID]
-	if !learned || skill.Type != SkillTypeActive {
+	if !learned || skill.Type != SkillTypeActive || !sc.CanUseSkill(skill) {
 		return false
 	}
 
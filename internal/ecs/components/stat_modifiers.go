@@ -0,0 +1,308 @@
+// Package components provides the stat modifier pipeline that derives effective stats
+// from base stats plus equipment, passive skills and status effects
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+)
+
+// StatType identifies a stat that modifiers can change
+type StatType int
+
+const (
+	StatMaxHP        StatType = iota // Maximum health points
+	StatMaxMP                        // Maximum mana points
+	StatAttack                       // Physical attack power
+	StatDefense                      // Physical defense
+	StatMagicAttack                  // Magic attack power
+	StatMagicDefense                 // Magic defense
+	StatSpeed                        // Speed/agility
+	StatAccuracy                     // Hit accuracy percentage
+	StatCritRate                     // Critical hit rate percentage
+	StatCritDamage                   // Bonus critical damage percentage
+	StatEvasion                      // Evasion percentage
+	StatMoveRange                    // Tactical movement range in tiles
+	StatAP                           // Tactical action points per turn
+	statCount                        // Number of stat types
+)
+
+func (s StatType) String() string {
+	switch s {
+	case StatMaxHP:
+		return "MaxHP"
+	case StatMaxMP:
+		return "MaxMP"
+	case StatAttack:
+		return "Attack"
+	case StatDefense:
+		return "Defense"
+	case StatMagicAttack:
+		return "MagicAttack"
+	case StatMagicDefense:
+		return "MagicDefense"
+	case StatSpeed:
+		return "Speed"
+	case StatAccuracy:
+		return "Accuracy"
+	case StatCritRate:
+		return "CritRate"
+	case StatCritDamage:
+		return "CritDamage"
+	case StatEvasion:
+		return "Evasion"
+	case StatMoveRange:
+		return "MoveRange"
+	case StatAP:
+		return "AP"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseStatType converts a stat name ("Attack", "magic_attack", "HP") to a StatType
+func ParseStatType(name string) (StatType, error) {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", " ", "").Replace(name))
+	switch normalized {
+	case "hp":
+		return StatMaxHP, nil
+	case "mp":
+		return StatMaxMP, nil
+	case "magicpower":
+		return StatMagicAttack, nil
+	case "movement":
+		return StatMoveRange, nil
+	}
+	for stat := StatType(0); stat < statCount; stat++ {
+		if strings.ToLower(stat.String()) == normalized {
+			return stat, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown stat: %s", name)
+}
+
+// ModifierType tells how a modifier value is applied
+type ModifierType int
+
+const (
+	ModifierFlat    ModifierType = iota // Value is added to the base stat
+	ModifierPercent                     // Value is a percentage of the stat after flat modifiers
+)
+
+// ModifierSource tells where a modifier comes from
+type ModifierSource int
+
+const (
+	SourceEquipment ModifierSource = iota // Equipped items
+	SourceSkill                           // Learned passive skills
+	SourceStatus                          // Status effects and consumable buffs
+	SourceSet                             // Equipment set bonuses
+)
+
+func (ms ModifierSource) String() string {
+	switch ms {
+	case SourceEquipment:
+		return "Equipment"
+	case SourceSkill:
+		return "Skill"
+	case SourceStatus:
+		return "Status"
+	case SourceSet:
+		return "Set"
+	default:
+		return "Unknown"
+	}
+}
+
+// StatModifier changes one stat by a flat amount or a percentage
+type StatModifier struct {
+	Stat       StatType       // Stat being modified
+	Type       ModifierType   // Flat or percentage
+	Value      int            // Amount, or percentage points for percentage modifiers
+	Source     ModifierSource // Where the modifier comes from
+	SourceName string         // Name of the item, skill or effect granting it
+}
+
+// StatusEffect is a temporary set of modifiers, such as a consumable buff
+type StatusEffect struct {
+	Name      string         // Effect name, one effect per name
+	Modifiers []StatModifier // Modifiers applied while the effect lasts
+	Battles   int            // Battles the effect lasts (0 = until removed)
+}
+
+// NewStatusEffect creates a status effect with a single flat modifier
+func NewStatusEffect(name string, stat StatType, value, battles int) *StatusEffect {
+	return &StatusEffect{
+		Name: name,
+		Modifiers: []StatModifier{
+			{Stat: stat, Type: ModifierFlat, Value: value, Source: SourceStatus, SourceName: name},
+		},
+		Battles: battles,
+	}
+}
+
+// GetBaseStat returns a stat before any modifier
+func (r *RPGStatsComponent) GetBaseStat(stat StatType) int {
+	switch stat {
+	case StatMaxHP:
+		return r.MaxHP
+	case StatMaxMP:
+		return r.MaxMP
+	case StatAttack:
+		return r.Attack
+	case StatDefense:
+		return r.Defense
+	case StatMagicAttack:
+		return r.MagicAttack
+	case StatMagicDefense:
+		return r.MagicDefense
+	case StatSpeed:
+		return r.Speed
+	case StatAccuracy:
+		return r.Accuracy
+	case StatCritRate:
+		return r.CritRate
+	case StatMoveRange:
+		return r.MoveRange
+	case StatAP:
+		if def := r.Job.Definition(); def != nil {
+			return def.MaxAP
+		}
+		return constants.DefaultMaxAP
+	default:
+		return 0
+	}
+}
+
+// GetStat returns the effective stat: (base + flat modifiers) scaled by percentage modifiers
+func (r *RPGStatsComponent) GetStat(stat StatType) int {
+	flat, percent := 0, 0
+	for _, modifier := range r.GetStatModifiers() {
+		if modifier.Stat != stat {
+			continue
+		}
+		if modifier.Type == ModifierPercent {
+			percent += modifier.Value
+		} else {
+			flat += modifier.Value
+		}
+	}
+
+	value := (r.GetBaseStat(stat) + flat) * (100 + percent) / 100
+	if value < 0 {
+		value = 0
+	}
+	return value
+}
+
+// GetStatBonus returns how much the modifiers add to a stat
+func (r *RPGStatsComponent) GetStatBonus(stat StatType) int {
+	return r.GetStat(stat) - r.GetBaseStat(stat)
+}
+
+// GetMaxHP returns the effective maximum HP
+func (r *RPGStatsComponent) GetMaxHP() int {
+	return r.GetStat(StatMaxHP)
+}
+
+// GetMaxMP returns the effective maximum MP
+func (r *RPGStatsComponent) GetMaxMP() int {
+	return r.GetStat(StatMaxMP)
+}
+
+// GetStatModifiers returns the equipment and skill modifiers followed by the status effect modifiers
+func (r *RPGStatsComponent) GetStatModifiers() []StatModifier {
+	modifiers := make([]StatModifier, 0, len(r.Modifiers))
+	modifiers = append(modifiers, r.Modifiers...)
+	for _, effect := range r.StatusEffects {
+		modifiers = append(modifiers, effect.Modifiers...)
+	}
+	return modifiers
+}
+
+// SetModifiers replaces the equipment and skill modifiers, keeping current HP/MP within the new maximums
+func (r *RPGStatsComponent) SetModifiers(modifiers []StatModifier) {
+	r.Modifiers = modifiers
+	r.clampToMax()
+}
+
+// AddStatusEffect applies a status effect, replacing any effect with the same name
+func (r *RPGStatsComponent) AddStatusEffect(effect *StatusEffect) {
+	r.RemoveStatusEffect(effect.Name)
+	r.StatusEffects = append(r.StatusEffects, effect)
+}
+
+// RemoveStatusEffect removes a status effect by name
+func (r *RPGStatsComponent) RemoveStatusEffect(name string) {
+	for i, effect := range r.StatusEffects {
+		if effect.Name == name {
+			r.StatusEffects = append(r.StatusEffects[:i], r.StatusEffects[i+1:]...)
+			break
+		}
+	}
+	r.clampToMax()
+}
+
+// TickStatusEffects counts a finished battle and removes the status effects that expired
+func (r *RPGStatsComponent) TickStatusEffects() {
+	remaining := r.StatusEffects[:0]
+	for _, effect := range r.StatusEffects {
+		if effect.Battles > 0 {
+			effect.Battles--
+			if effect.Battles == 0 {
+				continue
+			}
+		}
+		remaining = append(remaining, effect)
+	}
+	r.StatusEffects = remaining
+	r.clampToMax()
+}
+
+// clampToMax keeps current HP/MP within the effective maximums
+func (r *RPGStatsComponent) clampToMax() {
+	if maxHP := r.GetMaxHP(); r.CurrentHP > maxHP {
+		r.CurrentHP = maxHP
+	}
+	if maxMP := r.GetMaxMP(); r.CurrentMP > maxMP {
+		r.CurrentMP = maxMP
+	}
+}
+
+// ToModifiers converts equipment stat bonuses to flat stat modifiers
+func (es EquipmentStats) ToModifiers(source ModifierSource, sourceName string) []StatModifier {
+	bonuses := []struct {
+		stat  StatType
+		value int
+	}{
+		{StatAttack, es.AttackBonus},
+		{StatDefense, es.DefenseBonus},
+		{StatMagicAttack, es.MagicPowerBonus},
+		{StatMagicDefense, es.MagicDefBonus},
+		{StatSpeed, es.SpeedBonus},
+		{StatMaxHP, es.HPBonus},
+		{StatMaxMP, es.MPBonus},
+		{StatCritRate, es.CritChanceBonus},
+		{StatCritDamage, es.CritDamageBonus},
+		{StatAccuracy, es.AccuracyBonus},
+		{StatEvasion, es.EvasionBonus},
+		{StatMoveRange, es.MovementBonus},
+		{StatAP, es.APBonus},
+	}
+
+	modifiers := make([]StatModifier, 0)
+	for _, bonus := range bonuses {
+		if bonus.value != 0 {
+			modifiers = append(modifiers, StatModifier{
+				Stat:       bonus.stat,
+				Type:       ModifierFlat,
+				Value:      bonus.value,
+				Source:     source,
+				SourceName: sourceName,
+			})
+		}
+	}
+	return modifiers
+}
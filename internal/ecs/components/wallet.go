@@ -0,0 +1,152 @@
+// Package components provides the wallet component holding gold and other currencies
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+)
+
+// Currency identifies a kind of money held in a wallet
+type Currency string
+
+const (
+	CurrencyGold  Currency = "gold"  // Main currency: loot, quests, shops
+	CurrencyToken Currency = "token" // Secondary currency for special rewards
+)
+
+// DisplayName returns the currency name as shown in the UI
+func (c Currency) DisplayName() string {
+	if c == "" {
+		return ""
+	}
+	return strings.ToUpper(string(c[:1])) + string(c[1:])
+}
+
+// WalletTransaction records a single credit or debit of a wallet
+type WalletTransaction struct {
+	Currency Currency  // Currency moved
+	Amount   int       // Amount moved: positive for credits, negative for debits
+	Balance  int       // Balance of the currency after the transaction
+	Reason   string    // What the money was received or spent for
+	Time     time.Time // When the transaction happened
+}
+
+// WalletComponent holds the balance of every currency and the most recent transactions
+type WalletComponent struct {
+	Balances map[Currency]int    // Balance per currency
+	History  []WalletTransaction // Most recent transactions, oldest first
+}
+
+// NewWalletComponent creates an empty wallet
+func NewWalletComponent() *WalletComponent {
+	return &WalletComponent{
+		Balances: make(map[Currency]int),
+		History:  make([]WalletTransaction, 0),
+	}
+}
+
+// Balance returns the amount held of a currency
+func (w *WalletComponent) Balance(currency Currency) int {
+	return w.Balances[currency]
+}
+
+// Credit adds an amount of a currency to the wallet
+func (w *WalletComponent) Credit(currency Currency, amount int, reason string) {
+	if amount <= 0 {
+		return
+	}
+	w.Balances[currency] += amount
+	w.record(currency, amount, reason)
+}
+
+// Debit takes an amount of a currency from the wallet, failing without changes if the
+// balance is not enough
+func (w *WalletComponent) Debit(currency Currency, amount int, reason string) error {
+	if amount <= 0 {
+		return fmt.Errorf("invalid amount: %d", amount)
+	}
+	if w.Balances[currency] < amount {
+		return fmt.Errorf("not enough %s (%d needed, %d held)", currency, amount, w.Balances[currency])
+	}
+	w.Balances[currency] -= amount
+	w.record(currency, -amount, reason)
+	return nil
+}
+
+// Take removes up to an amount of a currency, never going below zero, and returns the
+// amount removed
+func (w *WalletComponent) Take(currency Currency, amount int, reason string) int {
+	if amount > w.Balances[currency] {
+		amount = w.Balances[currency]
+	}
+	if amount <= 0 {
+		return 0
+	}
+	w.Balances[currency] -= amount
+	w.record(currency, -amount, reason)
+	return amount
+}
+
+// SetBalance replaces the balance of a currency without recording a transaction, used
+// when restoring saved games
+func (w *WalletComponent) SetBalance(currency Currency, amount int) {
+	if amount <= 0 {
+		delete(w.Balances, currency)
+		return
+	}
+	w.Balances[currency] = amount
+}
+
+// GetCurrencies returns every currency with a positive balance, gold first
+func (w *WalletComponent) GetCurrencies() []Currency {
+	currencies := make([]Currency, 0, len(w.Balances))
+	for currency, balance := range w.Balances {
+		if balance > 0 {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		if currencies[i] == CurrencyGold || currencies[j] == CurrencyGold {
+			return currencies[i] == CurrencyGold
+		}
+		return currencies[i] < currencies[j]
+	})
+	return currencies
+}
+
+// GetRecentTransactions returns up to count of the latest transactions, newest first
+func (w *WalletComponent) GetRecentTransactions(count int) []WalletTransaction {
+	if count > len(w.History) {
+		count = len(w.History)
+	} else if count < 0 {
+		count = 0
+	}
+	recent := make([]WalletTransaction, 0, count)
+	for i := len(w.History) - 1; i >= len(w.History)-count; i-- {
+		recent = append(recent, w.History[i])
+	}
+	return recent
+}
+
+// record appends a transaction, dropping the oldest ones past constants.WalletHistoryLimit
+func (w *WalletComponent) record(currency Currency, amount int, reason string) {
+	w.History = append(w.History, WalletTransaction{
+		Currency: currency,
+		Amount:   amount,
+		Balance:  w.Balances[currency],
+		Reason:   reason,
+		Time:     time.Now(),
+	})
+	if len(w.History) > constants.WalletHistoryLimit {
+		w.History = w.History[len(w.History)-constants.WalletHistoryLimit:]
+	}
+}
+
+// String formats a transaction for logs and message panels
+func (wt WalletTransaction) String() string {
+	return fmt.Sprintf("%+d %s (%s)", wt.Amount, wt.Currency, wt.Reason)
+}
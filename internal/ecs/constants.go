@@ -20,6 +20,8 @@ const (
 	ComponentSkills       = "skills"
 	ComponentQuestJournal = "questjournal"
 	ComponentEvent        = "event"
+	ComponentLoot         = "loot"
+	ComponentBattleAI     = "battleai"
 )
 
 // Common entity tags
@@ -146,6 +146,71 @@ func (e *Entity) Skills() *components.SkillsComponent {
 	return nil
 }
 
+// RefreshStatModifiers collects the stat modifiers of the entity's equipment, equipment sets and learned skills
+// into its RPGStatsComponent. It must be called whenever equipment or learned skills change.
+// returns nothing.
+func (e *Entity) RefreshStatModifiers() {
+	stats := e.RPGStats()
+	if stats == nil {
+		return
+	}
+
+	modifiers := make([]components.StatModifier, 0)
+	if equipment := e.Equipment(); equipment != nil {
+		modifiers = append(modifiers, equipment.GetStatModifiers()...)
+		modifiers = append(modifiers, equipment.GetSetStatModifiers()...)
+	}
+	if skills := e.Skills(); skills != nil {
+		modifiers = append(modifiers, skills.GetStatModifiers()...)
+	}
+	stats.SetModifiers(modifiers)
+}
+
+// GetElementAffinity combines the entity's own affinity to an element with the affinities
+// of its equipment, the most protective one wins.
+// returns AffinityNormal for non-elemental damage or entities without stats.
+func (e *Entity) GetElementAffinity(element components.Element) components.ElementAffinity {
+	stats := e.RPGStats()
+	if stats == nil || element == components.ElementNone {
+		return components.AffinityNormal
+	}
+
+	affinities := []components.ElementAffinity{stats.GetElementAffinity(element)}
+	if equipment := e.Equipment(); equipment != nil {
+		affinities = append(affinities, equipment.GetElementAffinities(element)...)
+	}
+	return components.CombineAffinities(affinities...)
+}
+
+// TakeElementalDamage applies damage of an element scaled by the entity's affinity to it.
+// Absorbed damage heals the entity instead.
+// returns the damage dealt (minus the HP healed by absorbed damage) and the affinity applied.
+func (e *Entity) TakeElementalDamage(damage int, element components.Element) (int, components.ElementAffinity) {
+	stats := e.RPGStats()
+	if stats == nil {
+		return 0, components.AffinityNormal
+	}
+
+	affinity := e.GetElementAffinity(element)
+	damage = affinity.ApplyDamage(damage)
+	if damage < 0 {
+		before := stats.CurrentHP
+		stats.Heal(-damage)
+		return before - stats.CurrentHP, affinity
+	}
+	stats.TakeDamage(damage)
+	return damage, affinity
+}
+
+// WeaponElement retrieves the element of the entity's basic attacks.
+// returns the equipped weapon element or ElementNone.
+func (e *Entity) WeaponElement() components.Element {
+	if equipment := e.Equipment(); equipment != nil {
+		return equipment.GetWeaponElement()
+	}
+	return components.ElementNone
+}
+
 // QuestJournal retrieves the QuestJournalComponent from the entity.
 // returns a pointer to the QuestJournalComponent or nil if not found.
 func (e *Entity) QuestJournal() *components.QuestJournalComponent {
@@ -201,6 +266,28 @@ func (e *Entity) CombatState() *components.CombatStateComponent {
 	return nil
 }
 
+// BattleAI retrieves the BattleAIComponent from the entity.
+// returns a pointer to the BattleAIComponent or nil if not found.
+func (e *Entity) BattleAI() *components.BattleAIComponent {
+	if comp, exists := e.GetComponent(ComponentBattleAI); exists {
+		if ai, ok := comp.(*components.BattleAIComponent); ok {
+			return ai
+		}
+	}
+	return nil
+}
+
+// Loot retrieves the LootComponent from the entity.
+// returns a pointer to the LootComponent or nil if not found.
+func (e *Entity) Loot() *components.LootComponent {
+	if comp, exists := e.GetComponent(ComponentLoot); exists {
+		if loot, ok := comp.(*components.LootComponent); ok {
+			return loot
+		}
+	}
+	return nil
+}
+
 // AddTag adds a tag to the entity.
 // tag is the tag string to add.
 func (e *Entity) AddTag(tag string) {
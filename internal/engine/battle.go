@@ -165,10 +165,10 @@ func (bs *BattleSystem) executePlayerAttack() {
 
 	switch bs.SelectedAttack {
 	case AttackPhysical:
-		damage = bs.calculatePhysicalDamage(playerStats.Attack, enemyStats.Defense)
+		damage = bs.calculatePhysicalDamage(playerStats.GetStat(components.StatAttack), enemyStats.GetStat(components.StatDefense))
 		attackName = "Physical Attack"
 	case AttackMagical:
-		damage = bs.calculateMagicalDamage(playerStats.MagicAttack, enemyStats.MagicDefense)
+		damage = bs.calculateMagicalDamage(playerStats.GetStat(components.StatMagicAttack), enemyStats.GetStat(components.StatMagicDefense))
 		attackName = "Magical Attack"
 	}
 
@@ -211,10 +211,10 @@ func (bs *BattleSystem) executeEnemyRetaliation() {
 
 	switch bs.SelectedAttack {
 	case AttackPhysical:
-		damage = bs.calculatePhysicalDamage(enemyStats.Attack, playerStats.Defense)
+		damage = bs.calculatePhysicalDamage(enemyStats.GetStat(components.StatAttack), playerStats.GetStat(components.StatDefense))
 		attackName = "Physical Attack"
 	case AttackMagical:
-		damage = bs.calculateMagicalDamage(enemyStats.MagicAttack, playerStats.MagicDefense)
+		damage = bs.calculateMagicalDamage(enemyStats.GetStat(components.StatMagicAttack), playerStats.GetStat(components.StatMagicDefense))
 		attackName = "Magical Attack"
 	}
 
@@ -0,0 +1,34 @@
+// Package engine provides post-battle reward handling shared by the classic and tactical battle systems
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/systems"
+)
+
+// grantBattleRewards distributes experience and loot to the party and adds the gold to the party
+func (g *Game) grantBattleRewards(players, enemies []*ecs.Entity) *systems.BattleRewards {
+	rewards := g.rewardManager.DistributeRewards(players, enemies)
+	g.partyManager.AddGold(rewards.Gold, "Battle victory")
+
+	logger.Info("🏆 Battle rewards: %d XP (%d each), %d gold, %d item drops",
+		rewards.Experience, rewards.ExperienceEach, rewards.Gold, len(rewards.Items))
+
+	g.uiManager.AddMessage(fmt.Sprintf("Victory! Gained %d XP and %d gold", rewards.Experience, rewards.Gold))
+	for _, levelUp := range rewards.LevelUps {
+		g.uiManager.AddMessage(fmt.Sprintf("%s reached level %d!", levelUp.Name, levelUp.NewLevel))
+	}
+
+	return rewards
+}
+
+// showVictorySummary shows the rewards of a victory in the info widget
+func (g *Game) showVictorySummary(rewards *systems.BattleRewards) {
+	lines := rewards.SummaryLines()
+	lines = append(lines, fmt.Sprintf("Party gold: %d", g.partyManager.GetGold()))
+	g.uiManager.ShowInfoWidget("Victory!", strings.Join(lines, "\n"), "")
+}
@@ -26,6 +26,8 @@ type BattleSystemSelector struct {
 	// Classic battle system
 	classicManager  *classic.BattleManager
 	classicRenderer *classic.BattleRenderer
+	defeatCallback  func() // Called once the player confirms a classic defeat
+	escapeCallback  func() // Called once the player confirms a classic escape
 
 	// Keep references to tactical system (already in Game)
 	// We won't duplicate it here, just manage the selection
@@ -39,12 +41,15 @@ func NewBattleSystemSelector(screenWidth, screenHeight int) *BattleSystemSelecto
 
 	// Set up callbacks
 	classicManager.SetOnActionExecuted(func(action *classic.BattleAction) {
-		// Add battle log messages
-		if action.Target != nil {
-			message := getBattleActionMessage(action)
-			classicRenderer.AddBattleMessage(message)
+		// Add battle log messages; attacks and items without a target did nothing
+		if action.Target != nil || len(action.Results) > 0 || (action.ActionType != classic.ActionAttack &&
+			action.ActionType != classic.ActionMagic && action.ActionType != classic.ActionItem) {
+			for _, message := range getBattleActionMessages(action) {
+				classicRenderer.AddBattleMessage(message)
+			}
 		}
 	})
+	classicManager.SetOnMessage(classicRenderer.AddBattleMessage)
 
 	return &BattleSystemSelector{
 		currentSystem:   BattleSystemClassic, // Default to classic mode
@@ -53,6 +58,39 @@ func NewBattleSystemSelector(screenWidth, screenHeight int) *BattleSystemSelecto
 	}
 }
 
+// getBattleActionMessages formats a battle action into log lines, one per target for group actions
+func getBattleActionMessages(action *classic.BattleAction) []string {
+	if len(action.Results) <= 1 || action.Failure != "" {
+		return []string{getBattleActionMessage(action)}
+	}
+
+	attackerName := "Unknown"
+	if attackerStats := action.Entity.RPGStats(); attackerStats != nil {
+		attackerName = attackerStats.Name
+	}
+	header := attackerName + " attacks!"
+	if action.Spell != nil {
+		header = attackerName + " casts " + action.Spell.Name + "!"
+	}
+
+	messages := []string{header}
+	for _, result := range action.Results {
+		targetName := "Unknown"
+		if targetStats := result.Target.RPGStats(); targetStats != nil {
+			targetName = targetStats.Name
+		}
+		callout := result.Affinity.BattleLogCallout()
+		if result.Healed > 0 {
+			messages = append(messages, fmt.Sprintf("  %s recovers %d HP!%s HP: %d/%d",
+				targetName, result.Healed, callout, result.HPAfter, result.MaxHP))
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("  %s takes %d damage!%s HP: %d/%d",
+			targetName, result.Damage, callout, result.HPAfter, result.MaxHP))
+	}
+	return messages
+}
+
 // getBattleActionMessage formats a battle action into a readable message
 func getBattleActionMessage(action *classic.BattleAction) string {
 	attackerName := "Unknown"
@@ -62,31 +100,70 @@ func getBattleActionMessage(action *classic.BattleAction) string {
 		attackerName = attackerStats.Name
 	}
 
-	if targetStats := action.Target.RPGStats(); targetStats != nil {
-		targetName = targetStats.Name
+	if action.Target != nil {
+		if targetStats := action.Target.RPGStats(); targetStats != nil {
+			targetName = targetStats.Name
+		}
+	}
+
+	if action.Failure != "" {
+		return fmt.Sprintf("%s can't act: %s!", attackerName, action.Failure)
+	}
+
+	// Elemental weaknesses and resistances of the target are called out
+	callout := ""
+	if len(action.Results) > 0 {
+		callout = action.Results[0].Affinity.BattleLogCallout()
 	}
 
 	switch action.ActionType {
 	case classic.ActionAttack:
 		baseMessage := attackerName + " attacks " + targetName + "!"
+		if len(action.Results) > 0 && action.Results[0].Healed > 0 {
+			return fmt.Sprintf("%s Recovers %d HP!%s HP: %d/%d",
+				baseMessage, action.Results[0].Healed, callout, action.TargetHPAfter, action.TargetMaxHP)
+		}
 		if action.DamageDealt > 0 {
-			return fmt.Sprintf("%s Deals %d damage! HP: %d/%d",
-				baseMessage, action.DamageDealt, action.TargetHPAfter, action.TargetMaxHP)
+			return fmt.Sprintf("%s Deals %d damage!%s HP: %d/%d",
+				baseMessage, action.DamageDealt, callout, action.TargetHPAfter, action.TargetMaxHP)
 		}
-		return baseMessage
+		return baseMessage + callout
 	case classic.ActionMagic:
 		baseMessage := attackerName + " casts magic on " + targetName + "!"
+		if action.Spell != nil {
+			baseMessage = attackerName + " casts " + action.Spell.Name + " on " + targetName + "!"
+		}
+		if len(action.Results) > 0 && action.Results[0].Healed > 0 {
+			return fmt.Sprintf("%s Recovers %d HP!%s HP: %d/%d",
+				baseMessage, action.Results[0].Healed, callout, action.TargetHPAfter, action.TargetMaxHP)
+		}
 		if action.DamageDealt > 0 {
-			return fmt.Sprintf("%s Deals %d damage! HP: %d/%d",
-				baseMessage, action.DamageDealt, action.TargetHPAfter, action.TargetMaxHP)
+			return fmt.Sprintf("%s Deals %d damage!%s HP: %d/%d",
+				baseMessage, action.DamageDealt, callout, action.TargetHPAfter, action.TargetMaxHP)
 		}
-		return baseMessage
+		return baseMessage + callout
 	case classic.ActionDefend:
 		return attackerName + " defends!"
 	case classic.ActionItem:
+		if action.Item != nil && action.DamageDealt > 0 {
+			return fmt.Sprintf("%s uses %s on %s! Deals %d damage!%s HP: %d/%d",
+				attackerName, action.Item.Name, targetName, action.DamageDealt, callout, action.TargetHPAfter, action.TargetMaxHP)
+		}
+		if action.Item != nil {
+			return fmt.Sprintf("%s uses %s on %s!%s HP: %d/%d",
+				attackerName, action.Item.Name, targetName, callout, action.TargetHPAfter, action.TargetMaxHP)
+		}
 		return attackerName + " uses an item!"
 	case classic.ActionEscape:
-		return attackerName + " tries to escape!"
+		if action.Succeeded {
+			return "The party escaped!"
+		}
+		return attackerName + " tries to escape, but the way is blocked!"
+	case classic.ActionRowSwap:
+		if stats := action.Entity.RPGStats(); stats != nil && stats.BackRow {
+			return attackerName + " moves to the back row!"
+		}
+		return attackerName + " moves to the front row!"
 	default:
 		return attackerName + " acts!"
 	}
@@ -124,6 +201,15 @@ func (bss *BattleSystemSelector) StartBattle(game *Game, playerParty, enemyParty
 		bss.classicManager.SetOnBattleEnd(func(victory bool) {
 			logger.Debug("🏁 Classic battle ended, returning to exploration mode. Victory: %t", victory)
 			// The battle manager will handle its own cleanup in HandleBattleEndConfirmation
+			if !victory && bss.defeatCallback != nil {
+				bss.defeatCallback()
+			}
+		})
+		bss.classicManager.SetOnEscape(func() {
+			logger.Debug("🏃 Classic battle escaped, returning to exploration mode")
+			if bss.escapeCallback != nil {
+				bss.escapeCallback()
+			}
 		})
 
 		// Use new classic system
@@ -135,6 +221,16 @@ func (bss *BattleSystemSelector) StartBattle(game *Game, playerParty, enemyParty
 	}
 }
 
+// SetDefeatCallback sets the function called after the party loses a classic battle
+func (bss *BattleSystemSelector) SetDefeatCallback(callback func()) {
+	bss.defeatCallback = callback
+}
+
+// SetEscapeCallback sets the function called after the party escapes a classic battle
+func (bss *BattleSystemSelector) SetEscapeCallback(callback func()) {
+	bss.escapeCallback = callback
+}
+
 // IsClassicBattleActive returns true if a classic battle is currently running
 func (bss *BattleSystemSelector) IsClassicBattleActive() bool {
 	return bss.currentSystem == BattleSystemClassic && bss.classicManager.IsActive()
@@ -0,0 +1,187 @@
+// Package engine provides chest events: unlocking with keys or lockpicks and depositing loot
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/events"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// handleChestEvent unlocks a chest if needed and deposits its gold and items. Items that do
+// not fit in any party inventory stay in the chest, which can be opened again for them.
+func (g *Game) handleChestEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
+	logger.Info("Chest event triggered: %s", eventComp.Name)
+
+	lines := make([]string, 0)
+	if eventComp.EventData.IsLocked {
+		unlocked, err := g.unlockChest(eventComp, player)
+		if err != nil {
+			message := err.Error()
+			g.uiManager.AddMessage(message)
+			g.uiManager.ShowInfoWidget("Locked Chest", message, "")
+			return &events.EventResult{
+				Success: false,
+				Message: message,
+			}
+		}
+		g.uiManager.AddMessage(unlocked)
+		lines = append(lines, unlocked, "")
+	}
+
+	lines = append(lines, "You found:", "")
+
+	// Gold goes to the party wallet
+	gold := eventComp.EventData.Gold
+	if gold > 0 {
+		g.partyManager.AddGold(gold, fmt.Sprintf("Opened %s", eventComp.Name))
+		eventComp.EventData.Gold = 0
+		lines = append(lines, fmt.Sprintf("Gold: %d coins", gold))
+	}
+
+	granted, leftover := g.depositChestItems(eventComp.EventData.Items, player)
+	eventComp.EventData.Items = leftover
+	if len(granted) > 0 {
+		lines = append(lines, "Items:")
+		lines = append(lines, granted...)
+	}
+	if gold == 0 && len(granted) == 0 {
+		lines = append(lines, "Nothing you can carry.")
+	}
+
+	if len(leftover) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Inventories are full: %d item(s) left in the chest.", len(leftover)))
+		g.uiManager.AddMessage(fmt.Sprintf("The %s still holds %d item(s)", eventComp.Name, len(leftover)))
+	} else {
+		// An emptied chest is completed, which is kept in the event save state
+		eventComp.State = components.EventCompleted
+	}
+
+	g.uiManager.ShowInfoWidget(fmt.Sprintf("Opened %s", eventComp.Name), strings.Join(lines, "\n"), "")
+	g.uiManager.AddMessage(fmt.Sprintf("You opened a %s!", eventComp.Name))
+
+	return &events.EventResult{
+		Success: true,
+		Message: fmt.Sprintf("Opened %s", eventComp.Name),
+		Data: map[string]interface{}{
+			"items":    granted,
+			"leftover": leftover,
+			"gold":     gold,
+		},
+	}
+}
+
+// unlockChest opens the lock of a chest with a key from a party inventory or, without one,
+// a lockpick attempt by the party's highest level Rogue. It returns what unlocked the chest.
+func (g *Game) unlockChest(eventComp *components.EventComponent, player *ecs.Entity) (string, error) {
+	keyID := eventComp.EventData.KeyID
+	if keyID == 0 {
+		keyID = constants.ChestKeyItemID
+	}
+
+	for _, member := range g.chestRecipients(player) {
+		inventory := member.Inventory()
+		if inventory == nil || inventory.GetItemCount(keyID) == 0 {
+			continue
+		}
+		key := inventory.FindItem(keyID).Item
+		inventory.RemoveItem(keyID, 1)
+		eventComp.EventData.IsLocked = false
+		return fmt.Sprintf("%s unlocked the %s (used %s)", member.RPGStats().Name, eventComp.Name, key.Name), nil
+	}
+
+	rogue := g.findLockpicker()
+	if rogue == nil {
+		return "", fmt.Errorf("the %s is locked. You need a key or a Rogue to open it", eventComp.Name)
+	}
+
+	stats := rogue.RPGStats()
+	chance := lockpickChance(stats.Level, eventComp.EventData.LockTier)
+	if g.rng.Intn(100) >= chance {
+		return "", fmt.Errorf("%s failed to pick the lock of the %s (%d%% chance)", stats.Name, eventComp.Name, chance)
+	}
+	eventComp.EventData.IsLocked = false
+	return fmt.Sprintf("%s picked the lock of the %s", stats.Name, eventComp.Name), nil
+}
+
+// findLockpicker returns the highest level Rogue of the party, nil without one
+func (g *Game) findLockpicker() *ecs.Entity {
+	var best *ecs.Entity
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		stats := member.RPGStats()
+		if stats == nil || stats.Job != components.JobRogue || stats.CurrentHP <= 0 {
+			continue
+		}
+		if best == nil || stats.Level > best.RPGStats().Level {
+			best = member
+		}
+	}
+	return best
+}
+
+// lockpickChance returns the lockpick success percentage of a Rogue level against a lock tier
+func lockpickChance(level, lockTier int) int {
+	chance := constants.LockpickBaseChance + level*constants.LockpickChancePerLevel - lockTier*constants.LockpickChancePerLockTier
+	if chance < constants.LockpickMinChance {
+		chance = constants.LockpickMinChance
+	} else if chance > constants.LockpickMaxChance {
+		chance = constants.LockpickMaxChance
+	}
+	return chance
+}
+
+// depositChestItems adds chest items to the inventory of the player who opened the chest,
+// then to the other party members. It returns a line per deposited item and the references
+// of the items that did not fit.
+func (g *Game) depositChestItems(refs []string, player *ecs.Entity) ([]string, []string) {
+	granted := make([]string, 0, len(refs))
+	leftover := make([]string, 0)
+	if components.GlobalItemRegistry == nil {
+		logger.Error("Item registry not initialized")
+		return granted, refs
+	}
+
+	recipients := g.chestRecipients(player)
+	for _, ref := range refs {
+		template := components.GlobalItemRegistry.ResolveItem(ref)
+		if template == nil {
+			logger.Warn("Chest item %s not found in the item registry", ref)
+			continue
+		}
+
+		deposited := false
+		for _, member := range recipients {
+			inventory := member.Inventory()
+			if inventory == nil {
+				continue
+			}
+			if inventory.AddItem(components.GlobalItemRegistry.CreateItem(template.ID), 1) == 0 {
+				granted = append(granted, fmt.Sprintf("• %s (%s)", template.Name, member.RPGStats().Name))
+				deposited = true
+				break
+			}
+		}
+		if !deposited {
+			leftover = append(leftover, ref)
+		}
+	}
+	return granted, leftover
+}
+
+// chestRecipients returns the player who opened a chest followed by the other party members
+func (g *Game) chestRecipients(player *ecs.Entity) []*ecs.Entity {
+	recipients := make([]*ecs.Entity, 0)
+	if player != nil {
+		recipients = append(recipients, player)
+	}
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		if member != player {
+			recipients = append(recipients, member)
+		}
+	}
+	return recipients
+}
@@ -0,0 +1,31 @@
+// Package engine provides the crafting widget toggle for the active player
+package engine
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/crafting"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// toggleCrafting shows/hides the crafting widget for the active player, paying from the party gold
+func (g *Game) toggleCrafting() {
+	if g.uiManager.IsCraftingVisible() {
+		g.uiManager.HideCrafting()
+		return
+	}
+
+	activePlayer := g.GetActivePlayer()
+	if activePlayer == nil || activePlayer.RPGStats() == nil {
+		g.uiManager.AddMessage("No active player to craft with")
+		return
+	}
+
+	err := g.uiManager.ShowCrafting(crafting.GetGlobalCraftingRegistry(), activePlayer, g.partyManager, g.rng)
+	if err != nil {
+		g.uiManager.AddMessage(fmt.Sprintf("Failed to open crafting: %v", err))
+		logger.Error("Failed to open crafting for %s: %v", activePlayer.RPGStats().Name, err)
+		return
+	}
+	logger.Info("Crafting opened for player: %s", activePlayer.RPGStats().Name)
+}
@@ -0,0 +1,329 @@
+// Package engine provides party defeat handling, game over options and save/load of party progress
+package engine
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/save"
+	"github.com/jrecuero/myrpg/internal/skills"
+)
+
+// DefeatMode selects what happens when the whole party is defeated
+type DefeatMode int
+
+const (
+	DefeatModeGameOver DefeatMode = iota // Show the game over screen (retry, load, title)
+	DefeatModeRevive                     // Revive at the last rest point with a gold penalty
+)
+
+func (dm DefeatMode) String() string {
+	switch dm {
+	case DefeatModeGameOver:
+		return "Game Over"
+	case DefeatModeRevive:
+		return "Revive"
+	default:
+		return "Unknown"
+	}
+}
+
+// Game over screen options
+const (
+	gameOverOptionRetry = "Retry Battle"
+	gameOverOptionLoad  = "Load Last Save"
+	gameOverOptionTitle = "Return to Title"
+)
+
+// battleSnapshot holds what is needed to restart a battle from its beginning
+type battleSnapshot struct {
+	eventEntity *ecs.Entity                // Battle event entity that started the battle
+	eventComp   *components.EventComponent // Battle event data
+	player      *ecs.Entity                // Player that triggered the battle
+	party       *save.PartySaveData        // Party state when the battle started
+	enemies     []*ecs.Entity              // Enemies created for the battle
+}
+
+// SetDefeatMode selects what happens when the party is defeated
+func (g *Game) SetDefeatMode(mode DefeatMode) {
+	g.defeatMode = mode
+}
+
+// GetDefeatMode returns the current defeat mode
+func (g *Game) GetDefeatMode() DefeatMode {
+	return g.defeatMode
+}
+
+// takeBattleSnapshot records the party state before a battle event starts a battle
+func (g *Game) takeBattleSnapshot(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) {
+	g.lastBattle = &battleSnapshot{
+		eventEntity: entity,
+		eventComp:   eventComp,
+		player:      player,
+		party:       g.buildPartySaveData(),
+		enemies:     make([]*ecs.Entity, 0),
+	}
+}
+
+// handlePartyDefeat runs the defeat flow for both battle systems
+func (g *Game) handlePartyDefeat() {
+	logger.Info("💀 Party defeated (mode: %s)", g.defeatMode.String())
+
+	if g.currentMode == ModeTactical {
+		g.SwitchToExplorationMode()
+	}
+
+	if g.defeatMode == DefeatModeRevive {
+		g.reviveAtRestPoint()
+		return
+	}
+
+	g.gameOverActive = true
+	g.showGameOverScreen()
+}
+
+// showGameOverScreen shows the game over options; it is shown again until an option succeeds
+func (g *Game) showGameOverScreen() {
+	options := make([]string, 0, 3)
+	if g.lastBattle != nil {
+		options = append(options, gameOverOptionRetry)
+	}
+	options = append(options, gameOverOptionLoad, gameOverOptionTitle)
+
+	g.uiManager.ShowSelectionPopup(
+		"Game Over",
+		options,
+		func(index int, option string) {
+			var err error
+			switch option {
+			case gameOverOptionRetry:
+				err = g.retryBattle()
+			case gameOverOptionLoad:
+				err = g.LoadGame()
+			case gameOverOptionTitle:
+				err = g.returnToTitle()
+			}
+
+			if err != nil {
+				logger.Warn("Game over option %s failed: %v", option, err)
+				g.uiManager.AddMessage(fmt.Sprintf("%s failed: %v", option, err))
+				return
+			}
+			g.gameOverActive = false
+		},
+		nil, // The game over screen cannot be dismissed
+	)
+}
+
+// retryBattle restores the party to the start of the last battle and starts it again
+func (g *Game) retryBattle() error {
+	snapshot := g.lastBattle
+	if snapshot == nil {
+		return fmt.Errorf("no battle to retry")
+	}
+
+	g.removeBattleEnemies()
+	g.applyPartySaveData(snapshot.party)
+
+	g.uiManager.AddMessage("Retrying battle...")
+	result := g.handleBattleEvent(snapshot.eventEntity, snapshot.eventComp, snapshot.player)
+	if !result.Success {
+		return fmt.Errorf("%s", result.Message)
+	}
+	return nil
+}
+
+// reviveAtRestPoint revives the party at the last rest point and takes a share of its gold
+func (g *Game) reviveAtRestPoint() {
+	g.removeBattleEnemies()
+	g.lastBattle = nil
+
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		if stats := member.RPGStats(); stats != nil {
+			stats.CurrentHP = stats.GetMaxHP()
+			stats.CurrentMP = stats.GetMaxMP()
+			stats.ResetMovement()
+		}
+	}
+
+	// Without a visited rest point the party goes back to where the game started
+	restX, restY := g.restPointX, g.restPointY
+	if !g.hasRestPoint && g.newGameState != nil && len(g.newGameState.Members) > 0 {
+		restX, restY = g.newGameState.Members[0].X, g.newGameState.Members[0].Y
+	}
+	if leader := g.partyManager.GetPartyLeader(); leader != nil && leader.Transform() != nil {
+		leader.Transform().X = restX
+		leader.Transform().Y = restY
+	}
+	g.restoreExplorationPositions()
+
+	penalty := g.partyManager.RemoveGold(g.partyManager.GetGold()*constants.DefeatGoldPenaltyPercent/100, "Defeat penalty")
+
+	logger.Info("⛺ Party revived at rest point (%.0f, %.0f), lost %d gold", restX, restY, penalty)
+	g.uiManager.AddMessage(fmt.Sprintf("The party wakes up at the last rest point. Lost %d gold.", penalty))
+}
+
+// returnToTitle resets the game to the state it had when it started
+func (g *Game) returnToTitle() error {
+	if g.newGameState == nil {
+		return fmt.Errorf("no new game state recorded")
+	}
+
+	g.removeBattleEnemies()
+	g.lastBattle = nil
+
+	if err := g.ClearEventState(); err != nil {
+		return err
+	}
+
+	g.applyPartySaveData(g.newGameState)
+	g.activePlayerIndex = constants.DefaultActivePlayerIndex
+	g.InitializeGame()
+	return nil
+}
+
+// removeBattleEnemies removes the enemies created for the last battle from the world
+func (g *Game) removeBattleEnemies() {
+	if g.lastBattle == nil {
+		return
+	}
+	for _, enemy := range g.lastBattle.enemies {
+		g.RemoveEntity(enemy)
+	}
+	g.lastBattle.enemies = make([]*ecs.Entity, 0)
+}
+
+// recordRestPoint remembers a rest point position for revivals and saves the game
+func (g *Game) recordRestPoint(x, y float64) {
+	g.hasRestPoint = true
+	g.restPointX = x
+	g.restPointY = y
+
+	if err := g.SaveGame(); err != nil {
+		logger.Warn("Failed to save game at rest point: %v", err)
+		return
+	}
+	g.uiManager.AddMessage("Game saved.")
+}
+
+// SaveGame saves the event state and the party progress through the save manager
+func (g *Game) SaveGame() error {
+	if err := g.SaveEventState(); err != nil {
+		return fmt.Errorf("failed to save events: %v", err)
+	}
+	if err := g.saveManager.SavePartyState(g.buildPartySaveData()); err != nil {
+		return fmt.Errorf("failed to save party: %v", err)
+	}
+	return nil
+}
+
+// LoadGame loads the event state and the party progress of the last save
+func (g *Game) LoadGame() error {
+	if g.saveManager == nil {
+		return fmt.Errorf("save manager not initialized")
+	}
+	if !g.saveManager.HasPartySave() {
+		return fmt.Errorf("no saved game found")
+	}
+
+	partyData, err := g.saveManager.LoadPartyState()
+	if err != nil {
+		return err
+	}
+	if err := g.LoadEventState(); err != nil {
+		return err
+	}
+
+	g.removeBattleEnemies()
+	g.lastBattle = nil
+	g.applyPartySaveData(partyData)
+
+	g.uiManager.AddMessage("Game loaded.")
+	return nil
+}
+
+// buildPartySaveData captures the party progress for saves and snapshots
+func (g *Game) buildPartySaveData() *save.PartySaveData {
+	data := save.NewPartySaveData()
+	data.Gold = g.partyManager.GetGold()
+	wallet := g.partyManager.GetWallet()
+	for _, currency := range wallet.GetCurrencies() {
+		if currency != components.CurrencyGold {
+			if data.Currencies == nil {
+				data.Currencies = make(map[string]int)
+			}
+			data.Currencies[string(currency)] = wallet.Balance(currency)
+		}
+	}
+	data.HasRestPoint = g.hasRestPoint
+	data.RestPointX = g.restPointX
+	data.RestPointY = g.restPointY
+
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		if stats := member.RPGStats(); stats != nil {
+			state := save.NewPartyMemberState(stats, member.Transform())
+			if inventory := member.Inventory(); inventory != nil {
+				state.Inventory = save.CaptureInventory(inventory)
+			}
+			if equipment := member.Equipment(); equipment != nil {
+				state.Equipped = save.CaptureEquipment(equipment)
+			}
+			data.Members = append(data.Members, state)
+		}
+	}
+
+	return data
+}
+
+// applyPartySaveData restores party progress captured by buildPartySaveData
+func (g *Game) applyPartySaveData(data *save.PartySaveData) {
+	g.partyManager.Wallet = components.NewWalletComponent()
+	g.partyManager.Wallet.SetBalance(components.CurrencyGold, data.Gold)
+	for currency, balance := range data.Currencies {
+		g.partyManager.Wallet.SetBalance(components.Currency(currency), balance)
+	}
+	g.hasRestPoint = data.HasRestPoint
+	g.restPointX = data.RestPointX
+	g.restPointY = data.RestPointY
+
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		stats := member.RPGStats()
+		if stats == nil {
+			continue
+		}
+		if state, exists := data.GetMemberState(stats.Name); exists {
+			state.ApplyTo(stats, member.Transform())
+			if skillsComp := member.Skills(); skillsComp != nil && skillsComp.CurrentJob != stats.Job {
+				g.ensureJobSkills(member)
+				tree, _ := skills.GetGlobalSkillRegistry().GetSkillTree(stats.Job)
+				skillsComp.ChangeJob(stats.Job, tree)
+			}
+			g.restoreMemberItems(member, state)
+		} else {
+			logger.Warn("No saved state for party member %s", stats.Name)
+		}
+	}
+}
+
+// restoreMemberItems restores the saved inventory and equipment of a party member. Older
+// saves without items keep the member's current items.
+func (g *Game) restoreMemberItems(member *ecs.Entity, state *save.PartyMemberState) {
+	registry := components.GlobalItemRegistry
+	if registry == nil {
+		return
+	}
+	if inventory := member.Inventory(); inventory != nil && state.Inventory != nil {
+		if err := save.RestoreInventory(inventory, state.Inventory, registry); err != nil {
+			logger.Warn("Failed to restore inventory of %s: %v", state.Name, err)
+		}
+	}
+	if equipment := member.Equipment(); equipment != nil && state.Equipped != nil {
+		if err := save.RestoreEquipment(equipment, state.Equipped, registry); err != nil {
+			logger.Warn("Failed to restore equipment of %s: %v", state.Name, err)
+		}
+	}
+	member.RefreshStatModifiers()
+}
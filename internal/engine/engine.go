@@ -10,6 +10,7 @@ package engine
 import (
 	"fmt"
 	"image/color"
+	"math/rand"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -18,34 +19,56 @@ import (
 	"github.com/jrecuero/myrpg/cmd/myrpg/game/entities"
 	"github.com/jrecuero/myrpg/internal/battle/classic"
 	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/crafting"
 	"github.com/jrecuero/myrpg/internal/ecs"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/events"
 	"github.com/jrecuero/myrpg/internal/gfx"
 	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/loot"
 	"github.com/jrecuero/myrpg/internal/quests"
 	"github.com/jrecuero/myrpg/internal/save"
+	"github.com/jrecuero/myrpg/internal/shop"
 	"github.com/jrecuero/myrpg/internal/skills"
+	"github.com/jrecuero/myrpg/internal/systems"
 	"github.com/jrecuero/myrpg/internal/tactical"
 	"github.com/jrecuero/myrpg/internal/ui"
 )
 
 // Game represents the state of the game using an ECS architecture.
 type Game struct {
-	world              *ecs.World            // The game world containing all entities
-	activePlayerIndex  int                   // Index of the currently active player
-	tabKeyPressed      bool                  // Track TAB key state to prevent multiple switches
-	uiManager          *ui.UIManager         // UI system for panels and messages
-	battleSystem       *BattleSystem         // Battle system for combat
-	tacticalManager    *TacticalManager      // Tactical combat system
-	partyManager       *PartyManager         // Party and team management
-	enemyGroupManager  *EnemyGroupManager    // Enemy group formation
-	tacticalDeployment *TacticalDeployment   // Unit deployment for tactical combat
-	eventManager       *events.EventManager  // Event system for interactive world elements
-	saveManager        *save.SaveManager     // Save system for game state persistence
-	viewManager        *ViewManager          // View system for managing different game views
-	battleSelector     *BattleSystemSelector // Battle system selector (tactical vs classic)
-	currentMode        GameMode              // Current game mode (exploration/tactical)
+	world              *ecs.World                  // The game world containing all entities
+	activePlayerIndex  int                         // Index of the currently active player
+	tabKeyPressed      bool                        // Track TAB key state to prevent multiple switches
+	uiManager          *ui.UIManager               // UI system for panels and messages
+	battleSystem       *BattleSystem               // Battle system for combat
+	tacticalManager    *TacticalManager            // Tactical combat system
+	partyManager       *PartyManager               // Party and team management
+	enemyGroupManager  *EnemyGroupManager          // Enemy group formation
+	tacticalDeployment *TacticalDeployment         // Unit deployment for tactical combat
+	eventManager       *events.EventManager        // Event system for interactive world elements
+	saveManager        *save.SaveManager           // Save system for game state persistence
+	viewManager        *ViewManager                // View system for managing different game views
+	battleSelector     *BattleSystemSelector       // Battle system selector (tactical vs classic)
+	rng                *rand.Rand                  // Shared random source for battles and rewards
+	rewardManager      *systems.RewardManager      // Post-battle experience, gold and loot
+	progression        *systems.ProgressionManager // Experience, skill points and level-up listeners
+	currentMode        GameMode                    // Current game mode (exploration/tactical)
+
+	// Level-ups waiting for their stat-gain popup
+	pendingLevelUps []components.LevelUpEvent
+
+	// Shop queued by a dialog action, opened once the dialog closes
+	pendingShopID string
+
+	// Defeat handling
+	defeatMode     DefeatMode          // What happens when the party is defeated
+	gameOverActive bool                // Game over screen is waiting for a choice
+	lastBattle     *battleSnapshot     // Snapshot of the last battle for retries
+	newGameState   *save.PartySaveData // Party state at game start, restored by "Return to Title"
+	hasRestPoint   bool                // Whether a rest point has been visited
+	restPointX     float64             // Last rest point X position
+	restPointY     float64             // Last rest point Y position
 }
 
 // NewGame creates a new game instance with an empty world
@@ -64,6 +87,12 @@ func NewGame() *Game {
 	// Initialize save system
 	saveManager := save.NewSaveManager("saves") // Save files in "saves" directory
 
+	// Shared random source for battles and rewards
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	// Experience, skill points and level-up notifications
+	progression := systems.NewProgressionManager()
+
 	game := &Game{
 		world:              world,
 		activePlayerIndex:  constants.DefaultActivePlayerIndex,
@@ -76,17 +105,28 @@ func NewGame() *Game {
 		tacticalDeployment: tacticalDeployment,
 		eventManager:       eventManager,
 		saveManager:        saveManager,
+		rng:                rng,
+		rewardManager:      systems.NewRewardManager(rng, progression),
+		progression:        progression,
 		currentMode:        ModeExploration, // Start in exploration mode
 	}
 
+	// Level-ups show a stat-gain popup and update level quest objectives
+	progression.AddLevelUpListener(game.handleLevelUp)
+
+	// Dialog actions such as "open_shop" are carried out by the game
+	uiManager.SetDialogActionHandler(game.handleDialogAction)
+
 	// Initialize view management system
 	game.viewManager = NewViewManager(game)
 
 	// Initialize battle system selector
 	game.battleSelector = NewBattleSystemSelector(constants.ScreenWidth, constants.ScreenHeight)
+	game.battleSelector.GetClassicBattleManager().SetRNG(rng)
+	tacticalManager.SetRNG(rng)
 
-	// Initialize skills system
-	skills.InitializeSkillRegistry()
+	// Initialize skills system (loads the skill data file unless already loaded)
+	skills.GetGlobalSkillRegistry()
 
 	// Initialize quest system
 	quests.InitializeQuestRegistry()
@@ -132,9 +172,46 @@ func NewGame() *Game {
 		}
 	})
 
+	// Reinforcements spawned by battle scripts join the world like event-created enemies
+	tacticalManager.GetTurnBasedCombat().SetUnitFactory(func(unitID string) *ecs.Entity {
+		logger.Debug("🆕 Creating reinforcement: %s", unitID)
+		return entities.CreateEnemyFromDefinition(unitID, 0, 0)
+	})
+	tacticalManager.GetTurnBasedCombat().SetUnitSpawnCallback(func(unit *ecs.Entity) {
+		game.AddEntity(unit)
+		tacticalManager.Participants = append(tacticalManager.Participants, unit)
+	})
+
+	// Grant battle rewards after victories in both battle systems
+	game.battleSelector.GetClassicBattleManager().SetRewardHandler(game.grantBattleRewards)
+	tacticalManager.SetVictoryCallback(func(players, enemies []*ecs.Entity) {
+		rewards := game.grantBattleRewards(players, enemies)
+		game.showVictorySummary(rewards)
+	})
+
+	// Run the defeat flow when the party loses in either battle system
+	game.battleSelector.SetDefeatCallback(game.handlePartyDefeat)
+	game.battleSelector.SetEscapeCallback(game.handlePartyEscape)
+	tacticalManager.SetDefeatCallback(game.handlePartyDefeat)
+
 	// Initialize item system
 	components.InitializeItemSystem()
 
+	// Initialize equipment sets, which mark their members in the item registry
+	if err := components.LoadSetRegistry(constants.EquipmentSetDataFile); err != nil {
+		logger.Warn("Failed to load equipment set data: %v", err)
+		components.GlobalSetRegistry = components.NewSetRegistry()
+	}
+
+	// Initialize shops, whose stock comes from the item registry
+	shop.InitializeShopRegistry()
+
+	// Initialize crafting recipes and the equipment upgrade rule
+	crafting.InitializeCraftingRegistry()
+
+	// Initialize the loot rarity tiers and affix tables used by enemy drops
+	loot.InitializeLootGenerator()
+
 	return game
 }
 
@@ -422,14 +499,15 @@ func (g *Game) getAllCombatParticipants() []*ecs.Entity {
 	return participants
 }
 
-// createEnemiesFromBattleEvent creates enemy entities based on battle event data
-func (g *Game) createEnemiesFromBattleEvent(eventComp *components.EventComponent) {
+// createEnemiesFromBattleEvent creates enemy entities based on battle event data and returns them
+func (g *Game) createEnemiesFromBattleEvent(eventComp *components.EventComponent) []*ecs.Entity {
 	logger.Debug("🏗️  Creating enemies from battle event: %s", eventComp.Name)
 	logger.Debug("   Enemy list: %v", eventComp.EventData.Enemies)
 
+	created := make([]*ecs.Entity, 0, len(eventComp.EventData.Enemies))
 	if len(eventComp.EventData.Enemies) == 0 {
 		logger.Debug("   ⚠️  No enemies specified in battle event data")
-		return
+		return created
 	}
 
 	// For each enemy ID in the event data, create an enemy entity
@@ -437,7 +515,7 @@ func (g *Game) createEnemiesFromBattleEvent(eventComp *components.EventComponent
 		logger.Debug("   Creating enemy %d: %s", i, enemyID)
 
 		// Create enemy at a temporary position (will be positioned during tactical deployment)
-		enemy := entities.CreateEnemy(0, 0)
+		enemy := entities.CreateEnemyFromDefinition(enemyID, 0, 0)
 
 		if enemy == nil {
 			logger.Debug("   ❌ Failed to create enemy %s", enemyID)
@@ -446,6 +524,7 @@ func (g *Game) createEnemiesFromBattleEvent(eventComp *components.EventComponent
 
 		// Add the enemy to the world
 		g.AddEntity(enemy)
+		created = append(created, enemy)
 
 		logger.Debug("   ✅ Created and added enemy: %s (type: %s)", enemy.GetID(), enemyID)
 
@@ -457,7 +536,8 @@ func (g *Game) createEnemiesFromBattleEvent(eventComp *components.EventComponent
 		}
 	}
 
-	logger.Debug("🏁 Enemy creation completed. Total created: %d", len(eventComp.EventData.Enemies))
+	logger.Debug("🏁 Enemy creation completed. Total created: %d", len(created))
+	return created
 }
 
 // GetNearbyEnemies returns enemies within the specified distance of the given entity.
@@ -502,6 +582,11 @@ func (g *Game) GetNearbyEnemies(entity *ecs.Entity, distance float64) []*ecs.Ent
 
 // CheckAndRemoveDeadEntities removes entities with HP <= 0
 func (g *Game) CheckAndRemoveDeadEntities() {
+	// A defeated party stays in the world until a game over option restores it
+	if g.gameOverActive {
+		return
+	}
+
 	entitiesToRemove := []*ecs.Entity{}
 
 	for _, entity := range g.world.GetEntities() {
@@ -525,6 +610,11 @@ func (g *Game) CheckAndRemoveDeadEntities() {
 
 // InitializeGame sets up the initial game state and messages
 func (g *Game) InitializeGame() {
+	// Remember the starting party so the game can be reset after a game over
+	if g.newGameState == nil {
+		g.newGameState = g.buildPartySaveData()
+	}
+
 	g.uiManager.AddMessage("Welcome to MyRPG!")
 	g.uiManager.AddMessage("Use arrow keys to move, TAB to switch between players")
 	g.uiManager.AddMessage("Press I for inventory, K for skills, J for quests, Q for equipment, H for help")
@@ -628,6 +718,14 @@ func (g *Game) Update() error {
 	if inpututil.IsKeyJustPressed(ebiten.KeyH) && !g.uiManager.IsPopupVisible() {
 		g.showTestInfoPopup()
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) && !g.uiManager.IsPopupVisible() {
+		g.toggleCrafting()
+	}
+
+	// Keep the game over screen up until one of its options succeeds
+	if g.gameOverActive && !g.uiManager.IsPopupVisible() {
+		g.showGameOverScreen()
+	}
 
 	// Block game input processing when popup is visible OR when UI consumed ESC
 	if g.uiManager.IsPopupVisible() || uiInputResult.EscConsumed {
@@ -666,6 +764,18 @@ func (g *Game) Update() error {
 
 // updateExploration handles exploration mode updates (your current system)
 func (g *Game) updateExploration() error {
+	// Show level-up popups once the battle screens are closed
+	if len(g.pendingLevelUps) > 0 {
+		g.showNextLevelUp()
+		return nil
+	}
+
+	// Open a shop requested by a dialog once the dialog is closed
+	if g.pendingShopID != "" {
+		g.openPendingShop()
+		return nil
+	}
+
 	// Update battle system first
 	g.battleSystem.Update()
 
@@ -706,6 +816,13 @@ func (g *Game) updateExploration() error {
 			}
 		}
 
+		// Handle 'G' key for toggling tactical turn order (Team turns vs Charge Time)
+		if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+			mode := g.tacticalManager.ToggleTurnMode()
+			logger.Info("⏱️  Tactical turn mode: %s", mode.String())
+			g.uiManager.AddMessage(fmt.Sprintf("Tactical turn mode: %s", mode.String()))
+		}
+
 		// Get the currently active player
 		activePlayer := g.GetActivePlayer()
 		if activePlayer == nil {
@@ -1007,7 +1124,7 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	}
 
 	// Draw top panel with mode-specific info
-	g.uiManager.DrawTopPanel(screen, activePlayerStats, uiMode, partyStats, gridPosition)
+	g.uiManager.DrawTopPanel(screen, activePlayerStats, uiMode, partyStats, gridPosition, g.partyManager.GetWallet())
 
 	// Draw game world background
 	g.uiManager.DrawGameWorldBackground(screen)
@@ -1779,9 +1896,7 @@ func (g *Game) toggleSkills() {
 	// Check if player has skills component, if not create one
 	if activePlayer.Skills() == nil {
 		// Create skills component for the player
-		skillsComp := components.NewSkillsComponent(activePlayer.RPGStats().Job)
-		skillsComp.AddSkillPoints(5) // Give some initial skill points
-		activePlayer.AddComponent(ecs.ComponentSkills, skillsComp)
+		g.ensureJobSkills(activePlayer)
 		logger.Info("Created skills component for player %s", activePlayer.RPGStats().Name)
 	}
 
@@ -1909,6 +2024,15 @@ func (g *Game) handleClassicBattleInput() {
 		return
 	}
 
+	// Battle time settings: Tab toggles Active/Wait, -/= change the battle speed
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		battleManager.ToggleATBMode()
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		battleManager.ChangeBattleSpeed(-1)
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		battleManager.ChangeBattleSpeed(1)
+	}
+
 	// Handle action selection
 	if battleManager.IsWaitingForPlayerAction() {
 		if inpututil.IsKeyJustPressed(ebiten.Key1) {
@@ -1917,6 +2041,28 @@ func (g *Game) handleClassicBattleInput() {
 			battleManager.HandlePlayerInput(classic.ActionMagic)
 		} else if inpututil.IsKeyJustPressed(ebiten.Key3) {
 			battleManager.HandlePlayerInput(classic.ActionDefend)
+		} else if inpututil.IsKeyJustPressed(ebiten.Key4) {
+			battleManager.HandlePlayerInput(classic.ActionItem)
+		} else if inpututil.IsKeyJustPressed(ebiten.Key5) {
+			battleManager.HandlePlayerInput(classic.ActionEscape)
+		} else if inpututil.IsKeyJustPressed(ebiten.Key6) {
+			battleManager.HandlePlayerInput(classic.ActionRowSwap)
+		}
+		return
+	}
+
+	// Handle spell and item selection
+	if battleManager.IsSelectingFromList() {
+		if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) {
+			battleManager.HandleListNavigation(-1)
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) {
+			battleManager.HandleListNavigation(1)
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
+			inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+			battleManager.ConfirmListSelection()
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+			inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+			battleManager.CancelSelection()
 		}
 		return
 	}
@@ -1932,6 +2078,9 @@ func (g *Game) handleClassicBattleInput() {
 		} else if inpututil.IsKeyJustPressed(ebiten.KeyEnter) ||
 			inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 			battleManager.ConfirmTargetSelection()
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+			inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+			battleManager.CancelSelection()
 		}
 		return
 	}
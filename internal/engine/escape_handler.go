@@ -0,0 +1,26 @@
+// Package engine provides the return to exploration after the party escapes a battle
+package engine
+
+import (
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// handlePartyEscape cleans up after an escape and gives the party a short invulnerability window
+func (g *Game) handlePartyEscape() {
+	logger.Info("🏃 Party escaped from battle")
+
+	// The enemies stay behind and the encounter can be fought later
+	g.removeBattleEnemies()
+	if g.lastBattle != nil && g.lastBattle.eventComp != nil {
+		g.eventManager.RestoreEvent(g.lastBattle.eventComp)
+	}
+	g.lastBattle = nil
+
+	// Standing on the battle event would start it again right away
+	g.eventManager.SuppressBattleEvents(time.Duration(constants.EscapeGraceSeconds * float64(time.Second)))
+
+	g.uiManager.AddMessage("You escaped safely!")
+}
@@ -24,6 +24,7 @@ func (g *Game) CreateGameEventHandlers() map[components.EventType]events.EventHa
 	handlers[components.EventCutscene] = g.handleCutsceneEvent
 	handlers[components.EventShop] = g.handleShopEvent
 	handlers[components.EventRest] = g.handleRestEvent
+	handlers[components.EventJobChange] = g.handleJobChangeEvent
 
 	return handlers
 }
@@ -32,8 +33,24 @@ func (g *Game) CreateGameEventHandlers() map[components.EventType]events.EventHa
 func (g *Game) handleBattleEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
 	logger.Info("Battle event triggered: %s", eventComp.Name)
 
+	// Snapshot the party so a lost battle can be retried from the start
+	g.takeBattleSnapshot(entity, eventComp, player)
+
 	// Create enemy entities from the battle event data
-	g.createEnemiesFromBattleEvent(eventComp)
+	g.lastBattle.enemies = g.createEnemiesFromBattleEvent(eventComp)
+
+	// Boss battles refuse the classic Escape command
+	g.battleSelector.GetClassicBattleManager().SetBossBattle(eventComp.EventData.Boss)
+
+	// Apply battle objectives and scripted events declared by the event (tactical battles only)
+	if g.battleSelector.GetBattleSystem() == BattleSystemTactical {
+		if err := g.tacticalManager.SetBattleObjectives(eventComp.EventData.Victory, eventComp.EventData.Defeat); err != nil {
+			logger.Warn("Invalid battle objectives for %s, using defaults: %v", eventComp.Name, err)
+		}
+		if err := g.tacticalManager.SetBattleScript(eventComp.EventData.Script); err != nil {
+			logger.Warn("Invalid battle script for %s, ignoring it: %v", eventComp.Name, err)
+		}
+	}
 
 	// Get all combat participants (all party members + any enemies)
 	participants := g.getAllCombatParticipants()
@@ -107,52 +124,6 @@ func (g *Game) handleDialogEvent(entity *ecs.Entity, eventComp *components.Event
 	}
 }
 
-// handleChestEvent shows loot information using the info widget
-func (g *Game) handleChestEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
-	logger.Info("Chest event triggered: %s", eventComp.Name)
-
-	// Check if locked
-	if eventComp.EventData.IsLocked {
-		message := fmt.Sprintf("The %s is locked!", eventComp.Name)
-		g.uiManager.AddMessage(message)
-		g.uiManager.ShowInfoWidget("Locked Chest", message, "")
-		return &events.EventResult{
-			Success: false,
-			Message: message,
-		}
-	}
-
-	// Build loot description
-	lootDescription := "You found:\n\n"
-
-	// Add items
-	if len(eventComp.EventData.Items) > 0 {
-		lootDescription += "Items:\n"
-		for _, itemID := range eventComp.EventData.Items {
-			lootDescription += fmt.Sprintf("• %s\n", itemID)
-		}
-		lootDescription += "\n"
-	}
-
-	// Add gold
-	if eventComp.EventData.Gold > 0 {
-		lootDescription += fmt.Sprintf("Gold: %d coins", eventComp.EventData.Gold)
-	}
-
-	// Show info widget with loot
-	g.uiManager.ShowInfoWidget(fmt.Sprintf("Opened %s", eventComp.Name), lootDescription, "")
-	g.uiManager.AddMessage(fmt.Sprintf("You opened a %s!", eventComp.Name))
-
-	return &events.EventResult{
-		Success: true,
-		Message: fmt.Sprintf("Opened %s", eventComp.Name),
-		Data: map[string]interface{}{
-			"item_ids": eventComp.EventData.Items,
-			"gold":     eventComp.EventData.Gold,
-		},
-	}
-}
-
 // handleDoorEvent handles area transitions
 func (g *Game) handleDoorEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
 	logger.Info("Door event triggered: %s", eventComp.Name)
@@ -189,7 +160,7 @@ func (g *Game) handleTrapEvent(entity *ecs.Entity, eventComp *components.EventCo
 		if playerStats := player.RPGStats(); playerStats != nil {
 			playerStats.CurrentHP = max(0, playerStats.CurrentHP-damage)
 			g.uiManager.AddMessage(fmt.Sprintf("%s takes %d damage! HP: %d/%d",
-				playerStats.Name, damage, playerStats.CurrentHP, playerStats.MaxHP))
+				playerStats.Name, damage, playerStats.CurrentHP, playerStats.GetMaxHP()))
 		}
 	}
 
@@ -261,20 +232,6 @@ func (g *Game) handleCutsceneEvent(entity *ecs.Entity, eventComp *components.Eve
 	}
 }
 
-// handleShopEvent handles shop events
-func (g *Game) handleShopEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
-	logger.Info("Shop event triggered: %s", eventComp.Name)
-
-	message := fmt.Sprintf("Welcome to %s!", eventComp.Name)
-	g.uiManager.AddMessage(message)
-	g.uiManager.ShowInfoWidget("Shop", message+"\n\n(Shopping system coming soon)", "")
-
-	return &events.EventResult{
-		Success: true,
-		Message: message,
-	}
-}
-
 // handleRestEvent handles rest/save points
 func (g *Game) handleRestEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
 	logger.Info("Rest event triggered: %s", eventComp.Name)
@@ -283,13 +240,18 @@ func (g *Game) handleRestEvent(entity *ecs.Entity, eventComp *components.EventCo
 
 	// Heal the player
 	if playerStats := player.RPGStats(); playerStats != nil {
-		playerStats.CurrentHP = playerStats.MaxHP
+		playerStats.CurrentHP = playerStats.GetMaxHP()
 		g.uiManager.AddMessage(fmt.Sprintf("%s's HP fully restored!", playerStats.Name))
 	}
 
 	g.uiManager.ShowInfoWidget("Rest Point", message, "")
 	g.uiManager.AddMessage(message)
 
+	// Rest points are where the party revives after a defeat, and they save the game
+	if transform := entity.Transform(); transform != nil {
+		g.recordRestPoint(transform.X, transform.Y)
+	}
+
 	return &events.EventResult{
 		Success: true,
 		Message: message,
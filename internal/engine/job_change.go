@@ -0,0 +1,154 @@
+// Package engine provides job changes, secondary jobs and skill resets at job shrines
+package engine
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/events"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/skills"
+)
+
+// Job shrine menu options
+const (
+	jobOptionChange    = "Change Job"
+	jobOptionSecondary = "Secondary Job"
+	jobOptionNone      = "None"
+)
+
+// handleJobChangeEvent opens the job shrine menu
+func (g *Game) handleJobChangeEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
+	logger.Info("Job change event triggered: %s", eventComp.Name)
+
+	g.showJobShrineMenu(eventComp.Name)
+	g.uiManager.AddMessage(fmt.Sprintf("You visit the %s.", eventComp.Name))
+
+	return &events.EventResult{
+		Success: true,
+		Message: fmt.Sprintf("Visited %s", eventComp.Name),
+	}
+}
+
+// showJobShrineMenu lets the player pick the party member whose job changes
+func (g *Game) showJobShrineMenu(title string) {
+	members := g.partyManager.GetPartyForTactical()
+	options := make([]string, 0, len(members))
+	for _, member := range members {
+		stats := member.RPGStats()
+		options = append(options, fmt.Sprintf("%s - %s Lv%d", stats.Name, stats.Job.String(), stats.Level))
+	}
+
+	g.uiManager.ShowSelectionPopup(title, options, func(index int, option string) {
+		g.showJobOptionsMenu(title, members[index])
+	}, nil)
+}
+
+// showJobOptionsMenu lets the player choose between changing the job, setting the secondary job,
+// refunding single skills and resetting the skills
+func (g *Game) showJobOptionsMenu(title string, member *ecs.Entity) {
+	options := []string{jobOptionChange, jobOptionSecondary, respecOptionRefund, respecOptionReset}
+	g.uiManager.ShowSelectionPopup(member.RPGStats().Name, options,
+		func(index int, option string) {
+			switch option {
+			case jobOptionChange:
+				g.showJobSelectionMenu(member)
+			case jobOptionSecondary:
+				g.showSecondaryJobMenu(member)
+			case respecOptionRefund:
+				g.showSkillRefunds(member)
+			default:
+				g.showSkillResetMenu(member)
+			}
+		},
+		func() {
+			g.showJobShrineMenu(title)
+		})
+}
+
+// showJobSelectionMenu lists every job with the member's level in it
+func (g *Game) showJobSelectionMenu(member *ecs.Entity) {
+	stats := member.RPGStats()
+	jobs := make([]components.JobType, 0)
+	options := make([]string, 0)
+	for _, def := range components.GetJobRegistry().GetAllJobs() {
+		if def.ID == stats.Job {
+			continue
+		}
+		jobs = append(jobs, def.ID)
+		if level := stats.GetJobLevel(def.ID); level > 0 {
+			options = append(options, fmt.Sprintf("%s (Lv%d)", def.Name, level))
+		} else {
+			options = append(options, fmt.Sprintf("%s (new)", def.Name))
+		}
+	}
+
+	g.uiManager.ShowSelectionPopup("Change Job", options, func(index int, option string) {
+		if err := g.changeJob(member, jobs[index]); err != nil {
+			g.uiManager.AddMessage(fmt.Sprintf("Job change failed: %v", err))
+			logger.Warn("Job change failed for %s: %v", stats.Name, err)
+		}
+	}, nil)
+}
+
+// showSecondaryJobMenu lists the trained jobs that can lend their active skills
+func (g *Game) showSecondaryJobMenu(member *ecs.Entity) {
+	stats := member.RPGStats()
+	jobs := []components.JobType{""}
+	options := []string{jobOptionNone}
+	for _, job := range stats.GetTrainedJobs() {
+		if job == stats.Job {
+			continue
+		}
+		jobs = append(jobs, job)
+		options = append(options, fmt.Sprintf("%s (Lv%d)", job.String(), stats.GetJobLevel(job)))
+	}
+
+	g.uiManager.ShowSelectionPopup("Secondary Job", options, func(index int, option string) {
+		skillsComp := g.ensureJobSkills(member)
+		if err := skillsComp.SetSecondaryJob(jobs[index]); err != nil {
+			g.uiManager.AddMessage(fmt.Sprintf("Cannot set secondary job: %v", err))
+			return
+		}
+		g.uiManager.AddMessage(fmt.Sprintf("%s's secondary job: %s", stats.Name, option))
+	}, nil)
+}
+
+// changeJob switches a character to another job and follows it with the skills component
+func (g *Game) changeJob(member *ecs.Entity, job components.JobType) error {
+	stats := member.RPGStats()
+	oldJob := stats.Job
+	if err := stats.ChangeJob(job); err != nil {
+		return err
+	}
+
+	skillsComp := g.ensureJobSkills(member)
+	tree, _ := skills.GetGlobalSkillRegistry().GetSkillTree(job)
+	skillsComp.ChangeJob(job, tree)
+
+	g.uiManager.AddMessage(fmt.Sprintf("%s changed job: %s -> %s (Lv%d)",
+		stats.Name, oldJob.String(), job.String(), stats.Level))
+	logger.Info("%s changed job from %s to %s", stats.Name, oldJob, job)
+	return nil
+}
+
+// ensureJobSkills returns the member's skills component, creating it and recording
+// the skill trees of every trained job
+func (g *Game) ensureJobSkills(member *ecs.Entity) *components.SkillsComponent {
+	stats := member.RPGStats()
+	skillsComp := member.Skills()
+	if skillsComp == nil {
+		skillsComp = components.NewSkillsComponent(stats.Job)
+		skillsComp.AddSkillPoints(constants.InitialSkillPoints)
+		member.AddComponent(ecs.ComponentSkills, skillsComp)
+	}
+
+	registry := skills.GetGlobalSkillRegistry()
+	for _, job := range stats.GetTrainedJobs() {
+		tree, _ := registry.GetSkillTree(job)
+		skillsComp.TrainJob(job, tree)
+	}
+	return skillsComp
+}
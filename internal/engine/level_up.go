@@ -0,0 +1,115 @@
+// Package engine provides level-up notifications: stat-gain popups, level quest objectives
+// and quest completion
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// handleLevelUp queues the stat-gain popup, updates the "reach level" quest objectives and
+// completes the quests they finish
+func (g *Game) handleLevelUp(entity *ecs.Entity, event components.LevelUpEvent) {
+	logger.Info("⭐ %s reached %s level %d (+%d skill points)", event.Name, event.Job.String(), event.Level, event.SkillPoints)
+	g.pendingLevelUps = append(g.pendingLevelUps, event)
+
+	for _, member := range g.partyManager.GetPartyForTactical() {
+		journal := member.QuestJournal()
+		if journal == nil {
+			continue
+		}
+		for _, objective := range journal.UpdateLevelProgress(event.Job, event.Level) {
+			g.uiManager.AddMessage(fmt.Sprintf("Objective complete: %s", objective))
+		}
+		g.completeFinishedQuests(member, journal)
+	}
+}
+
+// completeFinishedQuests completes the active quests whose required objectives are done and
+// grants their rewards: gold to the party wallet, experience and skill points to the journal
+// owner, and items to the owner's inventory or the first party member with room
+func (g *Game) completeFinishedQuests(member *ecs.Entity, journal *components.QuestJournalComponent) {
+	for _, quest := range journal.GetActiveQuests() {
+		if !quest.CheckCompletion() {
+			continue
+		}
+		// The quest is completed before granting experience, so the level-ups it causes
+		// cannot complete it again
+		reward := journal.CompleteQuest(quest.ID)
+		g.uiManager.AddMessage(fmt.Sprintf("Quest complete: %s", quest.Title))
+		logger.Info("📜 Quest %s completed", quest.ID)
+		if reward == nil {
+			continue
+		}
+
+		if reward.Gold > 0 {
+			g.partyManager.AddGold(reward.Gold, fmt.Sprintf("Quest reward: %s", quest.Title))
+			g.uiManager.AddMessage(fmt.Sprintf("Received %d gold", reward.Gold))
+		}
+		if reward.SkillPoints > 0 {
+			g.progression.GrantSkillPoints(member, reward.SkillPoints)
+			g.uiManager.AddMessage(fmt.Sprintf("Received %d skill points", reward.SkillPoints))
+		}
+		for _, ref := range append(append([]string{}, reward.Items...), reward.Equipment...) {
+			g.grantQuestItem(member, ref)
+		}
+		if reward.Experience > 0 {
+			g.uiManager.AddMessage(fmt.Sprintf("Received %d experience", reward.Experience))
+			g.progression.GrantExperience(member, reward.Experience)
+		}
+	}
+}
+
+// grantQuestItem adds a quest reward item to the inventory of the journal owner, then to the
+// other party members
+func (g *Game) grantQuestItem(member *ecs.Entity, ref string) {
+	if components.GlobalItemRegistry == nil {
+		logger.Error("Item registry not initialized")
+		return
+	}
+	template := components.GlobalItemRegistry.ResolveItem(ref)
+	if template == nil {
+		logger.Warn("Quest reward item %s not found in the item registry", ref)
+		return
+	}
+
+	recipients := append([]*ecs.Entity{member}, g.partyManager.GetPartyForTactical()...)
+	for _, recipient := range recipients {
+		inventory := recipient.Inventory()
+		if inventory == nil {
+			continue
+		}
+		if inventory.AddItem(components.GlobalItemRegistry.CreateItem(template.ID), 1) == 0 {
+			g.uiManager.AddMessage(fmt.Sprintf("Received %s", template.Name))
+			return
+		}
+	}
+	logger.Warn("No inventory room for quest reward item %s", template.Name)
+}
+
+// showNextLevelUp shows the stat-gain popup of the oldest queued level-up
+func (g *Game) showNextLevelUp() {
+	event := g.pendingLevelUps[0]
+	g.pendingLevelUps = g.pendingLevelUps[1:]
+
+	title := fmt.Sprintf("%s reached level %d!", event.Name, event.Level)
+	g.uiManager.ShowInfoPopup(title, formatLevelUpGains(event), nil)
+}
+
+// formatLevelUpGains lists the stat gains and skill points of a level-up, one per line
+func formatLevelUpGains(event components.LevelUpEvent) string {
+	lines := []string{fmt.Sprintf("Job: %s", event.Job.String())}
+	for stat := components.StatMaxHP; stat <= components.StatAP; stat++ {
+		if gain, exists := event.Gains[stat]; exists {
+			lines = append(lines, fmt.Sprintf("%s %+d", stat.String(), gain))
+		}
+	}
+	if event.SkillPoints > 0 {
+		lines = append(lines, fmt.Sprintf("Skill points %+d", event.SkillPoints))
+	}
+	return strings.Join(lines, "\n")
+}
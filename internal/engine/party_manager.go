@@ -4,16 +4,18 @@ package engine
 import (
 	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/logger"
 	"github.com/jrecuero/myrpg/internal/tactical"
 )
 
 // PartyManager handles party composition and deployment
 type PartyManager struct {
-	PartyLeader    *ecs.Entity   // Single character in exploration mode
-	PartyMembers   []*ecs.Entity // Full party for tactical mode
-	ReserveMembers []*ecs.Entity // Inactive party members
-	MaxPartySize   int           // Maximum party size for tactical
+	PartyLeader    *ecs.Entity                 // Single character in exploration mode
+	PartyMembers   []*ecs.Entity               // Full party for tactical mode
+	ReserveMembers []*ecs.Entity               // Inactive party members
+	MaxPartySize   int                         // Maximum party size for tactical
+	Wallet         *components.WalletComponent // Gold and other currencies shared by the whole party
 }
 
 // NewPartyManager creates a new party manager
@@ -22,6 +24,7 @@ func NewPartyManager(maxSize int) *PartyManager {
 		PartyMembers:   make([]*ecs.Entity, 0),
 		ReserveMembers: make([]*ecs.Entity, 0),
 		MaxPartySize:   maxSize,
+		Wallet:         components.NewWalletComponent(),
 	}
 }
 
@@ -140,6 +143,31 @@ func (pm *PartyManager) GetPartyLeader() *ecs.Entity {
 	return pm.PartyLeader
 }
 
+// AddGold adds gold to the party wallet
+func (pm *PartyManager) AddGold(amount int, reason string) {
+	pm.Wallet.Credit(components.CurrencyGold, amount, reason)
+}
+
+// SpendGold pays gold from the party wallet, failing without changes if the party cannot afford it
+func (pm *PartyManager) SpendGold(amount int, reason string) error {
+	return pm.Wallet.Debit(components.CurrencyGold, amount, reason)
+}
+
+// RemoveGold takes gold from the party, never going below zero, and returns the amount removed
+func (pm *PartyManager) RemoveGold(amount int, reason string) int {
+	return pm.Wallet.Take(components.CurrencyGold, amount, reason)
+}
+
+// GetGold returns the gold held by the party
+func (pm *PartyManager) GetGold() int {
+	return pm.Wallet.Balance(components.CurrencyGold)
+}
+
+// GetWallet returns the party wallet
+func (pm *PartyManager) GetWallet() *components.WalletComponent {
+	return pm.Wallet
+}
+
 // GetPartySize returns the current party size
 func (pm *PartyManager) GetPartySize() int {
 	return len(pm.PartyMembers)
@@ -0,0 +1,76 @@
+// Package engine provides shop events and dialog actions that open the shop widget
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/events"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/shop"
+	"github.com/jrecuero/myrpg/internal/ui"
+)
+
+// openShop opens a shop from the shop registry for a customer paying from the party gold
+func (g *Game) openShop(shopID string, customer *ecs.Entity) error {
+	s, exists := shop.GetGlobalShopRegistry().GetShop(shopID)
+	if !exists {
+		return fmt.Errorf("shop %s not found", shopID)
+	}
+	if customer == nil || customer.Inventory() == nil {
+		return fmt.Errorf("no customer with an inventory")
+	}
+
+	// Restock for the time passed since the shop was last open
+	s.Update(time.Now())
+	if err := g.uiManager.ShowShop(s, customer, g.partyManager); err != nil {
+		return err
+	}
+	g.uiManager.AddMessage(fmt.Sprintf("Welcome to %s!", s.Name))
+	logger.Info("Opened shop %s for %s", s.ID, customer.RPGStats().Name)
+	return nil
+}
+
+// handleShopEvent opens the shop of a shop event for the player who triggered it
+func (g *Game) handleShopEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *events.EventResult {
+	logger.Info("Shop event triggered: %s", eventComp.Name)
+
+	shopID := eventComp.EventData.ShopID
+	if err := g.openShop(shopID, player); err != nil {
+		message := fmt.Sprintf("%s is closed", eventComp.Name)
+		g.uiManager.AddMessage(message)
+		logger.Warn("Failed to open shop %s: %v", shopID, err)
+		return &events.EventResult{
+			Success: false,
+			Message: message,
+		}
+	}
+
+	return &events.EventResult{
+		Success: true,
+		Message: fmt.Sprintf("Opened %s", eventComp.Name),
+		Data: map[string]interface{}{
+			"shop_id": shopID,
+		},
+	}
+}
+
+// handleDialogAction queues the shop of an "open_shop" dialog action, which is opened once
+// the dialog closes
+func (g *Game) handleDialogAction(action ui.Action) {
+	if action.Type == "open_shop" {
+		g.pendingShopID = action.ShopID
+	}
+}
+
+// openPendingShop opens the shop queued by a dialog for the active player
+func (g *Game) openPendingShop() {
+	shopID := g.pendingShopID
+	g.pendingShopID = ""
+	if err := g.openShop(shopID, g.GetActivePlayer()); err != nil {
+		g.uiManager.AddMessage("The shop is closed")
+		logger.Warn("Failed to open shop %s: %v", shopID, err)
+	}
+}
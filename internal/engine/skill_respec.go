@@ -0,0 +1,164 @@
+// Package engine provides skill respecs at job shrines, paid with gold or a Tome of Forgetting
+package engine
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/skills"
+)
+
+// Skill reset menu options
+const (
+	respecOptionReset  = "Reset Skills"
+	respecOptionRefund = "Refund Skills"
+	respecOptionTome   = "Use Tome of Forgetting"
+	respecOptionCancel = "Cancel"
+)
+
+// respecPayment is how a skill respec is paid
+type respecPayment int
+
+const (
+	respecPayGold respecPayment = iota // Gold cost of the refunded skill points
+	respecPayTome                      // One Tome of Forgetting from the member's inventory
+)
+
+// respecSkills refunds one leaf skill, or every learned skill when skill is nil, and then
+// charges the chosen payment. Nothing is charged when the refund fails.
+func (g *Game) respecSkills(member *ecs.Entity, skill *components.Skill, payment respecPayment) error {
+	skillsComp := member.Skills()
+	if skillsComp == nil {
+		return fmt.Errorf("%s has no skills", member.RPGStats().Name)
+	}
+
+	points := skillsComp.GetSpentPoints()
+	if skill != nil {
+		if err := skillsComp.CanForgetSkill(skill.ID); err != nil {
+			return err
+		}
+		points = skill.SkillPoints
+	}
+	if points == 0 {
+		return fmt.Errorf("no skill points to refund")
+	}
+	if err := g.canPayForRespec(member, points, payment); err != nil {
+		return err
+	}
+
+	name := member.RPGStats().Name
+	if skill != nil {
+		if err := skills.ForgetSkill(member, skill.ID); err != nil {
+			return err
+		}
+	} else {
+		if _, err := skills.ResetSkills(member); err != nil {
+			return err
+		}
+	}
+
+	receipt, err := g.payForRespec(member, points, payment)
+	if err != nil {
+		return err
+	}
+	if skill != nil {
+		g.uiManager.AddMessage(fmt.Sprintf("%s forgot %s (+%d SP, %s)", name, skill.Name, points, receipt))
+	} else {
+		g.uiManager.AddMessage(fmt.Sprintf("%s reset all skills (+%d SP, %s)", name, points, receipt))
+	}
+	logger.Info("%s refunded %d skill points (%s)", name, points, receipt)
+	return nil
+}
+
+// refundSkillsForGold is the skills widget refund handler of a job shrine, paid with gold
+func (g *Game) refundSkillsForGold(member *ecs.Entity, skill *components.Skill) error {
+	return g.respecSkills(member, skill, respecPayGold)
+}
+
+// canPayForRespec checks that the party can afford a respec with the chosen payment
+func (g *Game) canPayForRespec(member *ecs.Entity, points int, payment respecPayment) error {
+	if payment == respecPayTome {
+		if !hasRespecTome(member) {
+			return fmt.Errorf("%s has no Tome of Forgetting", member.RPGStats().Name)
+		}
+		return nil
+	}
+
+	cost := respecGoldCost(points)
+	if gold := g.partyManager.GetGold(); gold < cost {
+		return fmt.Errorf("not enough gold (%d needed, %d held)", cost, gold)
+	}
+	return nil
+}
+
+// payForRespec spends a Tome of Forgetting from the member's inventory or the gold cost of
+// the refunded skill points. It returns how the respec was paid.
+func (g *Game) payForRespec(member *ecs.Entity, points int, payment respecPayment) (string, error) {
+	if payment == respecPayTome {
+		if !hasRespecTome(member) {
+			return "", fmt.Errorf("%s has no Tome of Forgetting", member.RPGStats().Name)
+		}
+		member.Inventory().RemoveItem(constants.SkillRespecItemID, 1)
+		return "used a Tome of Forgetting", nil
+	}
+
+	cost := respecGoldCost(points)
+	if err := g.partyManager.SpendGold(cost, fmt.Sprintf("Skill respec for %s", member.RPGStats().Name)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("paid %d gold", cost), nil
+}
+
+// hasRespecTome reports whether a member carries a Tome of Forgetting
+func hasRespecTome(member *ecs.Entity) bool {
+	inventory := member.Inventory()
+	return inventory != nil && inventory.GetItemCount(constants.SkillRespecItemID) > 0
+}
+
+// respecGoldCost returns the gold paid to refund skill points
+func respecGoldCost(points int) int {
+	return points * constants.SkillRespecGoldPerPoint
+}
+
+// showSkillResetMenu asks how to pay for resetting every skill of a member at a job shrine
+func (g *Game) showSkillResetMenu(member *ecs.Entity) {
+	stats := member.RPGStats()
+	skillsComp := g.ensureJobSkills(member)
+	points := skillsComp.GetSpentPoints()
+	if points == 0 {
+		g.uiManager.AddMessage(fmt.Sprintf("%s has no skills to reset", stats.Name))
+		return
+	}
+
+	title := fmt.Sprintf("Refund %d SP?", points)
+	options := []string{fmt.Sprintf("Pay %d gold", respecGoldCost(points))}
+	payments := []respecPayment{respecPayGold}
+	if hasRespecTome(member) {
+		options = append(options, respecOptionTome)
+		payments = append(payments, respecPayTome)
+	}
+	options = append(options, respecOptionCancel)
+
+	g.uiManager.ShowSelectionPopup(title, options, func(index int, option string) {
+		if index >= len(payments) {
+			return
+		}
+		if err := g.respecSkills(member, nil, payments[index]); err != nil {
+			g.uiManager.AddMessage(fmt.Sprintf("Skill reset failed: %v", err))
+			logger.Warn("Skill reset failed for %s: %v", stats.Name, err)
+		}
+	}, nil)
+}
+
+// showSkillRefunds opens the skills widget of a member at a job shrine, where learned leaf
+// skills can be refunded for gold
+func (g *Game) showSkillRefunds(member *ecs.Entity) {
+	g.ensureJobSkills(member)
+	if err := g.uiManager.ShowSkillRefunds(member, g.refundSkillsForGold); err != nil {
+		g.uiManager.AddMessage(fmt.Sprintf("Failed to show skills: %v", err))
+		logger.Error("Failed to show skill refunds for %s: %v", member.RPGStats().Name, err)
+	}
+}
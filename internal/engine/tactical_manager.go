@@ -2,9 +2,12 @@
 package engine
 
 import (
+	"math/rand"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/logger"
 	"github.com/jrecuero/myrpg/internal/tactical"
 	"github.com/jrecuero/myrpg/internal/ui"
@@ -39,6 +42,9 @@ type TacticalManager struct {
 	IsActive           bool
 	Participants       []*ecs.Entity // Entities involved in tactical combat
 	UseTurnBasedCombat bool          // Flag to switch between old and new combat systems
+
+	victoryCallback func(players, enemies []*ecs.Entity) // Called with the participants after a player victory
+	defeatCallback  func()                               // Called after the party is defeated
 }
 
 // NewTacticalManager creates a new tactical manager
@@ -69,6 +75,12 @@ func NewTacticalManager(gridWidth, gridHeight, tileSize int) *TacticalManager {
 	return tm
 }
 
+// SetRNG sets the random source used by both tactical combat systems (the shared game RNG)
+func (tm *TacticalManager) SetRNG(rng *rand.Rand) {
+	tm.Combat.SetRNG(rng)
+	tm.TurnBasedCombat.SetRNG(rng)
+}
+
 // StartTacticalCombat switches to tactical mode with given entities
 func (tm *TacticalManager) StartTacticalCombat(entities []*ecs.Entity) {
 	tm.IsActive = true
@@ -98,6 +110,62 @@ func (tm *TacticalManager) EndTacticalCombat() {
 	tm.GridRenderer.ClearHighlights()
 	tm.GridRenderer.SetShowGrid(false)
 	tm.CombatUI.Reset()
+
+	// Objectives and scripts only apply to the battle that declared them
+	tm.TurnBasedCombat.SetObjectives(nil)
+	tm.TurnBasedCombat.SetBattleScript(nil)
+}
+
+// ToggleTurnMode switches the tactical turn order between team turns and charge-time turns
+func (tm *TacticalManager) ToggleTurnMode() tactical.TurnMode {
+	if tm.TurnBasedCombat.GetTurnMode() == tactical.TurnModeTeam {
+		tm.TurnBasedCombat.SetTurnMode(tactical.TurnModeChargeTime)
+	} else {
+		tm.TurnBasedCombat.SetTurnMode(tactical.TurnModeTeam)
+	}
+	return tm.TurnBasedCombat.GetTurnMode()
+}
+
+// SetVictoryCallback sets the function called with the battle participants after a player victory
+func (tm *TacticalManager) SetVictoryCallback(callback func(players, enemies []*ecs.Entity)) {
+	tm.victoryCallback = callback
+}
+
+// SetDefeatCallback sets the function called after the party is defeated
+func (tm *TacticalManager) SetDefeatCallback(callback func()) {
+	tm.defeatCallback = callback
+}
+
+// SetBattleObjectives configures victory and defeat conditions for the next tactical battle
+func (tm *TacticalManager) SetBattleObjectives(victory, defeat *components.BattleConditionSpec) error {
+	objectives, err := tactical.NewBattleObjectivesFromSpec(victory, defeat)
+	if err != nil {
+		tm.TurnBasedCombat.SetObjectives(nil)
+		return err
+	}
+
+	tm.TurnBasedCombat.SetObjectives(objectives)
+	logger.Debug("Battle objectives set - victory: %s, defeat: %s",
+		objectives.Victory.Description, objectives.Defeat.Description)
+	return nil
+}
+
+// SetBattleScript configures scripted events for the next tactical battle
+func (tm *TacticalManager) SetBattleScript(specs []components.BattleScriptSpec) error {
+	if len(specs) == 0 {
+		tm.TurnBasedCombat.SetBattleScript(nil)
+		return nil
+	}
+
+	script, err := tactical.NewBattleScriptFromSpec(specs)
+	if err != nil {
+		tm.TurnBasedCombat.SetBattleScript(nil)
+		return err
+	}
+
+	tm.TurnBasedCombat.SetBattleScript(script)
+	logger.Debug("Battle script set with %d events", len(script.Events))
+	return nil
 }
 
 // setupCombatUICallbacks configures the combat UI callbacks
@@ -171,6 +239,27 @@ func (tm *TacticalManager) setupCombatUICallbacks() {
 				logger.Action("Attack action executed successfully")
 			}
 		},
+		func(skill *components.Skill, target *ecs.Entity) {
+			// Handle ability target selection
+			activeUnit := tm.TurnBasedCombat.GetActiveUnit()
+			if activeUnit == nil {
+				logger.Action("No active unit for ability")
+				return
+			}
+
+			logger.Action("Creating ability action %s from %s to %s",
+				skill.ID, activeUnit.GetID(), target.GetID())
+
+			action, err := tm.TurnBasedCombat.CreateAbilityAction(activeUnit, skill, target)
+			if err != nil {
+				logger.Error("Failed to create ability action: %v", err)
+				return
+			}
+
+			if err := tm.TurnBasedCombat.ExecuteAction(action); err != nil {
+				logger.Error("Failed to execute ability action: %v", err)
+			}
+		},
 		func() {
 			// Handle cancel
 			logger.Action("Attack action cancelled by user")
@@ -310,16 +399,38 @@ func (tm *TacticalManager) ResetPlayerMovement(player *ecs.Entity) {
 
 // handleCombatEnd processes the end of combat
 func (tm *TacticalManager) handleCombatEnd() {
+	defeated := false
 	if tm.UseTurnBasedCombat {
 		result := tm.TurnBasedCombat.GetResult()
-		logger.Combat("Combat ended with result: %s", result.String())
+		if condition := tm.TurnBasedCombat.GetEndCondition(); condition != nil {
+			logger.Combat("Combat ended with result: %s (condition: %s)", result.String(), condition.Description)
+		} else {
+			logger.Combat("Combat ended with result: %s", result.String())
+		}
 
-		// TODO: Add proper victory/defeat handling
-		// This could trigger UI changes, experience gain, loot, etc.
+		// Grant victory rewards before the participants are cleared
+		if result == tactical.CombatResultPlayerVictory && tm.victoryCallback != nil {
+			players := make([]*ecs.Entity, 0)
+			enemies := make([]*ecs.Entity, 0)
+			for _, participant := range tm.Participants {
+				if participant.HasTag(ecs.TagPlayer) {
+					players = append(players, participant)
+				} else if participant.HasTag(ecs.TagEnemy) {
+					enemies = append(enemies, participant)
+				}
+			}
+			tm.victoryCallback(players, enemies)
+		}
+		defeated = result == tactical.CombatResultEnemyVictory
 	}
 
 	// End tactical mode
 	tm.EndTacticalCombat()
+
+	// Defeat handling may leave tactical mode, so it runs once combat is cleaned up
+	if defeated && tm.defeatCallback != nil {
+		tm.defeatCallback()
+	}
 }
 
 // GetTurnBasedCombat returns the turn-based combat manager for external access
@@ -23,6 +23,7 @@ func CreateDefaultHandlers() map[components.EventType]EventHandler {
 	handlers[components.EventCutscene] = HandleCutsceneEvent
 	handlers[components.EventShop] = HandleShopEvent
 	handlers[components.EventRest] = HandleRestEvent
+	handlers[components.EventJobChange] = HandleJobChangeEvent
 
 	return handlers
 }
@@ -277,3 +278,18 @@ func HandleRestEvent(entity *ecs.Entity, eventComp *components.EventComponent, p
 		NextAction: "rest_player",
 	}
 }
+
+// HandleJobChangeEvent handles job shrine events where characters change jobs
+func HandleJobChangeEvent(entity *ecs.Entity, eventComp *components.EventComponent, player *ecs.Entity) *EventResult {
+	logger.Info("Job change event triggered: %s", eventComp.Name)
+
+	data := make(map[string]interface{})
+	data["event_entity_id"] = entity.GetID()
+
+	return &EventResult{
+		Success:    true,
+		Message:    fmt.Sprintf("You visit the %s.", eventComp.Name),
+		Data:       data,
+		NextAction: "change_job",
+	}
+}
@@ -34,6 +34,7 @@ type EventManager struct {
 	eventHistory    []EventExecutionRecord                // History of executed events
 	currentGameMode components.GameMode                   // Current game mode for event filtering
 	collidingEvents map[string]bool                       // Track events currently colliding with player
+	battleGraceEnd  time.Time                             // Battle events do not trigger before this time
 }
 
 // EventExecutionRecord tracks when and how events were executed
@@ -135,6 +136,11 @@ func (em *EventManager) Update(deltaTime float64) {
 			continue
 		}
 
+		// Skip battle events during the grace period after an escape
+		if eventComp.EventType == components.EventBattle && time.Now().Before(em.battleGraceEnd) {
+			continue
+		}
+
 		// Check trigger condition
 		isTriggering := em.checkTriggerCondition(entity, eventComp, playerTransform, deltaTime)
 
@@ -438,6 +444,19 @@ func (em *EventManager) GetEventStats() map[string]interface{} {
 	return stats
 }
 
+// SuppressBattleEvents prevents battle events from triggering for the given duration
+func (em *EventManager) SuppressBattleEvents(duration time.Duration) {
+	em.battleGraceEnd = time.Now().Add(duration)
+	logger.Info("Battle events suppressed for %v", duration)
+}
+
+// RestoreEvent makes a triggered event available again (e.g. a battle the party escaped from)
+func (em *EventManager) RestoreEvent(eventComp *components.EventComponent) {
+	eventComp.Reset()
+	delete(em.completedEvents, eventComp.ID)
+	em.activeEvents[eventComp.ID] = eventComp
+}
+
 // GetGameMode returns the current game mode
 func (em *EventManager) GetGameMode() components.GameMode {
 	return em.currentGameMode
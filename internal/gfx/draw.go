@@ -1,3 +1,5 @@
+//go:build !headless
+
 // Package gfx provides functions for drawing sprites and handling graphics operations.
 // It includes functions to draw sprites at specified positions with scaling options,
 // as well as functions to get the bounding rectangle of a sprite for collision detection
@@ -73,14 +75,3 @@ func DrawSpriteClipped(screen *ebiten.Image, s *Sprite, x float64, y float64, sc
 	op.GeoM.Translate(x-float64(clipX), y-float64(clipY))
 	clippedScreen.DrawImage(s.Img, op)
 }
-
-// BoundsRect returns the bounding rectangle of a sprite at the specified position with an optional scale.
-// x and y are the coordinates where the sprite is drawn.
-// w and h are the width and height of the sprite.
-// scale is the scaling factor for the sprite (1.0 means no scaling).
-// returns an image.Rectangle representing the bounding box.
-func BoundsRect(x float64, y float64, w int, h int, scale float64) image.Rectangle {
-	sw := float64(w) * scale
-	sh := float64(h) * scale
-	return image.Rect(int(x), int(y), int(x+sw), int(y+sh))
-}
@@ -0,0 +1,30 @@
+//go:build !headless
+
+package gfx
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Image is the image type sprites are drawn with.
+type Image = ebiten.Image
+
+// LoadImage loads an image from the specified file path.
+// path is the file path to the image.
+// w and h are the desired width and height of the image (not used in this function).
+// returns a pointer to the loaded ebiten.Image and an error if any occurs.
+func LoadImage(path string, w int, h int) (*Image, error) {
+	img, _, err := ebitenutil.NewImageFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// subImage returns the part of an image inside the given rectangle.
+func subImage(img *Image, rect image.Rectangle) *Image {
+	return img.SubImage(rect).(*ebiten.Image)
+}
@@ -0,0 +1,43 @@
+//go:build headless
+
+package gfx
+
+import (
+	"image"
+	_ "image/png" // Sprite files are PNG images
+	"os"
+)
+
+// Image stands in for an ebiten image in headless builds (such as the battle
+// simulator), where nothing is drawn and only the image size is known.
+type Image struct {
+	bounds image.Rectangle
+}
+
+// Bounds returns the bounds of the image.
+func (img *Image) Bounds() image.Rectangle {
+	return img.bounds
+}
+
+// LoadImage reads the size of the image at the specified file path.
+// path is the file path to the image.
+// w and h are the desired width and height of the image (not used in this function).
+// returns a pointer to the headless Image and an error if any occurs.
+func LoadImage(path string, w int, h int) (*Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{bounds: image.Rect(0, 0, config.Width, config.Height)}, nil
+}
+
+// subImage returns the part of an image inside the given rectangle.
+func subImage(img *Image, rect image.Rectangle) *Image {
+	return &Image{bounds: rect.Intersect(img.bounds)}
+}
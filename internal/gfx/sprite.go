@@ -6,9 +6,6 @@ package gfx
 
 import (
 	"image"
-
-	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 // Sprite represents a drawable image in the game.
@@ -17,21 +14,9 @@ import (
 // W is the width of the sprite.
 // H is the height of the sprite.
 type Sprite struct {
-	Img *ebiten.Image // The image representing the sprite
-	W   int           // Width of the sprite
-	H   int           // Height of the sprite
-}
-
-// LoadImage loads an image from the specified file path.
-// path is the file path to the image.
-// w and h are the desired width and height of the image (not used in this function).
-// returns a pointer to the loaded ebiten.Image and an error if any occurs.
-func LoadImage(path string, w int, h int) (*ebiten.Image, error) {
-	img, _, err := ebitenutil.NewImageFromFile(path)
-	if err != nil {
-		return nil, err
-	}
-	return img, nil
+	Img *Image // The image representing the sprite
+	W   int    // Width of the sprite
+	H   int    // Height of the sprite
 }
 
 // NewSpriteFromFile creates a new Sprite by loading an image from the specified file path.
@@ -59,11 +44,11 @@ func (s *Sprite) Bounds(x float64, y float64) image.Rectangle {
 
 // SpriteSheet represents a collection of sprites arranged in a grid
 type SpriteSheet struct {
-	Image        *ebiten.Image // The sprite sheet image
-	SpriteWidth  int           // Width of each individual sprite
-	SpriteHeight int           // Height of each individual sprite
-	Columns      int           // Number of columns in the sprite sheet
-	Rows         int           // Number of rows in the sprite sheet
+	Image        *Image // The sprite sheet image
+	SpriteWidth  int    // Width of each individual sprite
+	SpriteHeight int    // Height of each individual sprite
+	Columns      int    // Number of columns in the sprite sheet
+	Rows         int    // Number of rows in the sprite sheet
 }
 
 // NewSpriteSheetFromFile creates a new sprite sheet from an image file
@@ -99,7 +84,7 @@ func (ss *SpriteSheet) GetSprite(index int) (*Sprite, error) {
 	y := row * ss.SpriteHeight
 
 	// Create a sub-image for the sprite
-	subImg := ss.Image.SubImage(image.Rect(x, y, x+ss.SpriteWidth, y+ss.SpriteHeight)).(*ebiten.Image)
+	subImg := subImage(ss.Image, image.Rect(x, y, x+ss.SpriteWidth, y+ss.SpriteHeight))
 
 	return &Sprite{
 		Img: subImg,
@@ -127,3 +112,14 @@ func (ss *SpriteSheet) GetSprites(startIndex, count int) ([]*Sprite, error) {
 func (ss *SpriteSheet) GetAllSprites() ([]*Sprite, error) {
 	return ss.GetSprites(0, ss.Columns*ss.Rows)
 }
+
+// BoundsRect returns the bounding rectangle of a sprite at the specified position with an optional scale.
+// x and y are the coordinates where the sprite is drawn.
+// w and h are the width and height of the sprite.
+// scale is the scaling factor for the sprite (1.0 means no scaling).
+// returns an image.Rectangle representing the bounding box.
+func BoundsRect(x float64, y float64, w int, h int, scale float64) image.Rectangle {
+	sw := float64(w) * scale
+	sh := float64(h) * scale
+	return image.Rect(int(x), int(y), int(x+sw), int(y+sh))
+}
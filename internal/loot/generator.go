@@ -0,0 +1,221 @@
+// Package loot provides procedural equipment generation: a rarity rolled for each drop and
+// random prefixes and suffixes rolled from affix tables
+package loot
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// StatRange is the range a stat bonus of an affix is rolled in
+type StatRange struct {
+	Stat     components.StatType // Stat raised
+	Min, Max int                 // Inclusive roll range
+}
+
+// Affix is a prefix or suffix that can be rolled on equipment
+type Affix struct {
+	ID     string                     // Affix ID
+	Name   string                     // Name added to the item ("Swift", "of the Bear")
+	Prefix bool                       // Whether the name goes before the item name
+	Stats  []StatRange                // Stat bonuses rolled
+	Slots  []components.EquipmentSlot // Slots the affix can appear on (empty = any)
+}
+
+// CanRollOn returns true if the affix can appear on equipment of a slot
+func (a *Affix) CanRollOn(slot components.EquipmentSlot) bool {
+	if len(a.Slots) == 0 {
+		return true
+	}
+	for _, allowed := range a.Slots {
+		if allowed == slot {
+			return true
+		}
+	}
+	return false
+}
+
+// Roll rolls the stat bonuses of the affix, scaled by a power percentage
+func (a *Affix) Roll(rng *rand.Rand, power int) components.ItemAffix {
+	rolled := components.ItemAffix{ID: a.ID, Name: a.Name, Prefix: a.Prefix}
+	for _, stat := range a.Stats {
+		value := stat.Min
+		if stat.Max > stat.Min {
+			value += rng.Intn(stat.Max - stat.Min + 1)
+		}
+		scaled := value * power / 100
+		if value > 0 && scaled < 1 {
+			scaled = 1
+		}
+		rolled.Stats = rolled.Stats.AddStat(stat.Stat, scaled)
+	}
+	return rolled
+}
+
+// RarityTier defines how a rarity is rolled and what it grants
+type RarityTier struct {
+	Rarity       components.ItemRarity // Rarity of the tier
+	Weight       int                   // Relative chance of rolling the tier for a drop
+	Affixes      int                   // Affixes rolled, at most one prefix and one suffix
+	Power        int                   // Percentage applied to the rolled affix bonuses
+	ValuePercent int                   // Item value as a percentage of the base item value
+}
+
+// Generator rolls rarities and affixes for equipment
+type Generator struct {
+	tiers    map[components.ItemRarity]*RarityTier
+	prefixes []*Affix
+	suffixes []*Affix
+}
+
+// NewGenerator creates a generator without rarity tiers or affixes, which only creates
+// plain copies of base items
+func NewGenerator() *Generator {
+	return &Generator{
+		tiers:    make(map[components.ItemRarity]*RarityTier),
+		prefixes: make([]*Affix, 0),
+		suffixes: make([]*Affix, 0),
+	}
+}
+
+// RegisterTier adds or replaces a rarity tier
+func (g *Generator) RegisterTier(tier *RarityTier) {
+	g.tiers[tier.Rarity] = tier
+}
+
+// RegisterAffix adds an affix to the prefix or suffix table
+func (g *Generator) RegisterAffix(affix *Affix) {
+	if affix.Prefix {
+		g.prefixes = append(g.prefixes, affix)
+	} else {
+		g.suffixes = append(g.suffixes, affix)
+	}
+}
+
+// GetTier returns the tier of a rarity, nil if it is not defined
+func (g *Generator) GetTier(rarity components.ItemRarity) *RarityTier {
+	return g.tiers[rarity]
+}
+
+// RollRarity rolls a rarity by the tier weights, Common if no tier has weight
+func (g *Generator) RollRarity(rng *rand.Rand) components.ItemRarity {
+	total := 0
+	for rarity := components.ItemRarityCommon; rarity <= components.ItemRarityLegendary; rarity++ {
+		if tier := g.tiers[rarity]; tier != nil {
+			total += tier.Weight
+		}
+	}
+	if total <= 0 {
+		return components.ItemRarityCommon
+	}
+
+	roll := rng.Intn(total)
+	for rarity := components.ItemRarityCommon; rarity <= components.ItemRarityLegendary; rarity++ {
+		if tier := g.tiers[rarity]; tier != nil {
+			if roll < tier.Weight {
+				return rarity
+			}
+			roll -= tier.Weight
+		}
+	}
+	return components.ItemRarityCommon
+}
+
+// RollDrop generates a dropped copy of a base item. Equipment gets a rolled rarity, never
+// below its own, and the affixes of that rarity; other items are plain copies.
+func (g *Generator) RollDrop(base *components.Item, rng *rand.Rand) (*components.Item, error) {
+	if base.Equipment == nil {
+		return g.createCopy(base)
+	}
+	rarity := g.RollRarity(rng)
+	if rarity < base.Rarity {
+		rarity = base.Rarity
+	}
+	return g.Generate(base, rarity, rng)
+}
+
+// Generate creates an item instance of a base equipment item with a rarity, rolling the
+// rarity's affixes on top of the base stats. Without affixes and at the base rarity, the
+// result is a plain copy of the base item.
+func (g *Generator) Generate(base *components.Item, rarity components.ItemRarity, rng *rand.Rand) (*components.Item, error) {
+	if base.Equipment == nil {
+		return nil, fmt.Errorf("%s is not equipment", base.Name)
+	}
+	item, err := g.createCopy(base)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := g.tiers[rarity]
+	affixCount, power, valuePercent := 0, 100, 100
+	if tier != nil {
+		affixCount, power, valuePercent = tier.Affixes, tier.Power, tier.ValuePercent
+	}
+
+	affixes := g.rollAffixes(item.Equipment.Slot, affixCount, power, rng)
+	if len(affixes) == 0 && rarity == base.Rarity {
+		return item, nil
+	}
+
+	equipment := item.Equipment
+	for _, affix := range affixes {
+		equipment.Stats = equipment.Stats.Add(affix.Stats)
+	}
+	equipment.Affixes = affixes
+	item.Name = components.AffixedName(base.Name, affixes)
+	item.Rarity = rarity
+	item.Value = base.Value * valuePercent / 100
+	equipment.Name = item.Name
+	equipment.Rarity = components.EquipmentRarity(rarity)
+	equipment.Value = item.Value
+	item.MakeInstance()
+	return item, nil
+}
+
+// rollAffixes rolls up to count affixes for a slot, at most one prefix and one suffix
+func (g *Generator) rollAffixes(slot components.EquipmentSlot, count, power int, rng *rand.Rand) []components.ItemAffix {
+	pools := [][]*Affix{filterAffixes(g.prefixes, slot), filterAffixes(g.suffixes, slot)}
+	rolled := make([]components.ItemAffix, 0, count)
+	for len(rolled) < count {
+		available := make([]int, 0, len(pools))
+		for i, pool := range pools {
+			if len(pool) > 0 {
+				available = append(available, i)
+			}
+		}
+		if len(available) == 0 {
+			break
+		}
+
+		pick := available[rng.Intn(len(available))]
+		pool := pools[pick]
+		rolled = append(rolled, pool[rng.Intn(len(pool))].Roll(rng, power))
+		pools[pick] = nil
+	}
+	return rolled
+}
+
+// createCopy creates a copy of a base item from the item registry
+func (g *Generator) createCopy(base *components.Item) (*components.Item, error) {
+	if components.GlobalItemRegistry == nil {
+		return nil, fmt.Errorf("item registry not initialized")
+	}
+	item := components.GlobalItemRegistry.CreateItem(base.ID)
+	if item == nil {
+		return nil, fmt.Errorf("item %d not found in the item registry", base.ID)
+	}
+	return item, nil
+}
+
+// filterAffixes returns the affixes that can appear on a slot
+func filterAffixes(affixes []*Affix, slot components.EquipmentSlot) []*Affix {
+	filtered := make([]*Affix, 0, len(affixes))
+	for _, affix := range affixes {
+		if affix.CanRollOn(slot) {
+			filtered = append(filtered, affix)
+		}
+	}
+	return filtered
+}
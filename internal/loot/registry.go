@@ -0,0 +1,173 @@
+// Package loot provides the loot generator loaded from the affix data file
+package loot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// rarityData is the layout of a rarity tier in the affix data file
+type rarityData struct {
+	Rarity       string `json:"rarity"`        // Rarity name ("common" ... "legendary")
+	Weight       int    `json:"weight"`        // Relative chance of rolling the rarity for a drop
+	Affixes      int    `json:"affixes"`       // Affixes rolled (0-2)
+	Power        int    `json:"power"`         // Percentage applied to affix bonuses (default: 100)
+	ValuePercent int    `json:"value_percent"` // Item value percentage (default: 100)
+}
+
+// affixData is the layout of an affix in the affix data file
+type affixData struct {
+	ID    string            `json:"id"`    // Affix ID
+	Name  string            `json:"name"`  // Name added to the item
+	Type  string            `json:"type"`  // "prefix" or "suffix"
+	Stats map[string][2]int `json:"stats"` // Stat name -> [min, max] bonus
+	Slots []string          `json:"slots"` // Slot names the affix can appear on (empty = any)
+}
+
+// affixDataFile is the layout of the affix data file
+type affixDataFile struct {
+	Rarities []rarityData `json:"rarities"`
+	Affixes  []affixData  `json:"affixes"`
+}
+
+// LoadFile registers every rarity tier and affix of an affix data file
+func (g *Generator) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read affix data: %v", err)
+	}
+
+	var file affixDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse affix data: %v", err)
+	}
+
+	for _, entry := range file.Rarities {
+		tier, err := entry.toRarityTier()
+		if err != nil {
+			return err
+		}
+		g.RegisterTier(tier)
+	}
+	ids := make(map[string]bool)
+	for _, entry := range file.Affixes {
+		if ids[entry.ID] {
+			return fmt.Errorf("affix %s already exists", entry.ID)
+		}
+		ids[entry.ID] = true
+		affix, err := entry.toAffix()
+		if err != nil {
+			return err
+		}
+		g.RegisterAffix(affix)
+	}
+	return nil
+}
+
+// toRarityTier converts a data file rarity into a rarity tier
+func (rd *rarityData) toRarityTier() (*RarityTier, error) {
+	rarity, err := components.ParseItemRarity(rd.Rarity)
+	if err != nil {
+		return nil, err
+	}
+	if rd.Weight < 0 || rd.Affixes < 0 || rd.Affixes > 2 || rd.Power < 0 || rd.ValuePercent < 0 {
+		return nil, fmt.Errorf("rarity %s: invalid weight, affixes, power or value percent", rd.Rarity)
+	}
+
+	tier := &RarityTier{
+		Rarity:       rarity,
+		Weight:       rd.Weight,
+		Affixes:      rd.Affixes,
+		Power:        rd.Power,
+		ValuePercent: rd.ValuePercent,
+	}
+	if tier.Power == 0 {
+		tier.Power = 100
+	}
+	if tier.ValuePercent == 0 {
+		tier.ValuePercent = 100
+	}
+	return tier, nil
+}
+
+// toAffix converts a data file affix into an affix
+func (ad *affixData) toAffix() (*Affix, error) {
+	if ad.ID == "" || ad.Name == "" {
+		return nil, fmt.Errorf("affix without id or name")
+	}
+	if ad.Type != "prefix" && ad.Type != "suffix" {
+		return nil, fmt.Errorf("affix %s: type must be prefix or suffix", ad.ID)
+	}
+	if len(ad.Stats) == 0 {
+		return nil, fmt.Errorf("affix %s: no stats", ad.ID)
+	}
+
+	affix := &Affix{
+		ID:     ad.ID,
+		Name:   ad.Name,
+		Prefix: ad.Type == "prefix",
+		Stats:  make([]StatRange, 0, len(ad.Stats)),
+		Slots:  make([]components.EquipmentSlot, 0, len(ad.Slots)),
+	}
+	ranges := make(map[components.StatType][2]int, len(ad.Stats))
+	for name, bounds := range ad.Stats {
+		stat, err := components.ParseStatType(name)
+		if err != nil {
+			return nil, fmt.Errorf("affix %s: %v", ad.ID, err)
+		}
+		if bounds[0] > bounds[1] {
+			return nil, fmt.Errorf("affix %s: %s range is reversed", ad.ID, name)
+		}
+		ranges[stat] = bounds
+	}
+	// Keep the stat order stable so equal seeds roll equal items
+	for stat := components.StatMaxHP; stat <= components.StatAP; stat++ {
+		if bounds, ok := ranges[stat]; ok {
+			affix.Stats = append(affix.Stats, StatRange{Stat: stat, Min: bounds[0], Max: bounds[1]})
+		}
+	}
+	for _, name := range ad.Slots {
+		slot, err := components.ParseEquipmentSlot(name)
+		if err != nil {
+			return nil, fmt.Errorf("affix %s: %v", ad.ID, err)
+		}
+		affix.Slots = append(affix.Slots, slot)
+	}
+	return affix, nil
+}
+
+// Global loot generator instance
+var GlobalLootGenerator *Generator
+
+// LoadLootGenerator loads the global loot generator from an affix data file
+func LoadLootGenerator(path string) error {
+	generator := NewGenerator()
+	if err := generator.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalLootGenerator = generator
+	return nil
+}
+
+// InitializeLootGenerator loads the global loot generator from the default affix data file,
+// leaving it without affixes if the file cannot be loaded
+func InitializeLootGenerator() {
+	if err := LoadLootGenerator(constants.AffixDataFile); err != nil {
+		logger.Warn("Failed to load affix data: %v", err)
+		GlobalLootGenerator = NewGenerator()
+	}
+}
+
+// GetGlobalLootGenerator returns the global loot generator, loading the default affix data
+// file if needed
+func GetGlobalLootGenerator() *Generator {
+	if GlobalLootGenerator == nil {
+		InitializeLootGenerator()
+	}
+	return GlobalLootGenerator
+}
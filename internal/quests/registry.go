@@ -272,6 +272,34 @@ func (qr *QuestRegistry) initializeDefaultQuests() {
 		},
 	}
 
+	// Side Quest - Growing Stronger
+	growingStronger := &components.Quest{
+		ID:          "side_growing_stronger",
+		Title:       "Growing Stronger",
+		Description: "Gain experience in battle until you are ready for greater threats.",
+		Type:        components.QuestTypeSide,
+		State:       components.QuestStateInactive,
+		GiverNPCID:  "village_elder",
+		Level:       1,
+		Repeatable:  false,
+		Objectives: []*components.QuestObjective{
+			{
+				ID:          "reach_level_5",
+				Description: "Reach level 5",
+				Type:        components.ObjectiveLevel,
+				Target:      "", // Any job
+				Required:    5,
+				Current:     0,
+				Completed:   false,
+				Optional:    false,
+			},
+		},
+		Reward: &components.QuestReward{
+			Gold:        50,
+			SkillPoints: 2,
+		},
+	}
+
 	// Daily Quest - Training Grounds
 	trainingGrounds := &components.Quest{
 		ID:          "daily_training",
@@ -305,6 +333,7 @@ func (qr *QuestRegistry) initializeDefaultQuests() {
 	qr.RegisterQuest(threatEmerges)
 	qr.RegisterQuest(gatherResources)
 	qr.RegisterQuest(equipmentMastery)
+	qr.RegisterQuest(growingStronger)
 	qr.RegisterQuest(trainingGrounds)
 }
 
@@ -12,21 +12,29 @@ import (
 
 // EventState represents the persistent state of an event that needs to be saved
 type EventState struct {
-	ID            string                `json:"id"`             // Event ID
-	State         components.EventState `json:"state"`          // Current event state (Active, Triggered, Completed, Disabled)
-	TriggerCount  int                   `json:"trigger_count"`  // Number of times triggered
-	MaxTriggers   int                   `json:"max_triggers"`   // Maximum trigger limit
-	LastTriggered *time.Time            `json:"last_triggered"` // Last trigger timestamp (pointer for nil handling)
-	Cooldown      time.Duration         `json:"cooldown"`       // Cooldown duration
-	Prerequisites []string              `json:"prerequisites"`  // Required prerequisite events
-	CanRepeat     bool                  `json:"can_repeat"`     // Whether event can repeat
-	ActiveInMode  components.GameMode   `json:"active_in_mode"` // Which game mode event is active in
+	ID            string                `json:"id"`              // Event ID
+	State         components.EventState `json:"state"`           // Current event state (Active, Triggered, Completed, Disabled)
+	TriggerCount  int                   `json:"trigger_count"`   // Number of times triggered
+	MaxTriggers   int                   `json:"max_triggers"`    // Maximum trigger limit
+	LastTriggered *time.Time            `json:"last_triggered"`  // Last trigger timestamp (pointer for nil handling)
+	Cooldown      time.Duration         `json:"cooldown"`        // Cooldown duration
+	Prerequisites []string              `json:"prerequisites"`   // Required prerequisite events
+	CanRepeat     bool                  `json:"can_repeat"`      // Whether event can repeat
+	ActiveInMode  components.GameMode   `json:"active_in_mode"`  // Which game mode event is active in
+	Chest         *ChestState           `json:"chest,omitempty"` // Contents and lock of a chest event
 
 	// Additional metadata for debugging and analytics
 	FirstTriggered *time.Time `json:"first_triggered,omitempty"` // When first triggered
 	SavedAt        time.Time  `json:"saved_at"`                  // When this state was saved
 }
 
+// ChestState is what a chest still holds after being partly looted
+type ChestState struct {
+	Items    []string `json:"items"`     // Item references left in the chest
+	Gold     int      `json:"gold"`      // Gold left in the chest
+	IsLocked bool     `json:"is_locked"` // Whether the chest is still locked
+}
+
 // EventStateSaveData contains all event states and completion tracking
 type EventStateSaveData struct {
 	Version         int                    `json:"version"`          // Save format version for compatibility
@@ -53,6 +61,16 @@ func NewEventState(eventComp *components.EventComponent) *EventState {
 	// Copy prerequisites slice
 	copy(es.Prerequisites, eventComp.Prerequisites)
 
+	// Chests keep what is left in them and whether they are still locked
+	if eventComp.EventType == components.EventChest {
+		es.Chest = &ChestState{
+			Items:    make([]string, len(eventComp.EventData.Items)),
+			Gold:     eventComp.EventData.Gold,
+			IsLocked: eventComp.EventData.IsLocked,
+		}
+		copy(es.Chest.Items, eventComp.EventData.Items)
+	}
+
 	// Handle LastTriggered (use pointer to handle zero time)
 	if !eventComp.LastTriggered.IsZero() {
 		es.LastTriggered = &eventComp.LastTriggered
@@ -86,6 +104,14 @@ func (es *EventState) ApplyToEventComponent(eventComp *components.EventComponent
 	} else {
 		eventComp.LastTriggered = time.Time{} // Zero time
 	}
+
+	// Restore chest contents (saves without them keep the chest as defined)
+	if es.Chest != nil && eventComp.EventType == components.EventChest {
+		eventComp.EventData.Items = make([]string, len(es.Chest.Items))
+		copy(eventComp.EventData.Items, es.Chest.Items)
+		eventComp.EventData.Gold = es.Chest.Gold
+		eventComp.EventData.IsLocked = es.Chest.IsLocked
+	}
 }
 
 // NewEventStateSaveData creates a new save data structure
@@ -0,0 +1,144 @@
+package save
+
+// Package save provides game state persistence functionality.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// ItemInstanceState holds the generated or upgraded data of an equipment instance
+type ItemInstanceState struct {
+	Name         string                     `json:"name"`                    // Instance name, with affixes and upgrade level
+	Rarity       components.EquipmentRarity `json:"rarity"`                  // Rolled rarity
+	Value        int                        `json:"value"`                   // Gold value
+	Stats        components.EquipmentStats  `json:"stats"`                   // Stat bonuses, including affixes and upgrades
+	UpgradeLevel int                        `json:"upgrade_level,omitempty"` // Crafting upgrade level
+	Affixes      []components.ItemAffix     `json:"affixes,omitempty"`       // Rolled prefixes and suffixes
+}
+
+// ItemState represents an inventory slot or an equipped item
+type ItemState struct {
+	ItemID   int                `json:"item_id"`            // Registry item ID
+	Quantity int                `json:"quantity"`           // Quantity in the slot
+	Slot     int                `json:"slot"`               // Inventory slot index or equipment slot
+	Instance *ItemInstanceState `json:"instance,omitempty"` // Instance data, nil for registry copies
+}
+
+// NewItemState creates a new ItemState from an item
+func NewItemState(item *components.Item, quantity, slot int) ItemState {
+	state := ItemState{
+		ItemID:   item.ID,
+		Quantity: quantity,
+		Slot:     slot,
+	}
+	if item.IsInstance() && item.Equipment != nil {
+		equipment := item.Equipment
+		state.Instance = &ItemInstanceState{
+			Name:         equipment.Name,
+			Rarity:       equipment.Rarity,
+			Value:        equipment.Value,
+			Stats:        equipment.Stats,
+			UpgradeLevel: equipment.UpgradeLevel,
+			Affixes:      append([]components.ItemAffix(nil), equipment.Affixes...),
+		}
+	}
+	return state
+}
+
+// CreateItem creates the saved item from the item registry, restoring its instance data
+// with a new instance identity
+func (is *ItemState) CreateItem(registry *components.ItemRegistry) (*components.Item, error) {
+	item := registry.CreateItem(is.ItemID)
+	if item == nil {
+		return nil, fmt.Errorf("item %d not found in the item registry", is.ItemID)
+	}
+	if is.Instance == nil || item.Equipment == nil {
+		return item, nil
+	}
+
+	equipment := item.Equipment
+	equipment.Name = is.Instance.Name
+	equipment.Rarity = is.Instance.Rarity
+	equipment.Value = is.Instance.Value
+	equipment.Stats = is.Instance.Stats
+	equipment.UpgradeLevel = is.Instance.UpgradeLevel
+	equipment.Affixes = append([]components.ItemAffix(nil), is.Instance.Affixes...)
+	item.Name = equipment.Name
+	item.Rarity = components.ItemRarity(equipment.Rarity)
+	item.Value = equipment.Value
+	item.MakeInstance()
+	return item, nil
+}
+
+// CaptureInventory returns the states of the occupied slots of an inventory
+func CaptureInventory(inventory *components.InventoryComponent) []ItemState {
+	states := make([]ItemState, 0)
+	for i := range inventory.Slots {
+		slot := &inventory.Slots[i]
+		if !slot.IsEmpty() {
+			states = append(states, NewItemState(slot.Item, slot.Quantity, i))
+		}
+	}
+	return states
+}
+
+// CaptureEquipment returns the states of the equipped items
+func CaptureEquipment(equipment *components.EquipmentComponent) []ItemState {
+	states := make([]ItemState, 0)
+	for slot := components.SlotHead; slot <= components.SlotAccessory2; slot++ {
+		if equipped := equipment.GetEquipped(slot); equipped != nil {
+			states = append(states, NewItemState(components.NewEquipmentItem(equipped), 1, int(slot)))
+		}
+	}
+	return states
+}
+
+// RestoreInventory replaces the content of an inventory with saved slots. Items missing
+// from the registry are skipped.
+func RestoreInventory(inventory *components.InventoryComponent, states []ItemState, registry *components.ItemRegistry) error {
+	for i := range inventory.Slots {
+		inventory.Slots[i] = components.InventorySlot{}
+	}
+
+	var errs []error
+	for i := range states {
+		state := &states[i]
+		item, err := state.CreateItem(registry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if slot := inventory.GetSlotByIndex(state.Slot); slot != nil && slot.IsEmpty() {
+			slot.Item = item
+			slot.Quantity = state.Quantity
+		} else if inventory.AddItem(item, state.Quantity) > 0 {
+			errs = append(errs, fmt.Errorf("no room for %s", item.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RestoreEquipment replaces the equipped items with saved ones. Items missing from the
+// registry are skipped.
+func RestoreEquipment(equipment *components.EquipmentComponent, states []ItemState, registry *components.ItemRegistry) error {
+	equipment.Equipment = make(map[components.EquipmentSlot]*components.Equipment)
+
+	var errs []error
+	for i := range states {
+		state := &states[i]
+		item, err := state.CreateItem(registry)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if item.Equipment == nil {
+			errs = append(errs, fmt.Errorf("%s is not equipment", item.Name))
+			continue
+		}
+		equipment.Equip(item.Equipment)
+	}
+	return errors.Join(errs...)
+}
@@ -191,6 +191,62 @@ func (sm *SaveManager) ApplyEventState(world *ecs.World, eventManager *events.Ev
 	return nil
 }
 
+// SavePartyState saves the party progress to the latest party save file
+func (sm *SaveManager) SavePartyState(partyData *PartySaveData) error {
+	if partyData == nil {
+		return fmt.Errorf("party data is nil")
+	}
+
+	partyData.SavedAt = time.Now()
+	data, err := partyData.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to serialize party data: %v", err)
+	}
+
+	latestPath := filepath.Join(sm.saveDirectory, "party_latest.json")
+	if err := os.WriteFile(latestPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write save file %s: %v", latestPath, err)
+	}
+
+	logger.Info("Party state saved successfully: %d members, %d gold to %s",
+		len(partyData.Members), partyData.Gold, latestPath)
+	return nil
+}
+
+// LoadPartyState loads the party progress from the latest party save file
+func (sm *SaveManager) LoadPartyState() (*PartySaveData, error) {
+	latestPath := filepath.Join(sm.saveDirectory, "party_latest.json")
+
+	// Unlike events, there is no sensible default party to fall back to
+	if _, err := os.Stat(latestPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no party save file found at %s", latestPath)
+	}
+
+	data, err := os.ReadFile(latestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save file %s: %v", latestPath, err)
+	}
+
+	partyData := NewPartySaveData()
+	if err := partyData.FromJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to parse save file %s: %v", latestPath, err)
+	}
+
+	if err := partyData.Validate(); err != nil {
+		return nil, fmt.Errorf("save file validation failed: %v", err)
+	}
+
+	logger.Info("Party state loaded successfully: %d members, %d gold from %s",
+		len(partyData.Members), partyData.Gold, latestPath)
+	return partyData, nil
+}
+
+// HasPartySave returns true if a party save file exists
+func (sm *SaveManager) HasPartySave() bool {
+	_, err := os.Stat(filepath.Join(sm.saveDirectory, "party_latest.json"))
+	return err == nil
+}
+
 // ListSaveFiles returns a list of available save files
 func (sm *SaveManager) ListSaveFiles() ([]string, error) {
 	entries, err := os.ReadDir(sm.saveDirectory)
@@ -0,0 +1,172 @@
+package save
+
+// Package save provides game state persistence functionality.
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// PartyMemberState represents the persistent state of a party member
+type PartyMemberState struct {
+	Name         string                                        `json:"name"`                 // Character display name (used to match entities)
+	Job          components.JobType                            `json:"job,omitempty"`        // Current job
+	JobLevels    map[components.JobType]components.JobProgress `json:"job_levels,omitempty"` // Progress in the other trained jobs
+	Level        int                                           `json:"level"`                // Character level
+	Experience   int                                           `json:"experience"`           // Current experience points
+	ExpToNext    int                                           `json:"exp_to_next"`          // Experience needed for next level
+	CurrentHP    int                                           `json:"current_hp"`           // Current health points
+	MaxHP        int                                           `json:"max_hp"`               // Maximum health points
+	CurrentMP    int                                           `json:"current_mp"`           // Current mana points
+	MaxMP        int                                           `json:"max_mp"`               // Maximum mana points
+	Attack       int                                           `json:"attack"`               // Physical attack power
+	Defense      int                                           `json:"defense"`              // Physical defense
+	MagicAttack  int                                           `json:"magic_attack"`         // Magic attack power
+	MagicDefense int                                           `json:"magic_defense"`        // Magic defense
+	Speed        int                                           `json:"speed"`                // Speed/agility stat
+	BackRow      bool                                          `json:"back_row"`             // Classic battle formation row preference
+	X            float64                                       `json:"x"`                    // Exploration X position
+	Y            float64                                       `json:"y"`                    // Exploration Y position
+	Inventory    []ItemState                                   `json:"inventory"`            // Inventory slots (nil in older saves)
+	Equipped     []ItemState                                   `json:"equipped"`             // Equipped items (nil in older saves)
+}
+
+// PartySaveData contains the party progress and the last rest point
+type PartySaveData struct {
+	Version      int                `json:"version"`              // Save format version for compatibility
+	SavedAt      time.Time          `json:"saved_at"`             // When the save was created
+	Gold         int                `json:"gold"`                 // Party gold
+	Currencies   map[string]int     `json:"currencies,omitempty"` // Party balances of the other currencies
+	Members      []PartyMemberState `json:"members"`              // Party member states in party order
+	HasRestPoint bool               `json:"has_rest_point"`       // Whether a rest point has been visited
+	RestPointX   float64            `json:"rest_point_x"`         // Last rest point X position
+	RestPointY   float64            `json:"rest_point_y"`         // Last rest point Y position
+}
+
+// NewPartyMemberState creates a new PartyMemberState from a character's stats and position
+func NewPartyMemberState(stats *components.RPGStatsComponent, transform *components.Transform) PartyMemberState {
+	state := PartyMemberState{
+		Name:         stats.Name,
+		Job:          stats.Job,
+		Level:        stats.Level,
+		Experience:   stats.Experience,
+		ExpToNext:    stats.ExpToNext,
+		CurrentHP:    stats.CurrentHP,
+		MaxHP:        stats.MaxHP,
+		CurrentMP:    stats.CurrentMP,
+		MaxMP:        stats.MaxMP,
+		Attack:       stats.Attack,
+		Defense:      stats.Defense,
+		MagicAttack:  stats.MagicAttack,
+		MagicDefense: stats.MagicDefense,
+		Speed:        stats.Speed,
+		BackRow:      stats.BackRow,
+	}
+
+	if len(stats.JobLevels) > 0 {
+		state.JobLevels = make(map[components.JobType]components.JobProgress, len(stats.JobLevels))
+		for job, progress := range stats.JobLevels {
+			state.JobLevels[job] = progress
+		}
+	}
+
+	if transform != nil {
+		state.X = transform.X
+		state.Y = transform.Y
+	}
+
+	return state
+}
+
+// ApplyTo applies saved state back to a character's stats and position
+func (pms *PartyMemberState) ApplyTo(stats *components.RPGStatsComponent, transform *components.Transform) {
+	// Older saves have no job, keep the character's creation job
+	if pms.Job != "" {
+		stats.Job = pms.Job
+		stats.JobLevels = make(map[components.JobType]components.JobProgress, len(pms.JobLevels))
+		for job, progress := range pms.JobLevels {
+			stats.JobLevels[job] = progress
+		}
+		if def := pms.Job.Definition(); def != nil {
+			stats.MoveRange = def.MoveRange
+		}
+	}
+	stats.Level = pms.Level
+	stats.Experience = pms.Experience
+	stats.ExpToNext = pms.ExpToNext
+	stats.CurrentHP = pms.CurrentHP
+	stats.MaxHP = pms.MaxHP
+	stats.CurrentMP = pms.CurrentMP
+	stats.MaxMP = pms.MaxMP
+	stats.Attack = pms.Attack
+	stats.Defense = pms.Defense
+	stats.MagicAttack = pms.MagicAttack
+	stats.MagicDefense = pms.MagicDefense
+	stats.Speed = pms.Speed
+	stats.BackRow = pms.BackRow
+	stats.ResetMovement()
+
+	if transform != nil {
+		transform.X = pms.X
+		transform.Y = pms.Y
+	}
+}
+
+// NewPartySaveData creates a new party save data structure
+func NewPartySaveData() *PartySaveData {
+	return &PartySaveData{
+		Version: 1, // Current save format version
+		SavedAt: time.Now(),
+		Members: make([]PartyMemberState, 0),
+	}
+}
+
+// ToJSON serializes the party save data to JSON
+func (psd *PartySaveData) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(psd, "", "  ")
+}
+
+// FromJSON deserializes party save data from JSON
+func (psd *PartySaveData) FromJSON(data []byte) error {
+	return json.Unmarshal(data, psd)
+}
+
+// GetMemberState retrieves a party member state by character name
+func (psd *PartySaveData) GetMemberState(name string) (*PartyMemberState, bool) {
+	for i := range psd.Members {
+		if psd.Members[i].Name == name {
+			return &psd.Members[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate checks the integrity of the party save data
+func (psd *PartySaveData) Validate() error {
+	if psd.Version <= 0 {
+		return fmt.Errorf("invalid save version: %d", psd.Version)
+	}
+
+	if psd.Gold < 0 {
+		return fmt.Errorf("invalid party gold: %d", psd.Gold)
+	}
+	for currency, balance := range psd.Currencies {
+		if balance < 0 {
+			return fmt.Errorf("invalid party %s: %d", currency, balance)
+		}
+	}
+
+	for _, member := range psd.Members {
+		if member.Name == "" {
+			return fmt.Errorf("party member without a name")
+		}
+		if member.MaxHP <= 0 {
+			return fmt.Errorf("party member %s has invalid max HP %d", member.Name, member.MaxHP)
+		}
+	}
+
+	return nil
+}
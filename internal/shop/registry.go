@@ -0,0 +1,182 @@
+// Package shop provides the shop registry loaded from the shop data file
+package shop
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// ShopRegistry holds every shop, keyed by shop ID
+type ShopRegistry struct {
+	shops map[string]*Shop
+}
+
+// NewShopRegistry creates an empty shop registry
+func NewShopRegistry() *ShopRegistry {
+	return &ShopRegistry{
+		shops: make(map[string]*Shop),
+	}
+}
+
+// RegisterShop adds a shop to the registry
+func (sr *ShopRegistry) RegisterShop(shop *Shop) error {
+	if _, exists := sr.shops[shop.ID]; exists {
+		return fmt.Errorf("shop %s already exists", shop.ID)
+	}
+	sr.shops[shop.ID] = shop
+	return nil
+}
+
+// GetShop returns a shop by ID
+func (sr *ShopRegistry) GetShop(id string) (*Shop, bool) {
+	shop, exists := sr.shops[id]
+	return shop, exists
+}
+
+// GetAllShops returns every shop sorted by ID
+func (sr *ShopRegistry) GetAllShops() []*Shop {
+	shops := make([]*Shop, 0, len(sr.shops))
+	for _, shop := range sr.shops {
+		shops = append(shops, shop)
+	}
+	sort.Slice(shops, func(i, j int) bool {
+		return shops[i].ID < shops[j].ID
+	})
+	return shops
+}
+
+// stockData is the layout of a stock entry in the shop data file
+type stockData struct {
+	ItemID   int `json:"item_id"`  // Item registry ID
+	Quantity int `json:"quantity"` // Units after a full restock (-1 = unlimited)
+	Restock  int `json:"restock"`  // Units regained per restock period (0 = full restock)
+}
+
+// shopData is the layout of a shop in the shop data file
+type shopData struct {
+	ID             string      `json:"id"`              // Shop ID
+	Name           string      `json:"name"`            // Display name
+	PricePercent   int         `json:"price_percent"`   // Buy price percentage of the item value (default: constants.ShopPricePercent)
+	SellPercent    int         `json:"sell_percent"`    // Sell-back percentage of the item value (default: constants.ShopSellPercent)
+	RestockSeconds int         `json:"restock_seconds"` // Seconds between restocks (0 = never)
+	Stock          []stockData `json:"stock"`           // Items for sale
+}
+
+// shopDataFile is the layout of the shop data file
+type shopDataFile struct {
+	Shops []shopData `json:"shops"`
+}
+
+// LoadFile registers every shop of a shop data file. Items are looked up in the global
+// item registry, which must be initialized first.
+func (sr *ShopRegistry) LoadFile(path string) error {
+	if components.GlobalItemRegistry == nil {
+		return fmt.Errorf("item registry not initialized")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read shop data: %v", err)
+	}
+
+	var file shopDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse shop data: %v", err)
+	}
+
+	for _, entry := range file.Shops {
+		shop, err := entry.toShop()
+		if err != nil {
+			return err
+		}
+		if err := sr.RegisterShop(shop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toShop converts a data file shop into a shop with full stock
+func (sd *shopData) toShop() (*Shop, error) {
+	if sd.ID == "" {
+		return nil, fmt.Errorf("shop without id")
+	}
+	if sd.PricePercent < 0 || sd.SellPercent < 0 || sd.RestockSeconds < 0 {
+		return nil, fmt.Errorf("shop %s: negative price or restock time", sd.ID)
+	}
+
+	shop := &Shop{
+		ID:            sd.ID,
+		Name:          sd.Name,
+		PricePercent:  sd.PricePercent,
+		SellPercent:   sd.SellPercent,
+		RestockPeriod: time.Duration(sd.RestockSeconds) * time.Second,
+		Stock:         make([]*StockEntry, 0, len(sd.Stock)),
+	}
+	if shop.Name == "" {
+		shop.Name = sd.ID
+	}
+	if shop.PricePercent == 0 {
+		shop.PricePercent = constants.ShopPricePercent
+	}
+	if shop.SellPercent == 0 {
+		shop.SellPercent = constants.ShopSellPercent
+	}
+
+	for _, stock := range sd.Stock {
+		item := components.GlobalItemRegistry.GetItem(stock.ItemID)
+		if item == nil {
+			return nil, fmt.Errorf("shop %s: unknown item %d", sd.ID, stock.ItemID)
+		}
+		if stock.Quantity == 0 || stock.Quantity < -1 {
+			return nil, fmt.Errorf("shop %s: item %d needs a positive quantity or -1", sd.ID, stock.ItemID)
+		}
+		if stock.Restock < 0 {
+			return nil, fmt.Errorf("shop %s: item %d has a negative restock", sd.ID, stock.ItemID)
+		}
+		shop.Stock = append(shop.Stock, &StockEntry{
+			Item:        item,
+			Quantity:    stock.Quantity,
+			MaxQuantity: stock.Quantity,
+			Restock:     stock.Restock,
+		})
+	}
+	return shop, nil
+}
+
+// Global shop registry instance
+var GlobalShopRegistry *ShopRegistry
+
+// LoadShopRegistry loads the global shop registry from a shop data file
+func LoadShopRegistry(path string) error {
+	registry := NewShopRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalShopRegistry = registry
+	return nil
+}
+
+// InitializeShopRegistry loads the global shop registry from the default shop data file,
+// leaving it empty if the file cannot be loaded
+func InitializeShopRegistry() {
+	if err := LoadShopRegistry(constants.ShopDataFile); err != nil {
+		logger.Warn("Failed to load shop data: %v", err)
+		GlobalShopRegistry = NewShopRegistry()
+	}
+}
+
+// GetGlobalShopRegistry returns the global shop registry, loading the default shop data file if needed
+func GetGlobalShopRegistry() *ShopRegistry {
+	if GlobalShopRegistry == nil {
+		InitializeShopRegistry()
+	}
+	return GlobalShopRegistry
+}
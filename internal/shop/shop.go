@@ -0,0 +1,162 @@
+// Package shop provides merchants with limited, restocking stock and the gold prices
+// at which the party buys and sells items
+package shop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// Wallet holds the gold a customer pays with and receives
+type Wallet interface {
+	GetGold() int
+	AddGold(amount int, reason string)
+	SpendGold(amount int, reason string) error
+}
+
+// StockEntry is an item a shop sells and how many are left
+type StockEntry struct {
+	Item        *components.Item // Item template, copies are given to buyers
+	Quantity    int              // Units left (-1 = unlimited)
+	MaxQuantity int              // Units after a full restock (-1 = unlimited)
+	Restock     int              // Units regained per restock period (0 = back to MaxQuantity)
+}
+
+// IsUnlimited returns true if the entry never runs out
+func (se *StockEntry) IsUnlimited() bool {
+	return se.MaxQuantity < 0
+}
+
+// IsSoldOut returns true if no units are left
+func (se *StockEntry) IsSoldOut() bool {
+	return !se.IsUnlimited() && se.Quantity <= 0
+}
+
+// restock adds the units regained in one restock period
+func (se *StockEntry) restock() {
+	if se.IsUnlimited() {
+		return
+	}
+	if se.Restock <= 0 {
+		se.Quantity = se.MaxQuantity
+		return
+	}
+	se.Quantity += se.Restock
+	if se.Quantity > se.MaxQuantity {
+		se.Quantity = se.MaxQuantity
+	}
+}
+
+// Shop is a merchant with its stock and prices
+type Shop struct {
+	ID            string        // Shop ID, referenced by EventData.ShopID and dialog actions
+	Name          string        // Display name
+	PricePercent  int           // Buy price as a percentage of Item.Value
+	SellPercent   int           // Sell-back price as a percentage of Item.Value
+	RestockPeriod time.Duration // Time between restocks (0 = never restocks)
+	Stock         []*StockEntry // Items for sale, in display order
+
+	lastRestock time.Time // When stock was last restocked, zero until the shop is first opened
+}
+
+// Update restocks once per restock period elapsed since the last restock. The first call
+// starts the restock timer.
+func (s *Shop) Update(now time.Time) {
+	if s.lastRestock.IsZero() || s.RestockPeriod <= 0 {
+		s.lastRestock = now
+		return
+	}
+	for now.Sub(s.lastRestock) >= s.RestockPeriod {
+		for _, entry := range s.Stock {
+			entry.restock()
+		}
+		s.lastRestock = s.lastRestock.Add(s.RestockPeriod)
+	}
+}
+
+// TimeToRestock returns the time left before the next restock, 0 if the shop never restocks
+func (s *Shop) TimeToRestock(now time.Time) time.Duration {
+	if s.RestockPeriod <= 0 || s.lastRestock.IsZero() {
+		return 0
+	}
+	left := s.RestockPeriod - now.Sub(s.lastRestock)
+	if left < 0 {
+		left = 0
+	}
+	return left
+}
+
+// BuyPrice returns the gold the shop asks for one unit of an item
+func (s *Shop) BuyPrice(item *components.Item) int {
+	price := item.Value * s.PricePercent / 100
+	if price < 1 {
+		price = 1
+	}
+	return price
+}
+
+// SellPrice returns the gold the shop pays for one unit of an item
+func (s *Shop) SellPrice(item *components.Item) int {
+	return item.Value * s.SellPercent / 100
+}
+
+// CanSell returns an error if the shop does not buy an item
+func (s *Shop) CanSell(item *components.Item) error {
+	if item.QuestItem {
+		return fmt.Errorf("%s is a quest item and cannot be sold", item.Name)
+	}
+	if s.SellPrice(item) <= 0 {
+		return fmt.Errorf("%s is worthless", item.Name)
+	}
+	return nil
+}
+
+// Buy sells units of a stock entry to the customer: the gold is taken from the wallet and
+// the items are added to the inventory
+func (s *Shop) Buy(entry *StockEntry, quantity int, wallet Wallet, inventory *components.InventoryComponent) error {
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity: %d", quantity)
+	}
+	if !entry.IsUnlimited() && entry.Quantity < quantity {
+		return fmt.Errorf("only %d %s left", entry.Quantity, entry.Item.Name)
+	}
+	cost := s.BuyPrice(entry.Item) * quantity
+	if wallet.GetGold() < cost {
+		return fmt.Errorf("not enough gold (%d needed)", cost)
+	}
+
+	// Items that do not fit are taken back so the purchase never happens partially
+	if overflow := inventory.AddItem(components.GlobalItemRegistry.CreateItem(entry.Item.ID), quantity); overflow > 0 {
+		inventory.RemoveItem(entry.Item.ID, quantity-overflow)
+		return fmt.Errorf("inventory is full")
+	}
+
+	if err := wallet.SpendGold(cost, fmt.Sprintf("Bought %dx %s at %s", quantity, entry.Item.Name, s.Name)); err != nil {
+		inventory.RemoveItem(entry.Item.ID, quantity)
+		return err
+	}
+	if !entry.IsUnlimited() {
+		entry.Quantity -= quantity
+	}
+	return nil
+}
+
+// Sell buys units of an item from the customer: the items are removed from the inventory
+// and their sell price is added to the wallet
+func (s *Shop) Sell(item *components.Item, quantity int, wallet Wallet, inventory *components.InventoryComponent) error {
+	if quantity <= 0 {
+		return fmt.Errorf("invalid quantity: %d", quantity)
+	}
+	if err := s.CanSell(item); err != nil {
+		return err
+	}
+	if inventory.GetInstanceCount(item) < quantity {
+		return fmt.Errorf("not enough %s to sell", item.Name)
+	}
+
+	removed := inventory.RemoveInstance(item, quantity)
+	wallet.AddGold(s.SellPrice(item)*removed, fmt.Sprintf("Sold %dx %s at %s", removed, item.Name, s.Name))
+	return nil
+}
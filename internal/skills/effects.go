@@ -0,0 +1,175 @@
+// Package skills provides the skill effect handlers that apply and remove the effects of
+// learned skills, keyed by SkillEffect.Type
+package skills
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// EffectFunc applies or removes one effect of a skill on a character
+type EffectFunc func(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error
+
+// EffectHandler applies and removes one type of skill effect
+type EffectHandler struct {
+	Apply    EffectFunc                                // Called when the skill is learned
+	Remove   EffectFunc                                // Called when the skill is forgotten
+	Validate func(effect components.SkillEffect) error // Checks data file effects (nil = any effect is valid)
+}
+
+// effectHandlers holds the handler of every known skill effect type
+var effectHandlers = CreateDefaultEffectHandlers()
+
+// CreateDefaultEffectHandlers creates and returns the handlers of the built-in skill effect types
+func CreateDefaultEffectHandlers() map[string]EffectHandler {
+	handlers := make(map[string]EffectHandler)
+
+	statHandler := EffectHandler{Apply: refreshStats, Remove: refreshStats, Validate: validateStatTarget}
+	handlers["stat_bonus"] = statHandler
+	handlers["stat_percent"] = statHandler
+	handlers["ability_unlock"] = EffectHandler{Apply: applyAbilityUnlock, Remove: removeAbilityUnlock}
+	handlers["passive_effect"] = EffectHandler{Apply: applyPassiveEffect, Remove: removePassiveEffect, Validate: validatePassiveTarget}
+
+	return handlers
+}
+
+// RegisterEffectHandler adds or replaces the handler of a skill effect type
+func RegisterEffectHandler(effectType string, handler EffectHandler) {
+	effectHandlers[effectType] = handler
+}
+
+// ValidateEffect checks that an effect type has a handler and that the handler accepts the effect
+func ValidateEffect(effect components.SkillEffect) error {
+	handler, exists := effectHandlers[effect.Type]
+	if !exists {
+		return fmt.Errorf("unknown skill effect type: %s", effect.Type)
+	}
+	if handler.Validate != nil {
+		return handler.Validate(effect)
+	}
+	return nil
+}
+
+// LearnSkill spends the skill points of a skill, learns it and applies its effects
+func LearnSkill(entity *ecs.Entity, skill *components.Skill) error {
+	skillsComp := entity.Skills()
+	if skillsComp == nil {
+		return fmt.Errorf("%s has no skills", entity.GetID())
+	}
+	if !skillsComp.LearnSkill(skill) {
+		return fmt.Errorf("cannot learn skill %s", skill.ID)
+	}
+	return ApplySkillEffects(entity, skill)
+}
+
+// ForgetSkill unlearns a leaf skill, refunds its skill points and removes its effects
+func ForgetSkill(entity *ecs.Entity, skillID string) error {
+	skillsComp := entity.Skills()
+	if skillsComp == nil {
+		return fmt.Errorf("%s has no skills", entity.GetID())
+	}
+	skill, err := skillsComp.ForgetSkill(skillID)
+	if err != nil {
+		return err
+	}
+	return RemoveSkillEffects(entity, skill)
+}
+
+// ResetSkills unlearns every skill of a character, refunds all its skill points and removes
+// the skill effects. It returns the skill points refunded.
+func ResetSkills(entity *ecs.Entity) (int, error) {
+	skillsComp := entity.Skills()
+	if skillsComp == nil {
+		return 0, fmt.Errorf("%s has no skills", entity.GetID())
+	}
+	refunded := skillsComp.GetSpentPoints()
+	for _, skill := range skillsComp.ResetSkills() {
+		if err := RemoveSkillEffects(entity, skill); err != nil {
+			return refunded, err
+		}
+	}
+	return refunded, nil
+}
+
+// ApplySkillEffects applies every effect of a learned skill through its effect handler
+func ApplySkillEffects(entity *ecs.Entity, skill *components.Skill) error {
+	for _, effect := range skill.Effects {
+		handler, exists := effectHandlers[effect.Type]
+		if !exists {
+			return fmt.Errorf("skill %s: unknown effect type %s", skill.ID, effect.Type)
+		}
+		if err := handler.Apply(entity, skill, effect); err != nil {
+			return fmt.Errorf("skill %s: %v", skill.ID, err)
+		}
+	}
+	return nil
+}
+
+// RemoveSkillEffects removes every effect of a skill through its effect handler, once the
+// skill is no longer learned
+func RemoveSkillEffects(entity *ecs.Entity, skill *components.Skill) error {
+	for _, effect := range skill.Effects {
+		handler, exists := effectHandlers[effect.Type]
+		if !exists {
+			return fmt.Errorf("skill %s: unknown effect type %s", skill.ID, effect.Type)
+		}
+		if err := handler.Remove(entity, skill, effect); err != nil {
+			return fmt.Errorf("skill %s: %v", skill.ID, err)
+		}
+	}
+	return nil
+}
+
+// refreshStats recomputes the stat modifiers, which the modifier pipeline collects from learned skills
+func refreshStats(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error {
+	entity.RefreshStatModifiers()
+	return nil
+}
+
+// validateStatTarget checks that a stat effect targets a known stat
+func validateStatTarget(effect components.SkillEffect) error {
+	_, err := components.ParseStatType(effect.Target)
+	return err
+}
+
+// applyAbilityUnlock equips a newly learned active ability if a slot is free
+func applyAbilityUnlock(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error {
+	if skillsComp := entity.Skills(); skillsComp != nil {
+		skillsComp.EquipActiveAbility(skill.ID)
+	}
+	return nil
+}
+
+// removeAbilityUnlock unequips a forgotten active ability
+func removeAbilityUnlock(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error {
+	if skillsComp := entity.Skills(); skillsComp != nil {
+		skillsComp.UnequipActiveAbility(skill.ID)
+	}
+	return nil
+}
+
+// applyPassiveEffect adds the effect value to the character's passive effects
+func applyPassiveEffect(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error {
+	if skillsComp := entity.Skills(); skillsComp != nil {
+		skillsComp.AddPassiveEffect(effect.Target, effect.Value)
+	}
+	return nil
+}
+
+// removePassiveEffect takes the effect value back from the character's passive effects
+func removePassiveEffect(entity *ecs.Entity, skill *components.Skill, effect components.SkillEffect) error {
+	if skillsComp := entity.Skills(); skillsComp != nil {
+		skillsComp.AddPassiveEffect(effect.Target, -effect.Value)
+	}
+	return nil
+}
+
+// validatePassiveTarget checks that a passive effect names what it affects
+func validatePassiveTarget(effect components.SkillEffect) error {
+	if effect.Target == "" {
+		return fmt.Errorf("passive effect without target")
+	}
+	return nil
+}
@@ -1,27 +1,30 @@
 package skills
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
 )
 
 // SkillRegistry manages all available skills in the game
 type SkillRegistry struct {
-	skills     map[string]*components.Skill                 // All skills keyed by ID
-	skillTrees map[components.JobType]*components.SkillTree // Skill trees by job class
+	skills     map[string]*components.Skill     // All skills keyed by ID
+	skillTrees map[string]*components.SkillTree // Skill trees by tree ID
 }
 
-// NewSkillRegistry creates a new skill registry
+// NewSkillRegistry creates an empty skill registry
 func NewSkillRegistry() *SkillRegistry {
-	registry := &SkillRegistry{
+	return &SkillRegistry{
 		skills:     make(map[string]*components.Skill),
-		skillTrees: make(map[components.JobType]*components.SkillTree),
+		skillTrees: make(map[string]*components.SkillTree),
 	}
-
-	// Initialize with default skills
-	registry.initializeDefaultSkills()
-	return registry
 }
 
 // RegisterSkill adds a skill to the registry
@@ -55,452 +58,304 @@ func (sr *SkillRegistry) GetSkillsByJob(jobClass components.JobType) []*componen
 	return jobSkills
 }
 
-// GetSkillTree returns the skill tree for a job class
+// GetSkillTree returns the skill tree for a job class, as named by its job definition
 func (sr *SkillRegistry) GetSkillTree(jobClass components.JobType) (*components.SkillTree, bool) {
-	tree, exists := sr.skillTrees[jobClass]
-	return tree, exists
+	treeID := string(jobClass)
+	if def := jobClass.Definition(); def != nil {
+		treeID = def.GetSkillTreeID()
+	}
+	return sr.GetSkillTreeByID(treeID)
 }
 
-// initializeDefaultSkills creates the default skill trees for each job class
-func (sr *SkillRegistry) initializeDefaultSkills() {
-	// Initialize Warrior skills
-	sr.initializeWarriorSkills()
+// GetSkillTreeByID returns a skill tree by its ID
+func (sr *SkillRegistry) GetSkillTreeByID(treeID string) (*components.SkillTree, bool) {
+	tree, exists := sr.skillTrees[treeID]
+	return tree, exists
+}
 
-	// Initialize Mage skills
-	sr.initializeMageSkills()
+// skillEffectData is the layout of a skill effect in the skill data file
+type skillEffectData struct {
+	Type        string                 `json:"type"`        // Effect type, must have an effect handler
+	Target      string                 `json:"target"`      // What is affected (stat, ability or passive name)
+	Value       int                    `json:"value"`       // Numeric value for the effect
+	Description string                 `json:"description"` // Human readable description
+	Data        map[string]interface{} `json:"data"`        // Additional effect data (spell cost, damage, element...)
+}
 
-	// Initialize Rogue skills
-	sr.initializeRogueSkills()
+// abilityData is the layout of the costs and targeting of an active skill in the skill data file
+type abilityData struct {
+	MPCost   int    `json:"mp_cost"`  // MP spent per use (0 = free)
+	APCost   int    `json:"ap_cost"`  // Tactical action points spent per use
+	Cooldown int    `json:"cooldown"` // Turns before the ability can be used again
+	Charges  int    `json:"charges"`  // Uses per battle (0 = unlimited)
+	Range    int    `json:"range"`    // Tactical range in tiles
+	Target   string `json:"target"`   // Target type (default: enemy)
+}
 
-	// Initialize Cleric skills
-	sr.initializeClericSkills()
+// abilityTargets lists the target types an active skill can declare
+var abilityTargets = map[string]bool{
+	"enemy": true, "ally": true, "all_enemies": true, "all_allies": true, "random": true, "row": true,
+}
 
-	// Initialize Archer skills
-	sr.initializeArcherSkills()
+// skillData is the layout of a skill in the skill data file
+type skillData struct {
+	ID            string            `json:"id"`            // Unique skill identifier
+	Name          string            `json:"name"`          // Display name
+	Description   string            `json:"description"`   // Detailed description
+	Type          string            `json:"type"`          // passive, active, trait or upgrade
+	Tier          int               `json:"tier"`          // Skill tier (default: row + 1)
+	X             int               `json:"x"`             // Column in the skill tree layout
+	Y             int               `json:"y"`             // Row in the skill tree layout
+	Prerequisites []string          `json:"prerequisites"` // Required skill IDs
+	SkillPoints   int               `json:"skill_points"`  // Cost in skill points
+	Ability       *abilityData      `json:"ability"`       // Costs and targeting of an active skill
+	Effects       []skillEffectData `json:"effects"`       // What the skill does when learned
+	Icon          string            `json:"icon"`          // Path to skill icon
 }
 
-// initializeWarriorSkills creates the warrior skill tree
-func (sr *SkillRegistry) initializeWarriorSkills() {
-	// Tier 1 Skills
-	toughSkin := &components.Skill{
-		ID:            "warrior_tough_skin",
-		Name:          "Tough Skin",
-		Description:   "Increases maximum HP by 10 points.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobWarrior,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "MaxHP",
-				Value:       10,
-				Description: "+10 Maximum HP",
-			},
-		},
-		IconPath: "assets/icons/skills/tough_skin.png",
-	}
+// skillTreeData is the layout of a skill tree in the skill data file
+type skillTreeData struct {
+	ID     string             `json:"id"`     // Tree ID, referenced by job definitions
+	Job    components.JobType `json:"job"`    // Job the tree belongs to (default: the tree ID)
+	Name   string             `json:"name"`   // Display name
+	Skills []*skillData       `json:"skills"` // Skills of the tree
+}
 
-	powerStrike := &components.Skill{
-		ID:            "warrior_power_strike",
-		Name:          "Power Strike",
-		Description:   "Increases attack damage by 3 points.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobWarrior,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Attack",
-				Value:       3,
-				Description: "+3 Attack Damage",
-			},
-		},
-		IconPath: "assets/icons/skills/power_strike.png",
-	}
+// skillDataFile is the layout of the skill data file
+type skillDataFile struct {
+	Trees []*skillTreeData `json:"trees"`
+}
 
-	// Tier 2 Skills
-	ironWill := &components.Skill{
-		ID:            "warrior_iron_will",
-		Name:          "Iron Will",
-		Description:   "Increases defense and HP regeneration.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobWarrior,
-		Tier:          2,
-		Prerequisites: []string{"warrior_tough_skin"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Defense",
-				Value:       2,
-				Description: "+2 Defense",
-			},
-			{
-				Type:        "passive_effect",
-				Target:      "HP_Regen",
-				Value:       1,
-				Description: "1 HP regenerated per turn",
-			},
-		},
-		IconPath: "assets/icons/skills/iron_will.png",
+// LoadFile registers every skill and skill tree of a JSON skill data file, then checks that
+// prerequisites exist and do not form cycles. Only JSON is supported, like the other data files.
+func (sr *SkillRegistry) LoadFile(path string) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".json" {
+		return fmt.Errorf("unsupported skill data format %q: skill trees must be JSON", ext)
 	}
 
-	whirlwind := &components.Skill{
-		ID:            "warrior_whirlwind",
-		Name:          "Whirlwind Attack",
-		Description:   "Active ability: Attack all adjacent enemies for 1 AP.",
-		Type:          components.SkillTypeActive,
-		JobClass:      components.JobWarrior,
-		Tier:          2,
-		Prerequisites: []string{"warrior_power_strike"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "ability_unlock",
-				Target:      "whirlwind_attack",
-				Value:       1,
-				Description: "Unlocks Whirlwind Attack ability",
-				Data:        map[string]interface{}{"ap_cost": 1, "damage_multiplier": 0.8},
-			},
-		},
-		IconPath: "assets/icons/skills/whirlwind.png",
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read skill data: %v", err)
 	}
 
-	// Register warrior skills
-	sr.RegisterSkill(toughSkin)
-	sr.RegisterSkill(powerStrike)
-	sr.RegisterSkill(ironWill)
-	sr.RegisterSkill(whirlwind)
-
-	// Create warrior skill tree layout
-	warriorTree := &components.SkillTree{
-		JobClass: components.JobWarrior,
-		Name:     "Warrior Combat Arts",
-		Nodes:    make(map[string]*components.SkillNode),
-		Layout:   make([][]*components.SkillNode, 3), // 3 rows for tiers
-		MaxTier:  2,
+	var file skillDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse skill data: %v", err)
 	}
 
-	// Create skill nodes
-	toughSkinNode := &components.SkillNode{Skill: toughSkin, X: 0, Y: 0, Children: []string{"warrior_iron_will"}}
-	powerStrikeNode := &components.SkillNode{Skill: powerStrike, X: 1, Y: 0, Children: []string{"warrior_whirlwind"}}
-	ironWillNode := &components.SkillNode{Skill: ironWill, X: 0, Y: 1, Children: []string{}}
-	whirlwindNode := &components.SkillNode{Skill: whirlwind, X: 1, Y: 1, Children: []string{}}
-
-	// Add nodes to tree
-	warriorTree.Nodes["warrior_tough_skin"] = toughSkinNode
-	warriorTree.Nodes["warrior_power_strike"] = powerStrikeNode
-	warriorTree.Nodes["warrior_iron_will"] = ironWillNode
-	warriorTree.Nodes["warrior_whirlwind"] = whirlwindNode
-
-	// Set up layout grid
-	warriorTree.Layout = [][]*components.SkillNode{
-		{toughSkinNode, powerStrikeNode}, // Tier 1
-		{ironWillNode, whirlwindNode},    // Tier 2
+	for _, treeData := range file.Trees {
+		if err := sr.registerTreeData(treeData); err != nil {
+			return err
+		}
 	}
-
-	sr.skillTrees[components.JobWarrior] = warriorTree
+	return sr.Validate()
 }
 
-// initializeMageSkills creates the mage skill tree
-func (sr *SkillRegistry) initializeMageSkills() {
-	// Tier 1 Skills
-	manaPool := &components.Skill{
-		ID:            "mage_mana_pool",
-		Name:          "Expanded Mana Pool",
-		Description:   "Increases maximum MP by 15 points.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobMage,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "MaxMP",
-				Value:       15,
-				Description: "+15 Maximum MP",
-			},
-		},
-		IconPath: "assets/icons/skills/mana_pool.png",
+// registerTreeData registers the skills of a data file tree and builds its layout
+func (sr *SkillRegistry) registerTreeData(treeData *skillTreeData) error {
+	if treeData.ID == "" {
+		return fmt.Errorf("skill tree without id")
 	}
-
-	spellPower := &components.Skill{
-		ID:            "mage_spell_power",
-		Name:          "Spell Power",
-		Description:   "Increases magical attack damage by 4 points.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobMage,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "MagicAttack",
-				Value:       4,
-				Description: "+4 Magic Attack",
-			},
-		},
-		IconPath: "assets/icons/skills/spell_power.png",
+	if _, exists := sr.skillTrees[treeData.ID]; exists {
+		return fmt.Errorf("skill tree %s already exists", treeData.ID)
 	}
-
-	// Tier 2 Skills
-	fireball := &components.Skill{
-		ID:            "mage_fireball",
-		Name:          "Fireball",
-		Description:   "Active ability: Ranged fire attack for 2 AP.",
-		Type:          components.SkillTypeActive,
-		JobClass:      components.JobMage,
-		Tier:          2,
-		Prerequisites: []string{"mage_spell_power"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "ability_unlock",
-				Target:      "fireball",
-				Value:       1,
-				Description: "Unlocks Fireball spell",
-				Data:        map[string]interface{}{"ap_cost": 2, "range": 3, "damage": 25},
-			},
-		},
-		IconPath: "assets/icons/skills/fireball.png",
+	job := treeData.Job
+	if job == "" {
+		job = components.JobType(treeData.ID)
 	}
 
-	// Register mage skills
-	sr.RegisterSkill(manaPool)
-	sr.RegisterSkill(spellPower)
-	sr.RegisterSkill(fireball)
-
-	// Create mage skill tree
-	mageTree := &components.SkillTree{
-		JobClass: components.JobMage,
-		Name:     "Arcane Arts",
+	tree := &components.SkillTree{
+		JobClass: job,
+		Name:     treeData.Name,
 		Nodes:    make(map[string]*components.SkillNode),
-		MaxTier:  2,
 	}
+	positions := make(map[[2]int]string)
+	order := make([]*components.SkillNode, 0, len(treeData.Skills))
+	for _, entry := range treeData.Skills {
+		skill, err := entry.toSkill(job)
+		if err != nil {
+			return fmt.Errorf("skill tree %s: %v", treeData.ID, err)
+		}
+		if entry.X < 0 || entry.Y < 0 {
+			return fmt.Errorf("skill %s has a negative layout position", skill.ID)
+		}
+		position := [2]int{entry.X, entry.Y}
+		if other, taken := positions[position]; taken {
+			return fmt.Errorf("skills %s and %s share layout position (%d,%d)", other, skill.ID, entry.X, entry.Y)
+		}
+		positions[position] = skill.ID
+		if err := sr.RegisterSkill(skill); err != nil {
+			return err
+		}
 
-	manaPoolNode := &components.SkillNode{Skill: manaPool, X: 0, Y: 0, Children: []string{}}
-	spellPowerNode := &components.SkillNode{Skill: spellPower, X: 1, Y: 0, Children: []string{"mage_fireball"}}
-	fireballNode := &components.SkillNode{Skill: fireball, X: 1, Y: 1, Children: []string{}}
-
-	mageTree.Nodes["mage_mana_pool"] = manaPoolNode
-	mageTree.Nodes["mage_spell_power"] = spellPowerNode
-	mageTree.Nodes["mage_fireball"] = fireballNode
+		node := &components.SkillNode{Skill: skill, X: entry.X, Y: entry.Y, Children: []string{}}
+		tree.Nodes[skill.ID] = node
+		order = append(order, node)
+		if skill.Tier > tree.MaxTier {
+			tree.MaxTier = skill.Tier
+		}
+	}
 
-	mageTree.Layout = [][]*components.SkillNode{
-		{manaPoolNode, spellPowerNode}, // Tier 1
-		{nil, fireballNode},            // Tier 2
+	// Children and layout rows follow from prerequisites and positions
+	for _, node := range order {
+		for _, prereqID := range node.Skill.Prerequisites {
+			if parent, inTree := tree.Nodes[prereqID]; inTree {
+				if parent.Y >= node.Y {
+					return fmt.Errorf("skill %s must be on a row below its prerequisite %s", node.Skill.ID, prereqID)
+				}
+				parent.Children = append(parent.Children, node.Skill.ID)
+			}
+		}
+		for len(tree.Layout) <= node.Y {
+			tree.Layout = append(tree.Layout, []*components.SkillNode{})
+		}
+		for len(tree.Layout[node.Y]) <= node.X {
+			tree.Layout[node.Y] = append(tree.Layout[node.Y], nil)
+		}
+		tree.Layout[node.Y][node.X] = node
 	}
 
-	sr.skillTrees[components.JobMage] = mageTree
+	sr.skillTrees[treeData.ID] = tree
+	return nil
 }
 
-// initializeRogueSkills creates the rogue skill tree
-func (sr *SkillRegistry) initializeRogueSkills() {
-	// Tier 1 Skills
-	sneak := &components.Skill{
-		ID:            "rogue_sneak",
-		Name:          "Sneak",
-		Description:   "Move silently to avoid detection. Increases movement speed.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Speed",
-				Value:       3,
-				Description: "+3 Speed",
-			},
-		},
-		IconPath: "assets/icons/skills/sneak.png",
+// toSkill converts a data file skill into a skill of the given job
+func (sd *skillData) toSkill(job components.JobType) (*components.Skill, error) {
+	if sd.ID == "" {
+		return nil, fmt.Errorf("skill without id")
 	}
-
-	quickReflexes := &components.Skill{
-		ID:            "rogue_quick_reflexes",
-		Name:          "Quick Reflexes",
-		Description:   "Enhanced reflexes improve defense and agility.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Defense",
-				Value:       2,
-				Description: "+2 Defense",
-			},
-			{
-				Type:        "stat_bonus",
-				Target:      "Speed",
-				Value:       2,
-				Description: "+2 Speed",
-			},
-		},
-		IconPath: "assets/icons/skills/quick_reflexes.png",
+	skillType, err := components.ParseSkillType(sd.Type)
+	if err != nil {
+		return nil, fmt.Errorf("skill %s: %v", sd.ID, err)
 	}
 
-	preciseStrike := &components.Skill{
-		ID:            "rogue_precise_strike",
-		Name:          "Precise Strike",
-		Description:   "Target weak points for increased attack damage.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          1,
-		Prerequisites: []string{},
-		SkillPoints:   1,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Attack",
-				Value:       4,
-				Description: "+4 Attack Damage",
-			},
-		},
-		IconPath: "assets/icons/skills/precise_strike.png",
+	skill := &components.Skill{
+		ID:            sd.ID,
+		Name:          sd.Name,
+		Description:   sd.Description,
+		Type:          skillType,
+		JobClass:      job,
+		Tier:          sd.Tier,
+		Prerequisites: sd.Prerequisites,
+		SkillPoints:   sd.SkillPoints,
+		IconPath:      sd.Icon,
 	}
-
-	// Tier 2 Skills
-	shadowStep := &components.Skill{
-		ID:            "rogue_shadow_step",
-		Name:          "Shadow Step",
-		Description:   "Advanced stealth techniques grant massive speed boost.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          2,
-		Prerequisites: []string{"rogue_sneak"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Speed",
-				Value:       5,
-				Description: "+5 Speed",
-			},
-			{
-				Type:        "stat_bonus",
-				Target:      "Attack",
-				Value:       3,
-				Description: "+3 Attack Damage",
-			},
-		},
-		IconPath: "assets/icons/skills/shadow_step.png",
+	if skill.Name == "" {
+		skill.Name = sd.ID
 	}
-
-	evasion := &components.Skill{
-		ID:            "rogue_evasion",
-		Name:          "Evasion",
-		Description:   "Master dodging techniques dramatically increase survival.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          2,
-		Prerequisites: []string{"rogue_quick_reflexes"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Defense",
-				Value:       6,
-				Description: "+6 Defense",
-			},
-			{
-				Type:        "stat_bonus",
-				Target:      "MaxHP",
-				Value:       8,
-				Description: "+8 Maximum HP",
-			},
-		},
-		IconPath: "assets/icons/skills/evasion.png",
+	if skill.Tier <= 0 {
+		skill.Tier = sd.Y + 1
 	}
-
-	deadlyStrike := &components.Skill{
-		ID:            "rogue_deadly_strike",
-		Name:          "Deadly Strike",
-		Description:   "Devastating attack techniques for maximum damage.",
-		Type:          components.SkillTypePassive,
-		JobClass:      components.JobRogue,
-		Tier:          2,
-		Prerequisites: []string{"rogue_precise_strike"},
-		SkillPoints:   2,
-		Effects: []components.SkillEffect{
-			{
-				Type:        "stat_bonus",
-				Target:      "Attack",
-				Value:       7,
-				Description: "+7 Attack Damage",
-			},
-		},
-		IconPath: "assets/icons/skills/deadly_strike.png",
+	if skill.Prerequisites == nil {
+		skill.Prerequisites = []string{}
 	}
-
-	// Register all skills
-	sr.RegisterSkill(sneak)
-	sr.RegisterSkill(quickReflexes)
-	sr.RegisterSkill(preciseStrike)
-	sr.RegisterSkill(shadowStep)
-	sr.RegisterSkill(evasion)
-	sr.RegisterSkill(deadlyStrike)
-
-	// Create the skill tree
-	rogueTree := &components.SkillTree{
-		JobClass: components.JobRogue,
-		Name:     "Rogue Arts",
-		Nodes:    make(map[string]*components.SkillNode),
-		Layout:   make([][]*components.SkillNode, 3), // 3 rows for tiers
-		MaxTier:  2,
+	if sd.Ability != nil {
+		if skillType != components.SkillTypeActive {
+			return nil, fmt.Errorf("skill %s: only active skills have ability costs", sd.ID)
+		}
+		if sd.Ability.Target != "" && !abilityTargets[sd.Ability.Target] {
+			return nil, fmt.Errorf("skill %s: unknown ability target %s", sd.ID, sd.Ability.Target)
+		}
+		if sd.Ability.MPCost < 0 || sd.Ability.APCost < 0 || sd.Ability.Cooldown < 0 || sd.Ability.Charges < 0 || sd.Ability.Range < 0 {
+			return nil, fmt.Errorf("skill %s: negative ability cost", sd.ID)
+		}
+		skill.MPCost = sd.Ability.MPCost
+		skill.APCost = sd.Ability.APCost
+		skill.Cooldown = sd.Ability.Cooldown
+		skill.Charges = sd.Ability.Charges
+		skill.Range = sd.Ability.Range
+		skill.Target = sd.Ability.Target
 	}
 
-	// Create skill nodes
-	sneakNode := &components.SkillNode{Skill: sneak, X: 0, Y: 0, Children: []string{"rogue_shadow_step"}}
-	quickReflexesNode := &components.SkillNode{Skill: quickReflexes, X: 1, Y: 0, Children: []string{"rogue_evasion"}}
-	preciseStrikeNode := &components.SkillNode{Skill: preciseStrike, X: 2, Y: 0, Children: []string{"rogue_deadly_strike"}}
-	shadowStepNode := &components.SkillNode{Skill: shadowStep, X: 0, Y: 1, Children: []string{}}
-	evasionNode := &components.SkillNode{Skill: evasion, X: 1, Y: 1, Children: []string{}}
-	deadlyStrikeNode := &components.SkillNode{Skill: deadlyStrike, X: 2, Y: 1, Children: []string{}}
-
-	// Add nodes to tree
-	rogueTree.Nodes["rogue_sneak"] = sneakNode
-	rogueTree.Nodes["rogue_quick_reflexes"] = quickReflexesNode
-	rogueTree.Nodes["rogue_precise_strike"] = preciseStrikeNode
-	rogueTree.Nodes["rogue_shadow_step"] = shadowStepNode
-	rogueTree.Nodes["rogue_evasion"] = evasionNode
-	rogueTree.Nodes["rogue_deadly_strike"] = deadlyStrikeNode
-
-	// Set up layout grid
-	rogueTree.Layout[0] = []*components.SkillNode{sneakNode, quickReflexesNode, preciseStrikeNode}
-	rogueTree.Layout[1] = []*components.SkillNode{shadowStepNode, evasionNode, deadlyStrikeNode}
-	rogueTree.Layout[2] = []*components.SkillNode{} // Empty tier 3 for now
-
-	// Register the skill tree
-	sr.skillTrees[components.JobRogue] = rogueTree
+	for _, effectData := range sd.Effects {
+		effect := components.SkillEffect{
+			Type:        effectData.Type,
+			Target:      effectData.Target,
+			Value:       effectData.Value,
+			Description: effectData.Description,
+		}
+		if len(effectData.Data) > 0 {
+			effect.Data = effectData.Data
+		}
+		if err := ValidateEffect(effect); err != nil {
+			return nil, fmt.Errorf("skill %s: %v", sd.ID, err)
+		}
+		skill.Effects = append(skill.Effects, effect)
+	}
+	return skill, nil
 }
 
-func (sr *SkillRegistry) initializeClericSkills() {
-	// TODO: Implement cleric skills
-}
+// Validate checks that every prerequisite is a registered skill and that prerequisites do not form cycles
+func (sr *SkillRegistry) Validate() error {
+	for _, skill := range sr.skills {
+		for _, prereqID := range skill.Prerequisites {
+			if _, exists := sr.skills[prereqID]; !exists {
+				return fmt.Errorf("skill %s has unknown prerequisite %s", skill.ID, prereqID)
+			}
+		}
+	}
+
+	// Depth-first search over prerequisites, a skill met again while being visited closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(skillID string, path []string) error
+	visit = func(skillID string, path []string) error {
+		switch state[skillID] {
+		case visiting:
+			return fmt.Errorf("skill prerequisites form a cycle: %s -> %s", strings.Join(path, " -> "), skillID)
+		case visited:
+			return nil
+		}
+		state[skillID] = visiting
+		for _, prereqID := range sr.skills[skillID].Prerequisites {
+			if err := visit(prereqID, append(path, skillID)); err != nil {
+				return err
+			}
+		}
+		state[skillID] = visited
+		return nil
+	}
 
-func (sr *SkillRegistry) initializeArcherSkills() {
-	// TODO: Implement archer skills
+	ids := make([]string, 0, len(sr.skills))
+	for id := range sr.skills {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := visit(id, nil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Global skill registry instance
 var GlobalSkillRegistry *SkillRegistry
 
-// InitializeSkillRegistry initializes the global skill registry
+// LoadSkillRegistry loads the global skill registry from a skill data file
+func LoadSkillRegistry(path string) error {
+	registry := NewSkillRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		return err
+	}
+	GlobalSkillRegistry = registry
+	return nil
+}
+
+// InitializeSkillRegistry loads the global skill registry from the default skill data file,
+// leaving it empty if the file cannot be loaded
 func InitializeSkillRegistry() {
-	GlobalSkillRegistry = NewSkillRegistry()
+	if err := LoadSkillRegistry(constants.SkillDataFile); err != nil {
+		logger.Warn("Failed to load skill data: %v", err)
+		GlobalSkillRegistry = NewSkillRegistry()
+	}
 }
 
-// GetGlobalSkillRegistry returns the global skill registry
+// GetGlobalSkillRegistry returns the global skill registry, loading the default skill data file if needed
 func GetGlobalSkillRegistry() *SkillRegistry {
 	if GlobalSkillRegistry == nil {
 		InitializeSkillRegistry()
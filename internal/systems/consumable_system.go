@@ -3,6 +3,7 @@ package systems
 import (
 	"fmt"
 
+	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
 )
@@ -54,17 +55,19 @@ func (cm *ConsumableManager) applyEffect(effect components.ConsumableEffect, use
 	case "heal_mp":
 		return cm.healMP(stats, effect.Value)
 	case "buff_attack":
-		return cm.buffStat(stats, "attack", effect.Value)
+		return cm.buffStat(stats, components.StatAttack, effect.Value)
 	case "buff_defense":
-		return cm.buffStat(stats, "defense", effect.Value)
+		return cm.buffStat(stats, components.StatDefense, effect.Value)
 	case "buff_magic_attack":
-		return cm.buffStat(stats, "magic_attack", effect.Value)
+		return cm.buffStat(stats, components.StatMagicAttack, effect.Value)
 	case "buff_magic_defense":
-		return cm.buffStat(stats, "magic_defense", effect.Value)
+		return cm.buffStat(stats, components.StatMagicDefense, effect.Value)
 	case "buff_speed":
-		return cm.buffStat(stats, "speed", effect.Value)
+		return cm.buffStat(stats, components.StatSpeed, effect.Value)
 	case "cure_all":
 		return cm.cureAllStatusEffects(stats)
+	case "damage":
+		return cm.dealDamage(effect, user, actualTarget)
 	default:
 		return fmt.Errorf("unknown effect type: %s", effect.Type)
 	}
@@ -73,11 +76,11 @@ func (cm *ConsumableManager) applyEffect(effect components.ConsumableEffect, use
 // healHP restores HP to the target
 func (cm *ConsumableManager) healHP(stats *components.RPGStatsComponent, amount int) error {
 	if amount == 9999 { // Full heal
-		stats.CurrentHP = stats.MaxHP
+		stats.CurrentHP = stats.GetMaxHP()
 	} else {
 		stats.CurrentHP += amount
-		if stats.CurrentHP > stats.MaxHP {
-			stats.CurrentHP = stats.MaxHP
+		if stats.CurrentHP > stats.GetMaxHP() {
+			stats.CurrentHP = stats.GetMaxHP()
 		}
 	}
 	return nil
@@ -86,34 +89,30 @@ func (cm *ConsumableManager) healHP(stats *components.RPGStatsComponent, amount
 // healMP restores MP to the target
 func (cm *ConsumableManager) healMP(stats *components.RPGStatsComponent, amount int) error {
 	if amount == 9999 { // Full restore
-		stats.CurrentMP = stats.MaxMP
+		stats.CurrentMP = stats.GetMaxMP()
 	} else {
 		stats.CurrentMP += amount
-		if stats.CurrentMP > stats.MaxMP {
-			stats.CurrentMP = stats.MaxMP
+		if stats.CurrentMP > stats.GetMaxMP() {
+			stats.CurrentMP = stats.GetMaxMP()
 		}
 	}
 	return nil
 }
 
-// buffStat applies a temporary stat buff (would need buff system for duration)
-func (cm *ConsumableManager) buffStat(stats *components.RPGStatsComponent, statName string, amount int) error {
-	// For now, just apply direct temporary bonus
-	// In a full system, this would create a temporary buff with duration
-	switch statName {
-	case "attack":
-		stats.Attack += amount
-	case "defense":
-		stats.Defense += amount
-	case "magic_attack":
-		stats.MagicAttack += amount
-	case "magic_defense":
-		stats.MagicDefense += amount
-	case "speed":
-		stats.Speed += amount
-	default:
-		return fmt.Errorf("unknown stat: %s", statName)
+// buffStat applies a temporary stat buff as a status effect, base stats are not changed.
+// Using the same buff again refreshes it instead of stacking.
+func (cm *ConsumableManager) buffStat(stats *components.RPGStatsComponent, stat components.StatType, amount int) error {
+	name := fmt.Sprintf("%s Up", stat.String())
+	stats.AddStatusEffect(components.NewStatusEffect(name, stat, amount, constants.ConsumableBuffBattles))
+	return nil
+}
+
+// dealDamage hurts an enemy with the effect element, scaled by the target affinity
+func (cm *ConsumableManager) dealDamage(effect components.ConsumableEffect, user *ecs.Entity, target *ecs.Entity) error {
+	if target == user {
+		return fmt.Errorf("must be used on an enemy")
 	}
+	target.TakeElementalDamage(effect.Value, effect.Element)
 	return nil
 }
 
@@ -187,6 +186,12 @@ func (cm *ConsumableManager) GetConsumableDescription(item *components.Item) str
 			description += fmt.Sprintf("- Increases Speed by %d (temporary)\n", effect.Value)
 		case "cure_all":
 			description += "- Cures all status effects\n"
+		case "damage":
+			if effect.Element != components.ElementNone {
+				description += fmt.Sprintf("- Deals %d %s damage to an enemy\n", effect.Value, effect.Element.String())
+			} else {
+				description += fmt.Sprintf("- Deals %d damage to an enemy\n", effect.Value)
+			}
 		default:
 			description += fmt.Sprintf("- %s: %d\n", effect.Type, effect.Value)
 		}
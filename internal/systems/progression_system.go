@@ -0,0 +1,56 @@
+package systems
+
+import (
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// LevelUpListener is notified of every level gained by a character
+type LevelUpListener func(entity *ecs.Entity, event components.LevelUpEvent)
+
+// ProgressionManager grants experience, the skill points of every level gained and
+// notifies level-up listeners (UI popups, quest objectives)
+type ProgressionManager struct {
+	listeners []LevelUpListener
+}
+
+// NewProgressionManager creates a new progression manager without listeners
+func NewProgressionManager() *ProgressionManager {
+	return &ProgressionManager{
+		listeners: make([]LevelUpListener, 0),
+	}
+}
+
+// AddLevelUpListener registers a function called for every level gained
+func (pm *ProgressionManager) AddLevelUpListener(listener LevelUpListener) {
+	pm.listeners = append(pm.listeners, listener)
+}
+
+// GrantExperience gives experience to a character and returns the levels gained
+func (pm *ProgressionManager) GrantExperience(entity *ecs.Entity, exp int) []components.LevelUpEvent {
+	stats := entity.RPGStats()
+	if stats == nil {
+		return nil
+	}
+
+	events := stats.GainExperience(exp)
+	for _, event := range events {
+		pm.GrantSkillPoints(entity, event.SkillPoints)
+		for _, listener := range pm.listeners {
+			listener(entity, event)
+		}
+	}
+	return events
+}
+
+// GrantSkillPoints adds skill points, creating the skills component if the character has none yet
+func (pm *ProgressionManager) GrantSkillPoints(entity *ecs.Entity, points int) {
+	skillsComp := entity.Skills()
+	if skillsComp == nil {
+		skillsComp = components.NewSkillsComponent(entity.RPGStats().Job)
+		skillsComp.AddSkillPoints(constants.InitialSkillPoints)
+		entity.AddComponent(ecs.ComponentSkills, skillsComp)
+	}
+	skillsComp.AddSkillPoints(points)
+}
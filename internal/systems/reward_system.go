@@ -0,0 +1,239 @@
+package systems
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/loot"
+)
+
+// LevelUpResult records a party member that gained levels from battle experience
+type LevelUpResult struct {
+	Name     string                    // Character display name
+	OldLevel int                       // Level before the rewards were applied
+	NewLevel int                       // Level after the rewards were applied
+	Events   []components.LevelUpEvent // One event per level gained
+}
+
+// ItemReward records an item dropped by a defeated enemy
+type ItemReward struct {
+	Item      *components.Item // Dropped item
+	Quantity  int              // Quantity that was added to an inventory
+	Recipient string           // Name of the character that received the item
+	Lost      int              // Quantity left behind because every inventory was full
+}
+
+// BattleRewards summarizes everything granted to the party after a victory
+type BattleRewards struct {
+	Experience      int             // Total experience earned from defeated enemies
+	ExperienceEach  int             // Experience given to each surviving party member
+	Gold            int             // Gold earned for the party
+	Items           []ItemReward    // Items rolled from enemy drop tables
+	LevelUps        []LevelUpResult // Party members that leveled up
+	Survivors       []string        // Names of the party members that shared the experience
+	EnemiesDefeated int             // Number of defeated enemies that granted rewards
+}
+
+// RewardManager computes and applies post-battle rewards
+type RewardManager struct {
+	rng         *rand.Rand
+	progression *ProgressionManager
+}
+
+// NewRewardManager creates a new reward manager using the given random source.
+// Experience is granted through the progression manager.
+func NewRewardManager(rng *rand.Rand, progression *ProgressionManager) *RewardManager {
+	return &RewardManager{
+		rng:         rng,
+		progression: progression,
+	}
+}
+
+// DistributeRewards grants experience and loot from defeated enemies to the surviving party members.
+// Gold is only computed; the caller adds it to the party.
+func (rm *RewardManager) DistributeRewards(party, enemies []*ecs.Entity) *BattleRewards {
+	rewards := &BattleRewards{
+		Items:     make([]ItemReward, 0),
+		LevelUps:  make([]LevelUpResult, 0),
+		Survivors: make([]string, 0),
+	}
+
+	// Only surviving party members share the rewards
+	survivors := make([]*ecs.Entity, 0)
+	for _, member := range party {
+		if stats := member.RPGStats(); stats != nil && stats.IsAlive() {
+			survivors = append(survivors, member)
+			rewards.Survivors = append(rewards.Survivors, stats.Name)
+		}
+	}
+
+	// Only defeated enemies grant rewards
+	drops := make([]ItemReward, 0)
+	for _, enemy := range enemies {
+		stats := enemy.RPGStats()
+		if stats == nil || stats.IsAlive() {
+			continue
+		}
+		rewards.EnemiesDefeated++
+		rewards.Experience += rm.enemyExperience(enemy)
+		rewards.Gold += rm.enemyGold(enemy)
+		drops = append(drops, rm.rollDrops(enemy)...)
+	}
+
+	if len(survivors) == 0 {
+		return rewards
+	}
+
+	// Share experience evenly and process level-ups
+	rewards.ExperienceEach = rewards.Experience / len(survivors)
+	if rewards.Experience > 0 && rewards.ExperienceEach == 0 {
+		rewards.ExperienceEach = 1
+	}
+	for _, member := range survivors {
+		stats := member.RPGStats()
+		oldLevel := stats.Level
+		if events := rm.progression.GrantExperience(member, rewards.ExperienceEach); len(events) > 0 {
+			rewards.LevelUps = append(rewards.LevelUps, LevelUpResult{
+				Name:     stats.Name,
+				OldLevel: oldLevel,
+				NewLevel: stats.Level,
+				Events:   events,
+			})
+		}
+	}
+
+	// Deposit dropped items into the first inventories with room
+	for _, drop := range drops {
+		rewards.Items = append(rewards.Items, rm.depositItem(survivors, drop.Item, drop.Quantity))
+	}
+
+	return rewards
+}
+
+// enemyExperience returns the experience granted by a defeated enemy
+func (rm *RewardManager) enemyExperience(enemy *ecs.Entity) int {
+	if loot := enemy.Loot(); loot != nil && loot.Experience > 0 {
+		return loot.Experience
+	}
+	return enemy.RPGStats().Level * constants.ExperiencePerEnemyLevel
+}
+
+// enemyGold returns the gold granted by a defeated enemy
+func (rm *RewardManager) enemyGold(enemy *ecs.Entity) int {
+	if loot := enemy.Loot(); loot != nil {
+		return loot.Gold
+	}
+	return enemy.RPGStats().Level * constants.GoldPerEnemyLevel
+}
+
+// rollDrops rolls every entry of an enemy drop table. Each dropped piece of equipment rolls
+// its own rarity and affixes, so it is rewarded one by one.
+func (rm *RewardManager) rollDrops(enemy *ecs.Entity) []ItemReward {
+	drops := make([]ItemReward, 0)
+	enemyLoot := enemy.Loot()
+	if enemyLoot == nil || components.GlobalItemRegistry == nil {
+		return drops
+	}
+
+	for _, entry := range enemyLoot.Drops {
+		if rm.rng.Intn(100) >= entry.Chance {
+			continue
+		}
+
+		item := components.GlobalItemRegistry.CreateItem(entry.ItemID)
+		if item == nil {
+			continue
+		}
+
+		quantity := entry.MinQuantity
+		if entry.MaxQuantity > entry.MinQuantity {
+			quantity += rm.rng.Intn(entry.MaxQuantity - entry.MinQuantity + 1)
+		}
+		if item.Equipment == nil {
+			drops = append(drops, ItemReward{Item: item, Quantity: quantity})
+			continue
+		}
+		for i := 0; i < quantity; i++ {
+			generated, err := loot.GetGlobalLootGenerator().RollDrop(item, rm.rng)
+			if err != nil {
+				logger.Warn("Failed to generate %s: %v", item.Name, err)
+				generated = item
+			}
+			drops = append(drops, ItemReward{Item: generated, Quantity: 1})
+		}
+	}
+
+	return drops
+}
+
+// depositItem adds an item to the first party inventories with enough room
+func (rm *RewardManager) depositItem(recipients []*ecs.Entity, item *components.Item, quantity int) ItemReward {
+	reward := ItemReward{Item: item}
+	remaining := quantity
+
+	for _, member := range recipients {
+		inventory := member.Inventory()
+		if inventory == nil {
+			continue
+		}
+
+		var overflow int
+		if item.Stackable || item.IsInstance() {
+			overflow = inventory.AddItem(item, remaining)
+		} else {
+			// Non-stackable items take one slot each
+			overflow = remaining
+			for overflow > 0 && inventory.AddItem(components.GlobalItemRegistry.CreateItem(item.ID), 1) == 0 {
+				overflow--
+			}
+		}
+
+		if added := remaining - overflow; added > 0 {
+			reward.Quantity += added
+			if reward.Recipient == "" {
+				reward.Recipient = member.RPGStats().Name
+			}
+		}
+		remaining = overflow
+		if remaining <= 0 {
+			break
+		}
+	}
+
+	reward.Lost = remaining
+	return reward
+}
+
+// SummaryLines returns the rewards formatted for a victory summary screen
+func (br *BattleRewards) SummaryLines() []string {
+	lines := make([]string, 0)
+	lines = append(lines, fmt.Sprintf("Enemies defeated: %d", br.EnemiesDefeated))
+	lines = append(lines, fmt.Sprintf("Experience: %d (%d each)", br.Experience, br.ExperienceEach))
+	lines = append(lines, fmt.Sprintf("Gold: %d", br.Gold))
+
+	for _, levelUp := range br.LevelUps {
+		lines = append(lines, fmt.Sprintf("%s reached level %d!", levelUp.Name, levelUp.NewLevel))
+	}
+
+	if len(br.Items) == 0 {
+		lines = append(lines, "No items dropped")
+	}
+	for _, item := range br.Items {
+		name := item.Item.Name
+		if item.Item.IsInstance() {
+			name = fmt.Sprintf("%s [%s]", name, item.Item.Rarity)
+		}
+		if item.Quantity > 0 {
+			lines = append(lines, fmt.Sprintf("%s x%d -> %s", name, item.Quantity, item.Recipient))
+		}
+		if item.Lost > 0 {
+			lines = append(lines, fmt.Sprintf("%s x%d left behind (inventory full)", name, item.Lost))
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,306 @@
+// Package tactical provides the ability command of turn-based combat
+package tactical
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// AbilityAPCost returns the AP an ability spends, the default ability cost when its skill declares none
+func AbilityAPCost(skill *components.Skill) int {
+	if skill.APCost > 0 {
+		return skill.APCost
+	}
+	return constants.AbilityAPCost
+}
+
+// AbilityRange returns the range in tiles of an ability, adjacent tiles when its skill declares none
+func AbilityRange(skill *components.Skill) int {
+	if skill.Range > 0 {
+		return skill.Range
+	}
+	return constants.DefaultAbilityRange
+}
+
+// AbilityMPCost returns the MP an ability spends, nothing when its skill declares no "mp_cost"
+func AbilityMPCost(skill *components.Skill) int {
+	return skill.MPCost
+}
+
+// abilityEffect returns the data of the "ability_unlock" effect of a skill, nil if it unlocks no ability
+func abilityEffect(skill *components.Skill) map[string]interface{} {
+	for _, effect := range skill.Effects {
+		if effect.Type != "ability_unlock" {
+			continue
+		}
+		if data, ok := effect.Data.(map[string]interface{}); ok {
+			return data
+		}
+		return map[string]interface{}{}
+	}
+	return nil
+}
+
+// abilityDataNumber reads a numeric value from ability effect data
+func abilityDataNumber(data map[string]interface{}, key string) float64 {
+	switch value := data[key].(type) {
+	case int:
+		return float64(value)
+	case float64:
+		return value
+	default:
+		return 0
+	}
+}
+
+// abilityTargetsAllies returns true if an ability is used on the actor's own team
+func abilityTargetsAllies(skill *components.Skill) bool {
+	switch skill.Target {
+	case "ally", "all_allies":
+		return true
+	case "enemy", "all_enemies", "random", "row":
+		return false
+	}
+	return abilityDataNumber(abilityEffect(skill), "heal") > 0
+}
+
+// abilityHitsGroup returns true if an ability reaches every target in range instead of the chosen one
+func abilityHitsGroup(skill *components.Skill) bool {
+	switch skill.Target {
+	case "all_enemies", "all_allies", "random", "row":
+		return true
+	}
+	return false
+}
+
+// GetAbilitiesForUnit returns the active abilities a unit can use, from the learned skills of its
+// current and secondary jobs
+func (cbm *TurnBasedCombatManager) GetAbilitiesForUnit(actor *ecs.Entity) []*components.Skill {
+	abilities := make([]*components.Skill, 0)
+	if actor == nil || actor.Skills() == nil {
+		return abilities
+	}
+
+	for _, skill := range actor.Skills().GetUsableActiveSkills() {
+		if abilityEffect(skill) != nil {
+			abilities = append(abilities, skill)
+		}
+	}
+
+	// Learned skills are stored in a map, keep the list order stable
+	sort.Slice(abilities, func(i, j int) bool {
+		return abilities[i].Name < abilities[j].Name
+	})
+
+	return abilities
+}
+
+// CreateAbilityAction creates a validated ability action
+func (cbm *TurnBasedCombatManager) CreateAbilityAction(actor *ecs.Entity, skill *components.Skill, target *ecs.Entity) (*CombatAction, error) {
+	if actor == nil {
+		return nil, fmt.Errorf("actor is nil")
+	}
+	if skill == nil {
+		return nil, fmt.Errorf("ability is nil")
+	}
+	if target == nil {
+		return nil, fmt.Errorf("target is nil")
+	}
+
+	action := &CombatAction{
+		Type:      ActionSkill,
+		Actor:     actor,
+		Target:    target,
+		TargetPos: GridPos{}, // Not used for abilities
+		Skill:     skill,
+		APCost:    AbilityAPCost(skill),
+		Validated: false,
+		Message:   fmt.Sprintf("%s uses %s on %s", cbm.getEntityName(actor), skill.Name, cbm.getEntityName(target)),
+	}
+
+	if err := cbm.validateAbility(actor, skill); err != nil {
+		action.Message = fmt.Sprintf("Invalid ability: %v", err)
+		return action, err
+	}
+	if err := cbm.validateAbilityTarget(actor, skill, target); err != nil {
+		action.Message = fmt.Sprintf("Invalid ability: %v", err)
+		return action, err
+	}
+
+	action.Validated = true
+	return action, nil
+}
+
+// validateAbility checks if a unit can use an ability now: job, cooldown, charges, MP and AP
+func (cbm *TurnBasedCombatManager) validateAbility(actor *ecs.Entity, skill *components.Skill) error {
+	skills := actor.Skills()
+	if skills == nil || !skills.CanUseSkill(skill) || abilityEffect(skill) == nil {
+		return fmt.Errorf("%s cannot be used by %s", skill.Name, cbm.getEntityName(actor))
+	}
+	if err := skills.CanUseAbility(skill); err != nil {
+		return err
+	}
+
+	stats := actor.RPGStats()
+	if stats == nil {
+		return fmt.Errorf("actor has no stats")
+	}
+	if mpCost := AbilityMPCost(skill); stats.CurrentMP < mpCost {
+		return fmt.Errorf("not enough MP (need %d, have %d)", mpCost, stats.CurrentMP)
+	}
+
+	actionPoints := actor.ActionPoints()
+	if apCost := AbilityAPCost(skill); actionPoints == nil || !actionPoints.CanAfford(apCost) {
+		return fmt.Errorf("insufficient action points (need %d)", apCost)
+	}
+
+	return nil
+}
+
+// validateAbilityTarget checks if a target is alive, on the side the ability is used on and in range
+func (cbm *TurnBasedCombatManager) validateAbilityTarget(actor *ecs.Entity, skill *components.Skill, target *ecs.Entity) error {
+	targetStats := target.RPGStats()
+	if targetStats == nil {
+		return fmt.Errorf("target has no stats")
+	}
+	if !targetStats.IsAlive() {
+		return fmt.Errorf("target is already dead")
+	}
+
+	actorCombat := actor.CombatState()
+	targetCombat := target.CombatState()
+	if actorCombat == nil || targetCombat == nil {
+		return fmt.Errorf("missing combat state components")
+	}
+	if abilityTargetsAllies(skill) && actorCombat.Team != targetCombat.Team {
+		return fmt.Errorf("%s can only target allies", skill.Name)
+	}
+	if !abilityTargetsAllies(skill) && actorCombat.Team == targetCombat.Team {
+		return fmt.Errorf("%s can only target enemies", skill.Name)
+	}
+
+	actorTransform := actor.Transform()
+	targetTransform := target.Transform()
+	if actorTransform == nil || targetTransform == nil {
+		return fmt.Errorf("missing transform components")
+	}
+
+	actorGridPos := cbm.worldToGridPos(actorTransform.X, actorTransform.Y)
+	targetGridPos := cbm.worldToGridPos(targetTransform.X, targetTransform.Y)
+	distance := cbm.Grid.CalculateDistance(actorGridPos, targetGridPos)
+	if maxRange := AbilityRange(skill); distance > maxRange {
+		return fmt.Errorf("target out of range (distance: %d, max range: %d)", distance, maxRange)
+	}
+
+	return nil
+}
+
+// GetValidAbilityTargetsForUnit returns all valid targets of an ability, empty if the unit cannot use it now
+func (cbm *TurnBasedCombatManager) GetValidAbilityTargetsForUnit(actor *ecs.Entity, skill *components.Skill) []*ecs.Entity {
+	validTargets := []*ecs.Entity{}
+	if actor == nil || skill == nil {
+		return validTargets
+	}
+
+	if err := cbm.validateAbility(actor, skill); err != nil {
+		logger.VerboseCombat("Ability %s unavailable for %s: %v", skill.Name, actor.GetID(), err)
+		return validTargets
+	}
+
+	for _, team := range cbm.Teams {
+		for _, member := range team.Members {
+			if err := cbm.validateAbilityTarget(actor, skill, member); err == nil {
+				validTargets = append(validTargets, member)
+			}
+		}
+	}
+
+	return validTargets
+}
+
+// executeAbility spends the ability MP, starts its cooldown and applies it to its targets
+func (cbm *TurnBasedCombatManager) executeAbility(action *CombatAction) error {
+	if action.Skill == nil || action.Target == nil {
+		return fmt.Errorf("ability action without ability or target")
+	}
+
+	// Cooldowns, MP and range may have changed since the action was created
+	if err := cbm.validateAbility(action.Actor, action.Skill); err != nil {
+		return err
+	}
+	if err := cbm.validateAbilityTarget(action.Actor, action.Skill, action.Target); err != nil {
+		return err
+	}
+
+	targets := []*ecs.Entity{action.Target}
+	if abilityHitsGroup(action.Skill) {
+		targets = cbm.GetValidAbilityTargetsForUnit(action.Actor, action.Skill)
+	}
+
+	casterStats := action.Actor.RPGStats()
+	casterStats.UseMana(AbilityMPCost(action.Skill))
+	action.Actor.Skills().UseAbility(action.Skill)
+
+	for _, target := range targets {
+		cbm.applyAbility(action, target)
+	}
+
+	return nil
+}
+
+// applyAbility heals or damages a single target of an ability
+func (cbm *TurnBasedCombatManager) applyAbility(action *CombatAction, target *ecs.Entity) {
+	casterStats := action.Actor.RPGStats()
+	targetStats := target.RPGStats()
+	data := abilityEffect(action.Skill)
+
+	if heal := int(abilityDataNumber(data, "heal")); heal > 0 {
+		targetStats.Heal(heal)
+		cbm.sendUIMessage(fmt.Sprintf("%s's %s restores %d HP to %s (HP: %d/%d)",
+			casterStats.Name, action.Skill.Name, heal, targetStats.Name,
+			targetStats.CurrentHP, targetStats.GetMaxHP()))
+		return
+	}
+
+	// Spells add their power to magic damage, weapon skills scale the attack damage
+	var damage int
+	element := action.Actor.WeaponElement()
+	if multiplier := abilityDataNumber(data, "damage_multiplier"); multiplier > 0 {
+		damage = casterStats.GetStat(components.StatAttack) - targetStats.GetStat(components.StatDefense)
+		damage = int(float64(damage) * multiplier)
+	} else {
+		damage = int(abilityDataNumber(data, "damage")) +
+			casterStats.GetStat(components.StatMagicAttack) - targetStats.GetStat(components.StatMagicDefense)
+		name, _ := data["element"].(string)
+		parsed, err := components.ParseElement(name)
+		if err != nil {
+			logger.Warn("Skill %s: %v, using non-elemental damage", action.Skill.ID, err)
+		}
+		element = parsed
+	}
+	if damage < 1 {
+		damage = 1 // Minimum damage
+	}
+
+	damage, affinity := target.TakeElementalDamage(damage, element)
+	if damage < 0 {
+		cbm.sendUIMessage(fmt.Sprintf("%s absorbs %s's %s and recovers %d HP (HP: %d/%d)",
+			targetStats.Name, casterStats.Name, action.Skill.Name, -damage,
+			targetStats.CurrentHP, targetStats.GetMaxHP()))
+	} else {
+		cbm.sendUIMessage(fmt.Sprintf("%s's %s deals %d damage to %s%s (HP: %d/%d)",
+			casterStats.Name, action.Skill.Name, damage, targetStats.Name, affinity.BattleLogCallout(),
+			targetStats.CurrentHP, targetStats.GetMaxHP()))
+	}
+
+	if !targetStats.IsAlive() {
+		cbm.sendUIMessage(fmt.Sprintf("%s defeated!", targetStats.Name))
+		cbm.sendLogMessage(fmt.Sprintf("%s has been defeated by %s", targetStats.Name, casterStats.Name))
+		cbm.handleUnitDeath(target)
+	}
+}
@@ -0,0 +1,147 @@
+// Package tactical provides AI behavior profiles for enemy units in turn-based combat
+package tactical
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// AIProfile controls how an AI-driven unit behaves on its turn
+type AIProfile int
+
+const (
+	AIProfileDefensive  AIProfile = iota // Hold position and attack adjacent players (default)
+	AIProfileAggressive                  // Advance toward the nearest player and attack
+	AIProfilePassive                     // Hold position and take no action
+)
+
+func (ap AIProfile) String() string {
+	switch ap {
+	case AIProfileDefensive:
+		return "Defensive"
+	case AIProfileAggressive:
+		return "Aggressive"
+	case AIProfilePassive:
+		return "Passive"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseAIProfile converts a profile name from battle data into an AIProfile
+func ParseAIProfile(name string) (AIProfile, error) {
+	switch name {
+	case "defensive":
+		return AIProfileDefensive, nil
+	case "aggressive":
+		return AIProfileAggressive, nil
+	case "passive":
+		return AIProfilePassive, nil
+	default:
+		return AIProfileDefensive, fmt.Errorf("unknown AI profile: %s", name)
+	}
+}
+
+// SetAIProfile assigns an AI profile to a unit
+func (cbm *TurnBasedCombatManager) SetAIProfile(unit *ecs.Entity, profile AIProfile) {
+	if cbm.AIProfiles == nil {
+		cbm.AIProfiles = make(map[string]AIProfile)
+	}
+	cbm.AIProfiles[unit.GetID()] = profile
+	cbm.sendLogMessage(fmt.Sprintf("%s AI profile set to %s", cbm.getEntityName(unit), profile.String()))
+}
+
+// GetAIProfile returns the AI profile for a unit
+func (cbm *TurnBasedCombatManager) GetAIProfile(unit *ecs.Entity) AIProfile {
+	if profile, exists := cbm.AIProfiles[unit.GetID()]; exists {
+		return profile
+	}
+	return AIProfileDefensive
+}
+
+// planAdvance builds a move action that brings the unit closer to the nearest opponent
+func (cbm *TurnBasedCombatManager) planAdvance(unit *ecs.Entity) *CombatAction {
+	transform := unit.Transform()
+	stats := unit.RPGStats()
+	actionPoints := unit.ActionPoints()
+	if transform == nil || stats == nil || actionPoints == nil {
+		return nil
+	}
+
+	currentPos := cbm.worldToGridPos(transform.X, transform.Y)
+
+	// Find the closest living opponent
+	targetTeam := components.TeamPlayer
+	if combatState := unit.CombatState(); combatState != nil {
+		targetTeam = cbm.opposingTeam(combatState.Team)
+	}
+	target, targetDistance := cbm.findNearestUnit(currentPos, targetTeam)
+	if target == nil {
+		return nil
+	}
+	targetTransform := target.Transform()
+	targetPos := cbm.worldToGridPos(targetTransform.X, targetTransform.Y)
+
+	// Movement is limited by both AP and remaining moves
+	maxMoves := actionPoints.Current / constants.MovementAPCost
+	if stats.MovesRemaining < maxMoves {
+		maxMoves = stats.MovesRemaining
+	}
+	if maxMoves <= 0 {
+		return nil
+	}
+
+	// Pick the reachable tile that gets closest to the target
+	bestPos := currentPos
+	bestDistance := targetDistance
+	for _, pos := range cbm.Grid.CalculateMovementRange(currentPos, maxMoves) {
+		distance := cbm.Grid.CalculateDistance(pos, targetPos)
+		if distance < bestDistance {
+			bestPos = pos
+			bestDistance = distance
+		}
+	}
+
+	if bestPos == currentPos {
+		return nil
+	}
+
+	moveDistance := cbm.Grid.CalculateDistance(currentPos, bestPos)
+	return &CombatAction{
+		Type:      ActionMove,
+		Actor:     unit,
+		TargetPos: bestPos,
+		APCost:    moveDistance * constants.MovementAPCost,
+		Validated: true,
+		Message: fmt.Sprintf("%s advances toward %s",
+			cbm.getEntityName(unit), cbm.getEntityName(target)),
+	}
+}
+
+// findNearestUnit returns the closest living unit of a team and its distance
+func (cbm *TurnBasedCombatManager) findNearestUnit(from GridPos, team components.Team) (*ecs.Entity, int) {
+	var nearest *ecs.Entity
+	nearestDistance := 0
+
+	for _, teamInfo := range cbm.Teams {
+		if teamInfo.Team != team {
+			continue
+		}
+		for _, member := range teamInfo.Members {
+			transform := member.Transform()
+			if transform == nil || !cbm.isUnitAlive(member) {
+				continue
+			}
+			distance := cbm.Grid.CalculateDistance(from, cbm.worldToGridPos(transform.X, transform.Y))
+			if nearest == nil || distance < nearestDistance {
+				nearest = member
+				nearestDistance = distance
+			}
+		}
+	}
+
+	return nearest, nearestDistance
+}
@@ -0,0 +1,451 @@
+// Package tactical provides scripted mid-battle events such as reinforcements and dialog
+package tactical
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// ScriptTriggerType identifies what causes a scripted event to fire
+type ScriptTriggerType int
+
+const (
+	ScriptTriggerRound       ScriptTriggerType = iota // A given round has started
+	ScriptTriggerUnitHP                               // A unit's HP dropped to or below a percentage
+	ScriptTriggerTileEntered                          // A player unit is standing on a tile
+	ScriptTriggerUnitDeath                            // A unit has been defeated
+)
+
+func (stt ScriptTriggerType) String() string {
+	switch stt {
+	case ScriptTriggerRound:
+		return "Round"
+	case ScriptTriggerUnitHP:
+		return "Unit HP"
+	case ScriptTriggerTileEntered:
+		return "Tile Entered"
+	case ScriptTriggerUnitDeath:
+		return "Unit Death"
+	default:
+		return "Unknown"
+	}
+}
+
+// ScriptActionType identifies what a scripted event does when it fires
+type ScriptActionType int
+
+const (
+	ScriptActionSpawnWave ScriptActionType = iota // Spawn reinforcements at a grid edge
+	ScriptActionDialog                            // Show a line of dialog
+	ScriptActionTerrain                           // Change the terrain of a tile
+	ScriptActionAIProfile                         // Change the AI profile of units
+)
+
+func (sat ScriptActionType) String() string {
+	switch sat {
+	case ScriptActionSpawnWave:
+		return "Spawn Wave"
+	case ScriptActionDialog:
+		return "Dialog"
+	case ScriptActionTerrain:
+		return "Terrain"
+	case ScriptActionAIProfile:
+		return "AI Profile"
+	default:
+		return "Unknown"
+	}
+}
+
+// GridEdge identifies a side of the battle grid
+type GridEdge int
+
+const (
+	EdgeRight GridEdge = iota
+	EdgeLeft
+	EdgeTop
+	EdgeBottom
+)
+
+// ScriptTrigger describes when a scripted event fires
+type ScriptTrigger struct {
+	Type      ScriptTriggerType
+	Round     int     // Round number for ScriptTriggerRound
+	UnitName  string  // Unit for ScriptTriggerUnitHP and ScriptTriggerUnitDeath
+	HPPercent int     // Threshold for ScriptTriggerUnitHP
+	Tile      GridPos // Tile for ScriptTriggerTileEntered
+}
+
+// ScriptAction describes one effect of a scripted event
+type ScriptAction struct {
+	Type     ScriptActionType
+	Units    []string  // Unit IDs passed to the unit factory for ScriptActionSpawnWave
+	Edge     GridEdge  // Spawn edge for ScriptActionSpawnWave
+	Speaker  string    // Speaker for ScriptActionDialog
+	Text     string    // Line for ScriptActionDialog
+	Tile     GridPos   // Tile for ScriptActionTerrain
+	TileType TileType  // New terrain for ScriptActionTerrain
+	UnitName string    // Target for ScriptActionAIProfile (empty = all enemies)
+	Profile  AIProfile // New profile for ScriptActionAIProfile
+}
+
+// ScriptEvent pairs a trigger with the actions it runs
+type ScriptEvent struct {
+	Trigger ScriptTrigger
+	Actions []ScriptAction
+	Repeat  bool // Fire again each time the trigger becomes true
+
+	fired     bool
+	wasActive bool
+}
+
+// BattleScript holds the scripted events of a tactical battle
+type BattleScript struct {
+	Events []*ScriptEvent
+
+	originalTerrain map[GridPos]TileType // Terrain replaced by the script, restored after battle
+}
+
+// NewBattleScript creates an empty battle script
+func NewBattleScript() *BattleScript {
+	return &BattleScript{
+		Events:          make([]*ScriptEvent, 0),
+		originalTerrain: make(map[GridPos]TileType),
+	}
+}
+
+// AddEvent adds a scripted event to the battle script
+func (bs *BattleScript) AddEvent(trigger ScriptTrigger, repeat bool, actions ...ScriptAction) *ScriptEvent {
+	event := &ScriptEvent{Trigger: trigger, Actions: actions, Repeat: repeat}
+	bs.Events = append(bs.Events, event)
+	return event
+}
+
+// NewBattleScriptFromSpec builds a battle script from battle event data
+func NewBattleScriptFromSpec(specs []components.BattleScriptSpec) (*BattleScript, error) {
+	script := NewBattleScript()
+
+	for i, spec := range specs {
+		trigger, err := parseScriptTrigger(spec.Trigger)
+		if err != nil {
+			return nil, fmt.Errorf("script event %d: %v", i, err)
+		}
+
+		actions := make([]ScriptAction, 0, len(spec.Actions))
+		for _, actionSpec := range spec.Actions {
+			action, err := parseScriptAction(actionSpec)
+			if err != nil {
+				return nil, fmt.Errorf("script event %d: %v", i, err)
+			}
+			actions = append(actions, action)
+		}
+
+		script.AddEvent(trigger, spec.Repeat, actions...)
+	}
+
+	return script, nil
+}
+
+// parseScriptTrigger converts a trigger spec into a ScriptTrigger
+func parseScriptTrigger(spec components.BattleTriggerSpec) (ScriptTrigger, error) {
+	switch spec.Type {
+	case "round":
+		return ScriptTrigger{Type: ScriptTriggerRound, Round: spec.Round}, nil
+	case "unit_hp":
+		if spec.Target == "" {
+			return ScriptTrigger{}, fmt.Errorf("unit_hp trigger requires a target")
+		}
+		return ScriptTrigger{Type: ScriptTriggerUnitHP, UnitName: spec.Target, HPPercent: spec.HPPercent}, nil
+	case "tile_entered":
+		return ScriptTrigger{Type: ScriptTriggerTileEntered, Tile: GridPos{X: spec.TileX, Y: spec.TileY}}, nil
+	case "unit_death":
+		if spec.Target == "" {
+			return ScriptTrigger{}, fmt.Errorf("unit_death trigger requires a target")
+		}
+		return ScriptTrigger{Type: ScriptTriggerUnitDeath, UnitName: spec.Target}, nil
+	default:
+		return ScriptTrigger{}, fmt.Errorf("unknown trigger type: %s", spec.Type)
+	}
+}
+
+// parseScriptAction converts an action spec into a ScriptAction
+func parseScriptAction(spec components.BattleActionSpec) (ScriptAction, error) {
+	switch spec.Type {
+	case "spawn_wave":
+		edge, err := parseGridEdge(spec.Edge)
+		if err != nil {
+			return ScriptAction{}, err
+		}
+		return ScriptAction{Type: ScriptActionSpawnWave, Units: spec.Units, Edge: edge}, nil
+	case "dialog":
+		return ScriptAction{Type: ScriptActionDialog, Speaker: spec.Speaker, Text: spec.Text}, nil
+	case "terrain":
+		tileType, err := parseTileType(spec.Terrain)
+		if err != nil {
+			return ScriptAction{}, err
+		}
+		return ScriptAction{Type: ScriptActionTerrain, Tile: GridPos{X: spec.TileX, Y: spec.TileY}, TileType: tileType}, nil
+	case "ai_profile":
+		profile, err := ParseAIProfile(spec.Profile)
+		if err != nil {
+			return ScriptAction{}, err
+		}
+		return ScriptAction{Type: ScriptActionAIProfile, UnitName: spec.Target, Profile: profile}, nil
+	default:
+		return ScriptAction{}, fmt.Errorf("unknown action type: %s", spec.Type)
+	}
+}
+
+// parseGridEdge converts an edge name into a GridEdge (empty = right, the enemy side)
+func parseGridEdge(name string) (GridEdge, error) {
+	switch name {
+	case "", "right":
+		return EdgeRight, nil
+	case "left":
+		return EdgeLeft, nil
+	case "top":
+		return EdgeTop, nil
+	case "bottom":
+		return EdgeBottom, nil
+	default:
+		return EdgeRight, fmt.Errorf("unknown grid edge: %s", name)
+	}
+}
+
+// parseTileType converts a terrain name into a TileType
+func parseTileType(name string) (TileType, error) {
+	switch name {
+	case "floor":
+		return TileFloor, nil
+	case "wall":
+		return TileWall, nil
+	case "water":
+		return TileWater, nil
+	case "pit":
+		return TilePit, nil
+	case "elevated":
+		return TileElevated, nil
+	default:
+		return TileFloor, fmt.Errorf("unknown terrain: %s", name)
+	}
+}
+
+// OnPhaseChange evaluates triggers whenever combat returns to a team turn
+func (bs *BattleScript) OnPhaseChange(cbm *TurnBasedCombatManager, phase CombatPhase) {
+	if phase != CombatPhaseTeamTurn {
+		return
+	}
+
+	for _, event := range bs.Events {
+		active := event.Trigger.isActive(cbm)
+		shouldFire := active && !event.wasActive && (!event.fired || event.Repeat)
+		event.wasActive = active
+
+		if !shouldFire {
+			continue
+		}
+
+		event.fired = true
+		logger.Combat("Battle script triggered: %s", event.Trigger.Type.String())
+		for _, action := range event.Actions {
+			bs.runAction(cbm, action)
+		}
+	}
+}
+
+// Restore reverts terrain changed by the script
+func (bs *BattleScript) Restore(grid *Grid) {
+	for pos, tileType := range bs.originalTerrain {
+		grid.SetTileType(pos, tileType)
+	}
+	bs.originalTerrain = make(map[GridPos]TileType)
+}
+
+// isActive checks whether the trigger condition currently holds
+func (st ScriptTrigger) isActive(cbm *TurnBasedCombatManager) bool {
+	switch st.Type {
+	case ScriptTriggerRound:
+		return cbm.CurrentRound >= st.Round
+	case ScriptTriggerUnitHP:
+		unit := cbm.findUnitByName(st.UnitName)
+		if unit == nil || !cbm.isUnitAlive(unit) {
+			return false
+		}
+		stats := unit.RPGStats()
+		return stats.GetMaxHP() > 0 && stats.CurrentHP*100/stats.GetMaxHP() <= st.HPPercent
+	case ScriptTriggerTileEntered:
+		return cbm.isPlayerOnTile(st.Tile)
+	case ScriptTriggerUnitDeath:
+		unit := cbm.findUnitByName(st.UnitName)
+		return unit != nil && !cbm.isUnitAlive(unit)
+	default:
+		return false
+	}
+}
+
+// runAction executes a single scripted action
+func (bs *BattleScript) runAction(cbm *TurnBasedCombatManager, action ScriptAction) {
+	switch action.Type {
+	case ScriptActionSpawnWave:
+		cbm.spawnWave(action.Units, action.Edge)
+	case ScriptActionDialog:
+		if action.Speaker != "" {
+			cbm.sendUIMessage(fmt.Sprintf("%s: \"%s\"", action.Speaker, action.Text))
+		} else {
+			cbm.sendUIMessage(action.Text)
+		}
+	case ScriptActionTerrain:
+		tile := cbm.Grid.GetTile(action.Tile)
+		if tile == nil {
+			logger.Warn("Battle script terrain change outside grid at (%d,%d)", action.Tile.X, action.Tile.Y)
+			return
+		}
+		if _, recorded := bs.originalTerrain[action.Tile]; !recorded {
+			bs.originalTerrain[action.Tile] = tile.Type
+		}
+		cbm.Grid.SetTileType(action.Tile, action.TileType)
+		cbm.sendLogMessage(fmt.Sprintf("Terrain at (%d,%d) changed", action.Tile.X, action.Tile.Y))
+	case ScriptActionAIProfile:
+		for _, team := range cbm.Teams {
+			for _, member := range team.Members {
+				if !cbm.isUnitAlive(member) {
+					continue
+				}
+				if action.UnitName == "" && team.Team == components.TeamEnemy ||
+					action.UnitName != "" && cbm.getEntityName(member) == action.UnitName {
+					cbm.SetAIProfile(member, action.Profile)
+				}
+			}
+		}
+	}
+}
+
+// spawnWave creates reinforcements through the unit factory and places them on a grid edge
+func (cbm *TurnBasedCombatManager) spawnWave(unitIDs []string, edge GridEdge) {
+	if cbm.UnitFactory == nil {
+		logger.Warn("Battle script wants to spawn %d units but no unit factory is set", len(unitIDs))
+		return
+	}
+
+	tiles := cbm.findEdgeSpawnTiles(edge, len(unitIDs))
+	spawned := 0
+	for i, unitID := range unitIDs {
+		if i >= len(tiles) {
+			logger.Warn("No free edge tile left for reinforcement %s", unitID)
+			break
+		}
+
+		unit := cbm.UnitFactory(unitID)
+		if unit == nil {
+			logger.Warn("Unit factory returned nothing for %s", unitID)
+			continue
+		}
+
+		if err := cbm.SpawnUnit(unit, tiles[i]); err != nil {
+			logger.Error("Failed to spawn reinforcement %s: %v", unitID, err)
+			continue
+		}
+		spawned++
+	}
+
+	if spawned > 0 {
+		cbm.sendUIMessage(fmt.Sprintf("Reinforcements arrive! (%d units)", spawned))
+	}
+}
+
+// SpawnUnit adds a new unit to an ongoing battle at the given grid position
+func (cbm *TurnBasedCombatManager) SpawnUnit(unit *ecs.Entity, pos GridPos) error {
+	if !cbm.Grid.IsPassable(pos) {
+		return fmt.Errorf("spawn tile (%d,%d) is not free", pos.X, pos.Y)
+	}
+
+	if err := cbm.initializeEntityForCombat(unit); err != nil {
+		return err
+	}
+
+	// Place the unit on the grid
+	transform := unit.Transform()
+	if transform == nil {
+		return fmt.Errorf("unit has no transform component")
+	}
+	worldX, worldY := cbm.Grid.GridToWorld(pos)
+	transform.X = worldX + constants.GridOffsetX
+	transform.Y = worldY + constants.GridOffsetY
+	cbm.Grid.SetOccupied(pos, true, unit.GetID())
+
+	if stats := unit.RPGStats(); stats != nil {
+		stats.ResetMovement()
+	}
+
+	// Join the unit's team, creating it if needed
+	team := unit.CombatState().Team
+	var teamInfo *TeamInfo
+	for _, existing := range cbm.Teams {
+		if existing.Team == team {
+			teamInfo = existing
+			break
+		}
+	}
+	if teamInfo == nil {
+		teamInfo = &TeamInfo{Team: team, Members: make([]*ecs.Entity, 0)}
+		cbm.Teams = append(cbm.Teams, teamInfo)
+		cbm.InitiativeOrder = append(cbm.InitiativeOrder, teamInfo)
+	}
+	teamInfo.Members = append(teamInfo.Members, unit)
+	teamInfo.TotalSpeed = cbm.calculateTeamSpeed(teamInfo.Members)
+	teamInfo.Initiative = cbm.calculateTeamInitiative(teamInfo.Members)
+
+	logger.Combat("Spawned %s at (%d,%d) for %s team", cbm.getEntityName(unit), pos.X, pos.Y, team.String())
+
+	if cbm.UnitSpawnCallback != nil {
+		cbm.UnitSpawnCallback(unit)
+	}
+
+	return nil
+}
+
+// findEdgeSpawnTiles returns free tiles along an edge, spreading out from its center
+func (cbm *TurnBasedCombatManager) findEdgeSpawnTiles(edge GridEdge, count int) []GridPos {
+	tiles := make([]GridPos, 0, count)
+
+	// Look at the edge line first, then the lines just inside it
+	for depth := 0; depth < 3 && len(tiles) < count; depth++ {
+		length := cbm.Grid.Height
+		if edge == EdgeTop || edge == EdgeBottom {
+			length = cbm.Grid.Width
+		}
+
+		center := length / 2
+		for offset := 0; offset <= length && len(tiles) < count; offset++ {
+			// Alternate around the center: c, c+1, c-1, c+2, c-2...
+			index := center + (offset+1)/2
+			if offset%2 == 0 {
+				index = center - offset/2
+			}
+			if index < 0 || index >= length {
+				continue
+			}
+
+			var pos GridPos
+			switch edge {
+			case EdgeLeft:
+				pos = GridPos{X: depth, Y: index}
+			case EdgeRight:
+				pos = GridPos{X: cbm.Grid.Width - 1 - depth, Y: index}
+			case EdgeTop:
+				pos = GridPos{X: index, Y: depth}
+			case EdgeBottom:
+				pos = GridPos{X: index, Y: cbm.Grid.Height - 1 - depth}
+			}
+
+			if cbm.Grid.IsPassable(pos) {
+				tiles = append(tiles, pos)
+			}
+		}
+	}
+
+	return tiles
+}
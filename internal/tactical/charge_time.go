@@ -0,0 +1,210 @@
+// Package tactical provides charge-time (CT) based individual initiative for turn-based combat
+package tactical
+
+import (
+	"fmt"
+
+	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// TurnMode selects how the turn order is decided
+type TurnMode int
+
+const (
+	TurnModeTeam       TurnMode = iota // Whole teams alternate turns (default)
+	TurnModeChargeTime                 // Units act individually when their CT gauge fills
+)
+
+func (tm TurnMode) String() string {
+	switch tm {
+	case TurnModeTeam:
+		return "Team Turns"
+	case TurnModeChargeTime:
+		return "Charge Time"
+	default:
+		return "Unknown"
+	}
+}
+
+// SetTurnMode selects the turn mode used by the next battle
+func (cbm *TurnBasedCombatManager) SetTurnMode(mode TurnMode) {
+	if cbm.IsActive {
+		logger.Warn("Cannot change turn mode during combat")
+		return
+	}
+	cbm.TurnMode = mode
+}
+
+// GetTurnMode returns the current turn mode
+func (cbm *TurnBasedCombatManager) GetTurnMode() TurnMode {
+	return cbm.TurnMode
+}
+
+// startNextUnitTurn charges CT until a unit is ready and gives it the turn
+func (cbm *TurnBasedCombatManager) startNextUnitTurn() {
+	unit := cbm.advanceChargeTime()
+	if unit == nil {
+		logger.Turn("No unit can reach its turn")
+		return
+	}
+
+	// A round ends once a unit comes up for a second turn
+	if cbm.ctActedThisRound[unit.GetID()] {
+		cbm.CurrentRound++
+		cbm.ctActedThisRound = make(map[string]bool)
+		cbm.sendLogMessage(fmt.Sprintf("Starting Round %d", cbm.CurrentRound))
+	}
+	cbm.ctActedThisRound[unit.GetID()] = true
+
+	// Deactivate previous turn owner
+	if cbm.ActiveTeam != nil {
+		cbm.ActiveTeam.IsActive = false
+	}
+
+	// The active "team" holds just the unit whose turn it is
+	combatState := unit.CombatState()
+	cbm.ActiveTeam = &TeamInfo{
+		Team:       combatState.Team,
+		Members:    []*ecs.Entity{unit},
+		TotalSpeed: cbm.calculateTeamSpeed([]*ecs.Entity{unit}),
+		IsActive:   true,
+	}
+	cbm.ActiveUnit = unit
+	cbm.apSpentThisTurn = 0
+
+	cbm.restoreTeamActionPoints(cbm.ActiveTeam)
+
+	unitName := cbm.getEntityName(unit)
+	cbm.sendUIMessage(fmt.Sprintf("%s's turn", unitName))
+	cbm.sendLogMessage(fmt.Sprintf("Starting %s turn (CT: %d, Round %d)",
+		unitName, combatState.ChargeTime, cbm.CurrentRound))
+
+	cbm.notifyStateChange(CombatPhaseTeamTurn)
+}
+
+// advanceChargeTime ticks every living unit's CT by its Speed until one is ready
+func (cbm *TurnBasedCombatManager) advanceChargeTime() *ecs.Entity {
+	// Speeds are at least 1, so the threshold is always reached in bounded ticks
+	for tick := 0; tick <= constants.ChargeTimeThreshold; tick++ {
+		if unit := cbm.readiestUnit(); unit != nil {
+			return unit
+		}
+
+		for _, unit := range cbm.chargeTimeUnits() {
+			unit.CombatState().ChargeTime += cbm.chargeTimeSpeed(unit)
+		}
+	}
+	return nil
+}
+
+// readiestUnit returns the ready unit with the highest CT, nil if nobody is ready
+func (cbm *TurnBasedCombatManager) readiestUnit() *ecs.Entity {
+	var readiest *ecs.Entity
+	for _, unit := range cbm.chargeTimeUnits() {
+		chargeTime := unit.CombatState().ChargeTime
+		if chargeTime < constants.ChargeTimeThreshold {
+			continue
+		}
+		if readiest == nil || chargeTime > readiest.CombatState().ChargeTime {
+			readiest = unit
+		}
+	}
+	return readiest
+}
+
+// applyChargeTimeCost delays a unit's next turn based on how much AP it spent
+func (cbm *TurnBasedCombatManager) applyChargeTimeCost(unit *ecs.Entity) {
+	combatState := unit.CombatState()
+	actionPoints := unit.ActionPoints()
+	if combatState == nil || actionPoints == nil {
+		return
+	}
+
+	cost := constants.ChargeTimeBaseCost
+	if actionPoints.Maximum > 0 {
+		spent := cbm.apSpentThisTurn
+		if spent > actionPoints.Maximum {
+			spent = actionPoints.Maximum
+		}
+		cost += spent * constants.ChargeTimeActionCost / actionPoints.Maximum
+	}
+
+	combatState.ChargeTime -= cost
+	if combatState.ChargeTime < 0 {
+		combatState.ChargeTime = 0
+	}
+
+	logger.Turn("%s spent %d AP, CT reduced by %d to %d",
+		cbm.getEntityName(unit), cbm.apSpentThisTurn, cost, combatState.ChargeTime)
+}
+
+// GetTurnOrder predicts the next units to act in charge-time mode, starting with the current one
+func (cbm *TurnBasedCombatManager) GetTurnOrder(count int) []*ecs.Entity {
+	order := make([]*ecs.Entity, 0, count)
+	if cbm.TurnMode != TurnModeChargeTime || count <= 0 {
+		return order
+	}
+
+	units := cbm.chargeTimeUnits()
+	chargeTimes := make(map[*ecs.Entity]int, len(units))
+	for _, unit := range units {
+		chargeTimes[unit] = unit.CombatState().ChargeTime
+	}
+
+	// The current unit acts first and is assumed to use a full turn
+	if cbm.ActiveTeam != nil && len(cbm.ActiveTeam.Members) == 1 {
+		current := cbm.ActiveTeam.Members[0]
+		if _, alive := chargeTimes[current]; alive {
+			order = append(order, current)
+			chargeTimes[current] -= constants.ChargeTimeBaseCost + constants.ChargeTimeActionCost
+		}
+	}
+
+	for guard := 0; len(order) < count && len(units) > 0 && guard < count*constants.ChargeTimeThreshold; guard++ {
+		var next *ecs.Entity
+		for _, unit := range units {
+			if chargeTimes[unit] >= constants.ChargeTimeThreshold &&
+				(next == nil || chargeTimes[unit] > chargeTimes[next]) {
+				next = unit
+			}
+		}
+
+		if next != nil {
+			order = append(order, next)
+			chargeTimes[next] -= constants.ChargeTimeBaseCost + constants.ChargeTimeActionCost
+			continue
+		}
+
+		for _, unit := range units {
+			chargeTimes[unit] += cbm.chargeTimeSpeed(unit)
+		}
+	}
+
+	return order
+}
+
+// chargeTimeUnits returns the living units taking part in CT initiative, in initiative order
+func (cbm *TurnBasedCombatManager) chargeTimeUnits() []*ecs.Entity {
+	units := make([]*ecs.Entity, 0)
+	for _, team := range cbm.InitiativeOrder {
+		for _, member := range team.Members {
+			if cbm.isUnitAlive(member) && member.CombatState() != nil {
+				units = append(units, member)
+			}
+		}
+	}
+	return units
+}
+
+// chargeTimeSpeed returns how much CT a unit gains per tick
+func (cbm *TurnBasedCombatManager) chargeTimeSpeed(unit *ecs.Entity) int {
+	if stats := unit.RPGStats(); stats != nil {
+		if speed := stats.GetStat(components.StatSpeed); speed > 0 {
+			return speed
+		}
+	}
+	return 1
+}
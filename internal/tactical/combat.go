@@ -55,6 +55,8 @@ type TacticalCombat struct {
 	ValidMoves   []GridPos
 	ValidTargets []GridPos
 	Phase        TurnPhase
+
+	rng *rand.Rand // Random source for initiative and starting positions
 }
 
 // NewTacticalCombat creates a new tactical combat system
@@ -65,9 +67,15 @@ func NewTacticalCombat(grid *Grid) *TacticalCombat {
 		CurrentTurn: 0,
 		IsActive:    false,
 		Phase:       TurnPhaseMove,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetRNG sets the random source used for initiative and starting positions (the shared game RNG)
+func (tc *TacticalCombat) SetRNG(rng *rand.Rand) {
+	tc.rng = rng
+}
+
 // StartCombat initializes combat with all entities
 func (tc *TacticalCombat) StartCombat(entities []*ecs.Entity) {
 	tc.TurnOrder = make([]*TurnOrder, 0)
@@ -169,27 +177,15 @@ func (tc *TacticalCombat) StartCombat(entities []*ecs.Entity) {
 // calculateInitiative determines turn order based on character stats
 func (tc *TacticalCombat) calculateInitiative(stats *components.RPGStatsComponent) int {
 	// FFT-style initiative: Speed + random factor
-	baseInitiative := stats.Speed
-	// Add some randomness (you might want to use a proper random function)
-	randomFactor := time.Now().Nanosecond() % 10
+	baseInitiative := stats.GetStat(components.StatSpeed)
+	randomFactor := tc.rng.Intn(constants.InitiativeRandomRange)
 	return baseInitiative + randomFactor
 }
 
 // calculateMoveRange determines how far a unit can move
 func (tc *TacticalCombat) calculateMoveRange(stats *components.RPGStatsComponent) int {
-	// Base movement range based on job/speed
-	baseMove := 3 // Default movement range
-
-	switch stats.Job {
-	case components.JobRogue:
-		baseMove = 4 // Rogues are faster
-	case components.JobMage:
-		baseMove = 2 // Mages are slower
-	case components.JobWarrior:
-		baseMove = 3 // Balanced
-	}
-
-	return baseMove
+	// Job movement range plus equipment, skill and status modifiers
+	return stats.GetStat(components.StatMoveRange)
 }
 
 // FindStartingPosition finds an available starting position for an entity
@@ -236,7 +232,7 @@ func (tc *TacticalCombat) findRandomPosition(minX, maxX, minY, maxY int) GridPos
 	}
 
 	// Return random position from available ones
-	randomIndex := rand.Intn(len(availablePositions))
+	randomIndex := tc.rng.Intn(len(availablePositions))
 	selectedPos := availablePositions[randomIndex]
 
 	logger.Debug("Selected random position (%d,%d) from %d available positions",
@@ -99,6 +99,14 @@ func (g *Grid) SetOccupied(pos GridPos, occupied bool, unitID string) {
 	}
 }
 
+// SetTileType changes the terrain of a tile and updates its passability
+func (g *Grid) SetTileType(pos GridPos, tileType TileType) {
+	if tile := g.GetTile(pos); tile != nil {
+		tile.Type = tileType
+		tile.Passable = tileType == TileFloor || tileType == TileElevated
+	}
+}
+
 // CalculateDistance calculates movement distance between two grid positions
 func (g *Grid) CalculateDistance(from, to GridPos) int {
 	dx := int(math.Abs(float64(to.X - from.X)))
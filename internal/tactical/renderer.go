@@ -1,3 +1,5 @@
+//go:build !headless
+
 // Package tactical provides grid rendering for tactical combat mode
 package tactical
 
@@ -27,6 +29,7 @@ type GridRenderer struct {
 	HighlightColors  map[TileHighlight]color.Color
 	ShowGrid         bool
 	HighlightedTiles map[GridPos]TileHighlight
+	TerrainColors    map[TileType]color.Color
 }
 
 // NewGridRenderer creates a new grid renderer
@@ -43,6 +46,12 @@ func NewGridRenderer(grid *Grid) *GridRenderer {
 		},
 		ShowGrid:         true,
 		HighlightedTiles: make(map[GridPos]TileHighlight),
+		TerrainColors: map[TileType]color.Color{
+			TileWall:     color.RGBA{R: 90, G: 90, B: 90, A: 200},   // Dark gray
+			TileWater:    color.RGBA{R: 40, G: 90, B: 200, A: 160},  // Blue
+			TilePit:      color.RGBA{R: 20, G: 20, B: 20, A: 200},   // Black
+			TileElevated: color.RGBA{R: 150, G: 120, B: 80, A: 120}, // Brown
+		},
 	}
 }
 
@@ -83,7 +92,10 @@ func (gr *GridRenderer) ClearHighlightType(highlight TileHighlight) {
 
 // Draw renders the grid and highlights to the screen
 func (gr *GridRenderer) Draw(screen *ebiten.Image, offsetX, offsetY float64) {
-	// Draw tile highlights first (behind grid lines)
+	// Draw non-floor terrain first
+	gr.drawTerrain(screen, offsetX, offsetY)
+
+	// Draw tile highlights (behind grid lines)
 	gr.drawHighlights(screen, offsetX, offsetY)
 
 	// Draw grid lines on top
@@ -92,6 +104,22 @@ func (gr *GridRenderer) Draw(screen *ebiten.Image, offsetX, offsetY float64) {
 	}
 }
 
+// drawTerrain renders tiles whose terrain is not plain floor
+func (gr *GridRenderer) drawTerrain(screen *ebiten.Image, offsetX, offsetY float64) {
+	for pos, tile := range gr.Grid.Tiles {
+		terrainColor, exists := gr.TerrainColors[tile.Type]
+		if !exists {
+			continue
+		}
+		tileX := float32(pos.X*gr.TileSize) + float32(offsetX)
+		tileY := float32(pos.Y*gr.TileSize) + float32(offsetY)
+		vector.FillRect(screen,
+			tileX, tileY,
+			float32(gr.TileSize), float32(gr.TileSize),
+			terrainColor, false)
+	}
+}
+
 // drawHighlights renders tile highlighting
 func (gr *GridRenderer) drawHighlights(screen *ebiten.Image, offsetX, offsetY float64) {
 	for pos, highlight := range gr.HighlightedTiles {
@@ -3,7 +3,9 @@ package tactical
 
 import (
 	"fmt"
+	"math/rand"
 	"sort"
+	"time"
 
 	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
@@ -72,6 +74,7 @@ type TeamInfo struct {
 	Team         components.Team
 	Members      []*ecs.Entity
 	TotalSpeed   int
+	Initiative   int // Sum of the members' initiative, breaks speed ties
 	IsActive     bool
 	HasCompleted bool // All team members have acted this round
 }
@@ -88,6 +91,7 @@ type TurnBasedCombatManager struct {
 	Teams           []*TeamInfo
 	ActiveTeam      *TeamInfo
 	InitiativeOrder []*TeamInfo
+	TurnMode        TurnMode // Team turns (default) or individual charge-time turns
 
 	// Current Action
 	ActiveUnit    *ecs.Entity
@@ -96,6 +100,16 @@ type TurnBasedCombatManager struct {
 	// Grid and Systems
 	Grid *Grid
 
+	// Objectives
+	Objectives   *BattleObjectives
+	EndCondition *BattleCondition // Condition that ended the battle
+
+	// Scripted events and AI
+	Script            *BattleScript
+	AIProfiles        map[string]AIProfile            // AI profile by entity ID
+	UnitFactory       func(unitID string) *ecs.Entity // Creates reinforcement units for scripts
+	UnitSpawnCallback func(*ecs.Entity)               // Notifies the game of spawned units
+
 	// Callbacks for UI communication
 	MessageCallback     func(string) // For all messages (mainly logs)
 	UIMessageCallback   func(string) // For important UI messages only
@@ -104,8 +118,17 @@ type TurnBasedCombatManager struct {
 	// Debug and Logging
 	DebugMode bool
 
+	// AutoBattle lets the AI play the player team too (headless simulations)
+	AutoBattle bool
+
+	// Random source for initiative rolls
+	rng *rand.Rand
+
 	// Turn Management
 	forceEndPlayerTurn bool
+	scriptRunning      bool
+	apSpentThisTurn    int             // AP spent by the current unit (charge-time mode)
+	ctActedThisRound   map[string]bool // Units that acted this round (charge-time mode)
 }
 
 // CombatAction represents a combat action to be executed
@@ -114,6 +137,7 @@ type CombatAction struct {
 	Actor     *ecs.Entity
 	Target    *ecs.Entity
 	TargetPos GridPos
+	Skill     *components.Skill // Ability used by an ActionSkill action
 	APCost    int
 	Validated bool
 	Message   string
@@ -128,10 +152,18 @@ func NewTurnBasedCombatManager(grid *Grid) *TurnBasedCombatManager {
 		CurrentRound: 0,
 		Teams:        make([]*TeamInfo, 0),
 		Grid:         grid,
+		Objectives:   DefaultBattleObjectives(),
+		AIProfiles:   make(map[string]AIProfile),
 		DebugMode:    true, // Enable debug logging initially
+		rng:          rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// SetRNG sets the random source used for initiative rolls (the shared game RNG)
+func (cbm *TurnBasedCombatManager) SetRNG(rng *rand.Rand) {
+	cbm.rng = rng
+}
+
 // SetMessageCallback sets the callback for sending messages to UI
 func (cbm *TurnBasedCombatManager) SetMessageCallback(callback func(string)) {
 	cbm.MessageCallback = callback
@@ -147,6 +179,32 @@ func (cbm *TurnBasedCombatManager) SetStateChangeCallback(callback func(CombatPh
 	cbm.StateChangeCallback = callback
 }
 
+// SetObjectives sets the victory and defeat conditions for the next battle (nil restores defaults)
+func (cbm *TurnBasedCombatManager) SetObjectives(objectives *BattleObjectives) {
+	if objectives == nil {
+		objectives = DefaultBattleObjectives()
+	}
+	cbm.Objectives = objectives
+}
+
+// SetBattleScript sets the scripted events for the next battle, restoring terrain changed by the previous script
+func (cbm *TurnBasedCombatManager) SetBattleScript(script *BattleScript) {
+	if cbm.Script != nil {
+		cbm.Script.Restore(cbm.Grid)
+	}
+	cbm.Script = script
+}
+
+// SetUnitFactory sets the function used to create reinforcement units
+func (cbm *TurnBasedCombatManager) SetUnitFactory(factory func(unitID string) *ecs.Entity) {
+	cbm.UnitFactory = factory
+}
+
+// SetUnitSpawnCallback sets the callback invoked when a unit joins the battle mid-combat
+func (cbm *TurnBasedCombatManager) SetUnitSpawnCallback(callback func(*ecs.Entity)) {
+	cbm.UnitSpawnCallback = callback
+}
+
 // InitializeCombat sets up combat with the given entities
 func (cbm *TurnBasedCombatManager) InitializeCombat(entities []*ecs.Entity) error {
 	cbm.sendLogMessage("Initializing turn-based combat...")
@@ -160,6 +218,13 @@ func (cbm *TurnBasedCombatManager) InitializeCombat(entities []*ecs.Entity) erro
 	cbm.ActiveTeam = nil
 	cbm.ActiveUnit = nil
 	cbm.PendingAction = nil
+	cbm.EndCondition = nil
+	cbm.AIProfiles = make(map[string]AIProfile)
+	cbm.ctActedThisRound = make(map[string]bool)
+	cbm.apSpentThisTurn = 0
+	if cbm.Objectives == nil {
+		cbm.Objectives = DefaultBattleObjectives()
+	}
 
 	// Add combat components to all entities
 	for _, entity := range entities {
@@ -176,7 +241,7 @@ func (cbm *TurnBasedCombatManager) InitializeCombat(entities []*ecs.Entity) erro
 	// Update grid positions for all entities
 	for _, entity := range entities {
 		if transform := entity.Transform(); transform != nil {
-			gridPos := cbm.Grid.WorldToGrid(transform.X, transform.Y)
+			gridPos := cbm.worldToGridPos(transform.X, transform.Y)
 			cbm.Grid.SetOccupied(gridPos, true, entity.GetID())
 			logger.Debug("Grid position updated: %s at world (%.1f,%.1f) = grid (%d,%d)",
 				cbm.getEntityName(entity), transform.X, transform.Y, gridPos.X, gridPos.Y)
@@ -195,6 +260,7 @@ func (cbm *TurnBasedCombatManager) InitializeCombat(entities []*ecs.Entity) erro
 
 	cbm.sendLogMessage(fmt.Sprintf("Combat initialized with %d teams, %d total units",
 		len(cbm.Teams), len(entities)))
+	cbm.sendUIMessage(fmt.Sprintf("Objective: %s", cbm.Objectives.Victory.Description))
 
 	return nil
 }
@@ -216,8 +282,14 @@ func (cbm *TurnBasedCombatManager) initializeEntityForCombat(entity *ecs.Entity)
 		return fmt.Errorf("entity has no valid team tag")
 	}
 
-	// Add ActionPoints component based on job class
-	maxAP := cbm.getMaxAPForJob(stats.Job)
+	// Effective stats include the latest equipment and skill modifiers, abilities start ready
+	entity.RefreshStatModifiers()
+	if skillsComp := entity.Skills(); skillsComp != nil {
+		skillsComp.ResetCooldowns()
+	}
+
+	// Add ActionPoints component from the job's AP plus modifiers
+	maxAP := stats.GetStat(components.StatAP)
 	actionPoints := components.NewActionPointsComponent(maxAP)
 	entity.AddComponent(ecs.ComponentActionPoints, actionPoints)
 
@@ -232,29 +304,10 @@ func (cbm *TurnBasedCombatManager) initializeEntityForCombat(entity *ecs.Entity)
 	return nil
 }
 
-// getMaxAPForJob returns the maximum AP for a given job class
-func (cbm *TurnBasedCombatManager) getMaxAPForJob(job components.JobType) int {
-	switch job {
-	case components.JobWarrior:
-		return constants.WarriorMaxAP
-	case components.JobMage:
-		return constants.MageMaxAP
-	case components.JobRogue:
-		return constants.RogueMaxAP
-	case components.JobCleric:
-		return constants.ClericMaxAP
-	case components.JobArcher:
-		return constants.ArcherMaxAP
-	default:
-		return constants.WarriorMaxAP // Default fallback
-	}
-}
-
 // calculateEntityInitiative calculates initiative for a single entity
 func (cbm *TurnBasedCombatManager) calculateEntityInitiative(stats *components.RPGStatsComponent) int {
-	// For now, just use speed stat
-	// Future: could add randomization or other factors
-	return stats.Speed
+	// Speed plus a random bonus, so equally fast units do not always act in the same order
+	return stats.GetStat(components.StatSpeed) + cbm.rng.Intn(constants.InitiativeRandomRange)
 }
 
 // createTeams organizes entities into teams
@@ -271,16 +324,21 @@ func (cbm *TurnBasedCombatManager) createTeams(entities []*ecs.Entity) error {
 		teamMap[combatState.Team] = append(teamMap[combatState.Team], entity)
 	}
 
-	// Create TeamInfo objects
-	for team, members := range teamMap {
+	// Create TeamInfo objects, members act in initiative order
+	for _, team := range []components.Team{components.TeamPlayer, components.TeamEnemy} {
+		members := teamMap[team]
 		if len(members) == 0 {
 			continue
 		}
+		sort.SliceStable(members, func(i, j int) bool {
+			return members[i].CombatState().Initiative > members[j].CombatState().Initiative
+		})
 
 		teamInfo := &TeamInfo{
 			Team:         team,
 			Members:      members,
 			TotalSpeed:   cbm.calculateTeamSpeed(members),
+			Initiative:   cbm.calculateTeamInitiative(members),
 			IsActive:     false,
 			HasCompleted: false,
 		}
@@ -303,17 +361,31 @@ func (cbm *TurnBasedCombatManager) calculateTeamSpeed(members []*ecs.Entity) int
 	totalSpeed := 0
 	for _, member := range members {
 		if stats := member.RPGStats(); stats != nil {
-			totalSpeed += stats.Speed
+			totalSpeed += stats.GetStat(components.StatSpeed)
 		}
 	}
 	return totalSpeed
 }
 
+// calculateTeamInitiative sums the initiative of a team's members
+func (cbm *TurnBasedCombatManager) calculateTeamInitiative(members []*ecs.Entity) int {
+	total := 0
+	for _, member := range members {
+		if combatState := member.CombatState(); combatState != nil {
+			total += combatState.Initiative
+		}
+	}
+	return total
+}
+
 // calculateInitiative determines turn order based on team speeds
 func (cbm *TurnBasedCombatManager) calculateInitiative() {
-	// Sort teams by total speed (highest first)
-	sort.Slice(cbm.Teams, func(i, j int) bool {
-		return cbm.Teams[i].TotalSpeed > cbm.Teams[j].TotalSpeed
+	// Sort teams by total speed (highest first), initiative rolls break ties
+	sort.SliceStable(cbm.Teams, func(i, j int) bool {
+		if cbm.Teams[i].TotalSpeed != cbm.Teams[j].TotalSpeed {
+			return cbm.Teams[i].TotalSpeed > cbm.Teams[j].TotalSpeed
+		}
+		return cbm.Teams[i].Initiative > cbm.Teams[j].Initiative
 	})
 
 	// Set initiative order
@@ -329,6 +401,12 @@ func (cbm *TurnBasedCombatManager) calculateInitiative() {
 
 // startNextTeamTurn begins the next team's turn
 func (cbm *TurnBasedCombatManager) startNextTeamTurn() {
+	// In charge-time mode every unit takes its own turn
+	if cbm.TurnMode == TurnModeChargeTime {
+		cbm.startNextUnitTurn()
+		return
+	}
+
 	// Find next team that can act
 	var nextTeam *TeamInfo
 
@@ -375,9 +453,7 @@ func (cbm *TurnBasedCombatManager) startNextTeamTurn() {
 		nextTeam.Team.String(), cbm.CurrentRound))
 
 	// Notify UI of team change
-	if cbm.StateChangeCallback != nil {
-		cbm.StateChangeCallback(CombatPhaseTeamTurn)
-	}
+	cbm.notifyStateChange(CombatPhaseTeamTurn)
 }
 
 // restoreTeamActionPoints restores AP for all team members at turn start
@@ -393,6 +469,31 @@ func (cbm *TurnBasedCombatManager) restoreTeamActionPoints(team *TeamInfo) {
 		if stats := member.RPGStats(); stats != nil {
 			stats.ResetMovement()
 		}
+		if skillsComp := member.Skills(); skillsComp != nil {
+			skillsComp.TickCooldowns()
+		}
+		cbm.applyTurnRegeneration(member)
+	}
+}
+
+// applyTurnRegeneration heals living units with the "HP_Regen" passive effect of their learned
+// skills and equipment sets
+func (cbm *TurnBasedCombatManager) applyTurnRegeneration(member *ecs.Entity) {
+	stats := member.RPGStats()
+	if stats == nil || !stats.IsAlive() {
+		return
+	}
+	regen := 0
+	if skillsComp := member.Skills(); skillsComp != nil {
+		regen += skillsComp.GetPassiveEffect(constants.PassiveHPRegen)
+	}
+	if equipment := member.Equipment(); equipment != nil {
+		regen += equipment.GetSetPassiveEffect(constants.PassiveHPRegen)
+	}
+	if regen > 0 && stats.CurrentHP < stats.GetMaxHP() {
+		stats.Heal(regen)
+		cbm.sendLogMessage(fmt.Sprintf("%s regenerates %d HP (HP: %d/%d)",
+			stats.Name, regen, stats.CurrentHP, stats.GetMaxHP()))
 	}
 }
 
@@ -466,8 +567,8 @@ func (cbm *TurnBasedCombatManager) updateTeamTurn() error {
 		return nil
 	}
 
-	// Handle AI for enemy teams
-	if cbm.ActiveTeam.Team == components.TeamEnemy {
+	// Handle AI for enemy teams, and for the player team in auto battles
+	if cbm.ActiveTeam.Team == components.TeamEnemy || cbm.AutoBattle {
 		return cbm.processEnemyAI()
 	}
 
@@ -501,16 +602,29 @@ func (cbm *TurnBasedCombatManager) canUnitAct(entity *ecs.Entity) bool {
 	return true
 }
 
-// processEnemyAI handles AI for enemy units
+// processEnemyAI handles AI for the active team's units
 func (cbm *TurnBasedCombatManager) processEnemyAI() error {
-	// Simple AI: Find first enemy that can act and try to attack adjacent players
+	targetTeam := cbm.opposingTeam(cbm.ActiveTeam.Team)
+
+	// Simple AI: Find first enemy that can act and try to attack adjacent opponents
 	for _, enemy := range cbm.ActiveTeam.Members {
 		if !cbm.canUnitAct(enemy) {
 			continue
 		}
 
-		// Try to find adjacent player to attack
-		if target := cbm.findAdjacentTarget(enemy, components.TeamPlayer); target != nil {
+		profile := cbm.GetAIProfile(enemy)
+
+		// Passive units hold their ground without acting
+		if profile == AIProfilePassive {
+			if actionPoints := enemy.ActionPoints(); actionPoints != nil {
+				actionPoints.Current = 0
+			}
+			continue
+		}
+
+		// Try to find adjacent opponent to attack, if there is AP left for it
+		target := cbm.findAdjacentTarget(enemy, targetTeam)
+		if target != nil && enemy.ActionPoints().CanAfford(constants.AttackAPCost) {
 			// Create and execute attack action
 			action := &CombatAction{
 				Type:      ActionAttack,
@@ -524,6 +638,13 @@ func (cbm *TurnBasedCombatManager) processEnemyAI() error {
 			return cbm.ExecuteAction(action)
 		}
 
+		// Aggressive units close the distance before giving up
+		if profile == AIProfileAggressive {
+			if move := cbm.planAdvance(enemy); move != nil {
+				return cbm.ExecuteAction(move)
+			}
+		}
+
 		// No adjacent targets, end turn for this enemy
 		if actionPoints := enemy.ActionPoints(); actionPoints != nil {
 			actionPoints.Current = 0 // Exhaust AP
@@ -535,6 +656,19 @@ func (cbm *TurnBasedCombatManager) processEnemyAI() error {
 	return nil
 }
 
+// opposingTeam returns the team a unit of the given team fights against
+func (cbm *TurnBasedCombatManager) opposingTeam(team components.Team) components.Team {
+	if team == components.TeamPlayer {
+		return components.TeamEnemy
+	}
+	return components.TeamPlayer
+}
+
+// SetAutoBattle lets the AI play the player team too
+func (cbm *TurnBasedCombatManager) SetAutoBattle(enabled bool) {
+	cbm.AutoBattle = enabled
+}
+
 // findAdjacentTarget finds an adjacent enemy target
 func (cbm *TurnBasedCombatManager) findAdjacentTarget(actor *ecs.Entity, targetTeam components.Team) *ecs.Entity {
 	actorTransform := actor.Transform()
@@ -542,7 +676,7 @@ func (cbm *TurnBasedCombatManager) findAdjacentTarget(actor *ecs.Entity, targetT
 		return nil
 	}
 
-	actorPos := cbm.Grid.WorldToGrid(actorTransform.X, actorTransform.Y)
+	actorPos := cbm.worldToGridPos(actorTransform.X, actorTransform.Y)
 	neighbors := cbm.Grid.GetNeighbors(actorPos)
 
 	for _, neighborPos := range neighbors {
@@ -589,6 +723,13 @@ func (cbm *TurnBasedCombatManager) endTeamTurn() {
 		cbm.ActiveTeam.HasCompleted = true
 		cbm.ActiveTeam.IsActive = false
 
+		// Charge-time turns delay the unit's next turn by what it spent
+		if cbm.TurnMode == TurnModeChargeTime {
+			for _, member := range cbm.ActiveTeam.Members {
+				cbm.applyChargeTimeCost(member)
+			}
+		}
+
 		cbm.sendLogMessage(fmt.Sprintf("%s team turn ended", cbm.ActiveTeam.Team.String()))
 	}
 
@@ -602,8 +743,20 @@ func (cbm *TurnBasedCombatManager) changePhase(newPhase CombatPhase) {
 
 	cbm.sendLogMessage(fmt.Sprintf("Combat phase: %s -> %s", oldPhase.String(), newPhase.String()))
 
+	cbm.notifyStateChange(newPhase)
+}
+
+// notifyStateChange runs the battle script and then informs the state change callback
+func (cbm *TurnBasedCombatManager) notifyStateChange(phase CombatPhase) {
+	// Scripts run first so the UI sees any spawned units or terrain changes
+	if cbm.Script != nil && cbm.IsActive && !cbm.scriptRunning {
+		cbm.scriptRunning = true
+		cbm.Script.OnPhaseChange(cbm, phase)
+		cbm.scriptRunning = false
+	}
+
 	if cbm.StateChangeCallback != nil {
-		cbm.StateChangeCallback(newPhase)
+		cbm.StateChangeCallback(phase)
 	}
 }
 
@@ -632,6 +785,13 @@ func (cbm *TurnBasedCombatManager) updateActionExecution() error {
 	// Validate and execute the action
 	if err := cbm.executeAction(action); err != nil {
 		cbm.sendLogMessage(fmt.Sprintf("Action failed: %v", err))
+
+		// Keep AI units from retrying the same failing action every frame
+		if action.Actor != nil && (action.Actor.HasTag(ecs.TagEnemy) || cbm.AutoBattle) {
+			if actionPoints := action.Actor.ActionPoints(); actionPoints != nil {
+				actionPoints.Current = 0
+			}
+		}
 		cbm.changePhase(CombatPhaseTeamTurn)
 		return err
 	}
@@ -660,6 +820,10 @@ func (cbm *TurnBasedCombatManager) executeAction(action *CombatAction) error {
 		if err := cbm.executeAttack(action); err != nil {
 			return err
 		}
+	case ActionSkill:
+		if err := cbm.executeAbility(action); err != nil {
+			return err
+		}
 	case ActionWait:
 		// End turn action - if this is a player, end the entire team turn immediately
 		if action.Actor.HasTag(ecs.TagPlayer) {
@@ -675,6 +839,7 @@ func (cbm *TurnBasedCombatManager) executeAction(action *CombatAction) error {
 	logger.Action("Spending %d AP for %s (before: %d/%d)",
 		action.APCost, action.Actor.GetID(), actionPoints.Current, actionPoints.Maximum)
 	actionPoints.Spend(action.APCost)
+	cbm.apSpentThisTurn += action.APCost
 	logger.Action("After spending AP: %d/%d", actionPoints.Current, actionPoints.Maximum)
 
 	// Log the action
@@ -822,23 +987,30 @@ func (cbm *TurnBasedCombatManager) executeAttack(action *CombatAction) error {
 	logger.Combat("%s attacks %s", attackerStats.Name, targetStats.Name)
 
 	// Calculate damage (simple for now)
-	damage := attackerStats.Attack - targetStats.Defense
+	damage := attackerStats.GetStat(components.StatAttack) - targetStats.GetStat(components.StatDefense)
 	if damage < 1 {
 		damage = 1 // Minimum damage
 	}
 
-	// Apply damage
-	targetStats.TakeDamage(damage)
+	// Apply damage, scaled by the target affinity to the weapon element
+	element := action.Actor.WeaponElement()
+	damage, affinity := action.Target.TakeElementalDamage(damage, element)
 
 	// Send important combat result to UI
-	cbm.sendUIMessage(fmt.Sprintf("%s deals %d damage to %s (HP: %d/%d)",
-		attackerStats.Name, damage, targetStats.Name,
-		targetStats.CurrentHP, targetStats.MaxHP))
+	if damage < 0 {
+		cbm.sendUIMessage(fmt.Sprintf("%s absorbs %s's attack and recovers %d HP (HP: %d/%d)",
+			targetStats.Name, attackerStats.Name, -damage,
+			targetStats.CurrentHP, targetStats.GetMaxHP()))
+	} else {
+		cbm.sendUIMessage(fmt.Sprintf("%s deals %d damage to %s%s (HP: %d/%d)",
+			attackerStats.Name, damage, targetStats.Name, affinity.BattleLogCallout(),
+			targetStats.CurrentHP, targetStats.GetMaxHP()))
+	}
 
 	// Log detailed info to file only
-	cbm.sendLogMessage(fmt.Sprintf("Attack: %s -> %s, Damage: %d, Target HP: %d/%d",
-		attackerStats.Name, targetStats.Name, damage,
-		targetStats.CurrentHP, targetStats.MaxHP))
+	cbm.sendLogMessage(fmt.Sprintf("Attack: %s -> %s, Element: %s, Affinity: %s, Damage: %d, Target HP: %d/%d",
+		attackerStats.Name, targetStats.Name, element.String(), affinity.String(), damage,
+		targetStats.CurrentHP, targetStats.GetMaxHP()))
 
 	// Check if target died
 	if !targetStats.IsAlive() {
@@ -860,7 +1032,7 @@ func (cbm *TurnBasedCombatManager) handleUnitDeath(unit *ecs.Entity) {
 
 	// Get unit position and clear from grid
 	if transform := unit.Transform(); transform != nil {
-		gridPos := cbm.Grid.WorldToGrid(transform.X, transform.Y)
+		gridPos := cbm.worldToGridPos(transform.X, transform.Y)
 		cbm.Grid.SetOccupied(gridPos, false, "")
 		logger.Debug("Removed dead unit %s from grid position (%d,%d)",
 			cbm.getEntityName(unit), gridPos.X, gridPos.Y)
@@ -897,14 +1069,25 @@ func (cbm *TurnBasedCombatManager) updateEndTurn() error {
 
 // updateVictoryCheck checks for victory conditions
 func (cbm *TurnBasedCombatManager) updateVictoryCheck() error {
-	result := cbm.checkVictoryConditions()
+	result, condition := cbm.checkVictoryConditions()
 
 	if result != CombatResultOngoing {
 		cbm.Result = result
+		cbm.EndCondition = condition
 		cbm.changePhase(CombatPhaseEnded)
 		cbm.IsActive = false
 
-		cbm.sendLogMessage(fmt.Sprintf("Combat ended: %s", result.String()))
+		// A finished battle counts toward the duration of status effects
+		for _, team := range cbm.Teams {
+			for _, member := range team.Members {
+				if stats := member.RPGStats(); stats != nil {
+					stats.TickStatusEffects()
+				}
+			}
+		}
+
+		cbm.sendUIMessage(fmt.Sprintf("%s: %s", result.String(), condition.Description))
+		cbm.sendLogMessage(fmt.Sprintf("Combat ended: %s (%s)", result.String(), condition.Type.String()))
 		return nil
 	}
 
@@ -915,31 +1098,26 @@ func (cbm *TurnBasedCombatManager) updateVictoryCheck() error {
 	return nil
 }
 
-// checkVictoryConditions determines if combat should end
-func (cbm *TurnBasedCombatManager) checkVictoryConditions() CombatResult {
-	playerAlive := false
-	enemyAlive := false
+// checkVictoryConditions determines if combat should end and which condition ended it
+func (cbm *TurnBasedCombatManager) checkVictoryConditions() (CombatResult, *BattleCondition) {
+	objectives := cbm.Objectives
+	if objectives == nil {
+		objectives = DefaultBattleObjectives()
+	}
 
-	for _, team := range cbm.Teams {
-		for _, member := range team.Members {
-			if stats := member.RPGStats(); stats != nil && stats.IsAlive() {
-				switch team.Team {
-				case components.TeamPlayer:
-					playerAlive = true
-				case components.TeamEnemy:
-					enemyAlive = true
-				}
-			}
+	if objectives.Victory != nil {
+		if met, condition := objectives.Victory.Evaluate(cbm); met {
+			return CombatResultPlayerVictory, condition
 		}
 	}
 
-	if !playerAlive {
-		return CombatResultEnemyVictory
-	} else if !enemyAlive {
-		return CombatResultPlayerVictory
+	if objectives.Defeat != nil {
+		if met, condition := objectives.Defeat.Evaluate(cbm); met {
+			return CombatResultEnemyVictory, condition
+		}
 	}
 
-	return CombatResultOngoing
+	return CombatResultOngoing, nil
 }
 
 // Helper methods
@@ -1065,6 +1243,16 @@ func (cbm *TurnBasedCombatManager) GetCurrentRound() int {
 	return cbm.CurrentRound
 }
 
+// GetEndCondition returns the condition that ended combat, nil while ongoing
+func (cbm *TurnBasedCombatManager) GetEndCondition() *BattleCondition {
+	return cbm.EndCondition
+}
+
+// GetObjectives returns the victory and defeat conditions for the current battle
+func (cbm *TurnBasedCombatManager) GetObjectives() *BattleObjectives {
+	return cbm.Objectives
+}
+
 // GetResult returns the combat result
 func (cbm *TurnBasedCombatManager) GetResult() CombatResult {
 	return cbm.Result
@@ -1271,7 +1459,7 @@ func (cbm *TurnBasedCombatManager) GetValidAttackTargetsForUnit(actor *ecs.Entit
 
 	// First, log current positions of attacker and all potential targets (verbose only)
 	if actorTransform := actor.Transform(); actorTransform != nil {
-		actorGridPos := cbm.Grid.WorldToGrid(actorTransform.X, actorTransform.Y)
+		actorGridPos := cbm.worldToGridPos(actorTransform.X, actorTransform.Y)
 		logger.VerboseCombat("Attack range check - Attacker %s at World(%.1f,%.1f) Grid(%d,%d)",
 			actor.GetID(), actorTransform.X, actorTransform.Y, actorGridPos.X, actorGridPos.Y)
 	}
@@ -1281,7 +1469,7 @@ func (cbm *TurnBasedCombatManager) GetValidAttackTargetsForUnit(actor *ecs.Entit
 		for _, member := range team.Members {
 			// Log target position before validation (verbose only)
 			if targetTransform := member.Transform(); targetTransform != nil {
-				targetGridPos := cbm.Grid.WorldToGrid(targetTransform.X, targetTransform.Y)
+				targetGridPos := cbm.worldToGridPos(targetTransform.X, targetTransform.Y)
 				logger.VerboseCombat("Checking target %s at World(%.1f,%.1f) Grid(%d,%d)",
 					member.GetID(), targetTransform.X, targetTransform.Y, targetGridPos.X, targetGridPos.Y)
 			}
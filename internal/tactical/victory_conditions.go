@@ -0,0 +1,400 @@
+// Package tactical provides configurable victory and defeat conditions for turn-based combat
+package tactical
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+)
+
+// ConditionType identifies the kind of check a battle condition performs
+type ConditionType int
+
+const (
+	ConditionTeamDefeated  ConditionType = iota // Every member of a team has been defeated
+	ConditionDefeatUnit                         // A named unit (usually a boss) has been defeated
+	ConditionSurviveRounds                      // A number of full rounds have been completed
+	ConditionReachTile                          // A player unit is standing on a given tile
+	ConditionProtectUnit                        // A named unit has been defeated (defeat condition)
+	ConditionTurnLimit                          // The round limit has been exhausted (defeat condition)
+	ConditionAll                                // All child conditions are met (AND)
+	ConditionAny                                // Any child condition is met (OR)
+)
+
+func (ct ConditionType) String() string {
+	switch ct {
+	case ConditionTeamDefeated:
+		return "Team Defeated"
+	case ConditionDefeatUnit:
+		return "Defeat Unit"
+	case ConditionSurviveRounds:
+		return "Survive Rounds"
+	case ConditionReachTile:
+		return "Reach Tile"
+	case ConditionProtectUnit:
+		return "Protect Unit"
+	case ConditionTurnLimit:
+		return "Turn Limit"
+	case ConditionAll:
+		return "All Of"
+	case ConditionAny:
+		return "Any Of"
+	default:
+		return "Unknown"
+	}
+}
+
+// BattleCondition is a single condition or an AND/OR group of conditions
+type BattleCondition struct {
+	Type        ConditionType
+	Description string             // Text shown in the combat UI
+	Team        components.Team    // Team for ConditionTeamDefeated
+	UnitName    string             // Unit for ConditionDefeatUnit and ConditionProtectUnit
+	Rounds      int                // Round count for ConditionSurviveRounds and ConditionTurnLimit
+	Tile        GridPos            // Target tile for ConditionReachTile
+	Conditions  []*BattleCondition // Children for ConditionAll and ConditionAny
+}
+
+// BattleObjectives groups the conditions that end a battle in victory or defeat
+type BattleObjectives struct {
+	Victory *BattleCondition
+	Defeat  *BattleCondition
+}
+
+// NewTeamDefeatedCondition creates a condition met when every member of a team is down
+func NewTeamDefeatedCondition(team components.Team) *BattleCondition {
+	description := "Defeat all enemies"
+	if team == components.TeamPlayer {
+		description = "All allies defeated"
+	}
+	return &BattleCondition{Type: ConditionTeamDefeated, Team: team, Description: description}
+}
+
+// NewDefeatUnitCondition creates a condition met when the named unit is defeated
+func NewDefeatUnitCondition(unitName string) *BattleCondition {
+	return &BattleCondition{
+		Type:        ConditionDefeatUnit,
+		UnitName:    unitName,
+		Description: fmt.Sprintf("Defeat %s", unitName),
+	}
+}
+
+// NewSurviveRoundsCondition creates a condition met after the given number of rounds
+func NewSurviveRoundsCondition(rounds int) *BattleCondition {
+	return &BattleCondition{
+		Type:        ConditionSurviveRounds,
+		Rounds:      rounds,
+		Description: fmt.Sprintf("Survive %d rounds", rounds),
+	}
+}
+
+// NewReachTileCondition creates a condition met when a player unit stands on the tile
+func NewReachTileCondition(tile GridPos) *BattleCondition {
+	return &BattleCondition{
+		Type:        ConditionReachTile,
+		Tile:        tile,
+		Description: fmt.Sprintf("Reach exit (%d,%d)", tile.X, tile.Y),
+	}
+}
+
+// NewProtectUnitCondition creates a defeat condition met when the named unit falls
+func NewProtectUnitCondition(unitName string) *BattleCondition {
+	return &BattleCondition{
+		Type:        ConditionProtectUnit,
+		UnitName:    unitName,
+		Description: fmt.Sprintf("Protect %s", unitName),
+	}
+}
+
+// NewTurnLimitCondition creates a defeat condition met once the round limit is used up
+func NewTurnLimitCondition(rounds int) *BattleCondition {
+	return &BattleCondition{
+		Type:        ConditionTurnLimit,
+		Rounds:      rounds,
+		Description: fmt.Sprintf("Win within %d rounds", rounds),
+	}
+}
+
+// NewAllCondition creates a condition met when all children are met
+func NewAllCondition(conditions ...*BattleCondition) *BattleCondition {
+	return &BattleCondition{Type: ConditionAll, Conditions: conditions, Description: joinDescriptions(conditions, " and ")}
+}
+
+// NewAnyCondition creates a condition met when any child is met
+func NewAnyCondition(conditions ...*BattleCondition) *BattleCondition {
+	return &BattleCondition{Type: ConditionAny, Conditions: conditions, Description: joinDescriptions(conditions, " or ")}
+}
+
+// DefaultBattleObjectives returns the classic wipe-out objectives
+func DefaultBattleObjectives() *BattleObjectives {
+	return &BattleObjectives{
+		Victory: NewTeamDefeatedCondition(components.TeamEnemy),
+		Defeat:  NewTeamDefeatedCondition(components.TeamPlayer),
+	}
+}
+
+// NewBattleObjectivesFromSpec builds objectives from battle event data, falling back to defaults
+func NewBattleObjectivesFromSpec(victory, defeat *components.BattleConditionSpec) (*BattleObjectives, error) {
+	objectives := DefaultBattleObjectives()
+
+	if victory != nil {
+		condition, err := NewBattleConditionFromSpec(victory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid victory condition: %v", err)
+		}
+		objectives.Victory = condition
+	}
+
+	if defeat != nil {
+		condition, err := NewBattleConditionFromSpec(defeat)
+		if err != nil {
+			return nil, fmt.Errorf("invalid defeat condition: %v", err)
+		}
+		// Losing the whole party always ends the battle
+		objectives.Defeat = NewAnyCondition(NewTeamDefeatedCondition(components.TeamPlayer), condition)
+	}
+
+	return objectives, nil
+}
+
+// NewBattleConditionFromSpec converts a data-driven condition spec into a battle condition
+func NewBattleConditionFromSpec(spec *components.BattleConditionSpec) (*BattleCondition, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("condition spec is nil")
+	}
+
+	var condition *BattleCondition
+	switch spec.Type {
+	case "defeat_all":
+		condition = NewTeamDefeatedCondition(components.TeamEnemy)
+	case "party_defeated":
+		condition = NewTeamDefeatedCondition(components.TeamPlayer)
+	case "defeat_boss":
+		if spec.Target == "" {
+			return nil, fmt.Errorf("defeat_boss requires a target")
+		}
+		condition = NewDefeatUnitCondition(spec.Target)
+	case "survive_rounds":
+		if spec.Rounds <= 0 {
+			return nil, fmt.Errorf("survive_rounds requires a positive round count")
+		}
+		condition = NewSurviveRoundsCondition(spec.Rounds)
+	case "reach_tile":
+		condition = NewReachTileCondition(GridPos{X: spec.TileX, Y: spec.TileY})
+	case "protect_unit":
+		if spec.Target == "" {
+			return nil, fmt.Errorf("protect_unit requires a target")
+		}
+		condition = NewProtectUnitCondition(spec.Target)
+	case "turn_limit":
+		if spec.Rounds <= 0 {
+			return nil, fmt.Errorf("turn_limit requires a positive round count")
+		}
+		condition = NewTurnLimitCondition(spec.Rounds)
+	case "all", "any":
+		if len(spec.Conditions) == 0 {
+			return nil, fmt.Errorf("%s requires at least one child condition", spec.Type)
+		}
+		children := make([]*BattleCondition, 0, len(spec.Conditions))
+		for i := range spec.Conditions {
+			child, err := NewBattleConditionFromSpec(&spec.Conditions[i])
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		if spec.Type == "all" {
+			condition = NewAllCondition(children...)
+		} else {
+			condition = NewAnyCondition(children...)
+		}
+	default:
+		return nil, fmt.Errorf("unknown condition type: %s", spec.Type)
+	}
+
+	if spec.Description != "" {
+		condition.Description = spec.Description
+	}
+
+	return condition, nil
+}
+
+// Evaluate reports whether the condition is met and which leaf condition settled it
+func (bc *BattleCondition) Evaluate(cbm *TurnBasedCombatManager) (bool, *BattleCondition) {
+	switch bc.Type {
+	case ConditionAll:
+		for _, child := range bc.Conditions {
+			if met, _ := child.Evaluate(cbm); !met {
+				return false, nil
+			}
+		}
+		return len(bc.Conditions) > 0, bc
+	case ConditionAny:
+		for _, child := range bc.Conditions {
+			if met, reason := child.Evaluate(cbm); met {
+				return true, reason
+			}
+		}
+		return false, nil
+	}
+
+	if bc.isLeafMet(cbm) {
+		return true, bc
+	}
+	return false, nil
+}
+
+// isLeafMet evaluates a non-composite condition
+func (bc *BattleCondition) isLeafMet(cbm *TurnBasedCombatManager) bool {
+	switch bc.Type {
+	case ConditionTeamDefeated:
+		alive, _ := cbm.countTeamAlive(bc.Team)
+		return alive == 0
+	case ConditionDefeatUnit, ConditionProtectUnit:
+		unit := cbm.findUnitByName(bc.UnitName)
+		return unit != nil && !cbm.isUnitAlive(unit)
+	case ConditionSurviveRounds, ConditionTurnLimit:
+		return cbm.roundsCompleted() >= bc.Rounds
+	case ConditionReachTile:
+		return cbm.isPlayerOnTile(bc.Tile)
+	default:
+		return false
+	}
+}
+
+// Progress returns a short progress line for the combat UI
+func (bc *BattleCondition) Progress(cbm *TurnBasedCombatManager) string {
+	switch bc.Type {
+	case ConditionTeamDefeated:
+		alive, total := cbm.countTeamAlive(bc.Team)
+		return fmt.Sprintf("%s (%d/%d)", bc.Description, total-alive, total)
+	case ConditionDefeatUnit:
+		unit := cbm.findUnitByName(bc.UnitName)
+		if unit == nil {
+			return fmt.Sprintf("%s (not found)", bc.Description)
+		}
+		if stats := unit.RPGStats(); stats != nil {
+			return fmt.Sprintf("%s (HP %d/%d)", bc.Description, stats.CurrentHP, stats.GetMaxHP())
+		}
+		return bc.Description
+	case ConditionProtectUnit:
+		if unit := cbm.findUnitByName(bc.UnitName); unit != nil {
+			if stats := unit.RPGStats(); stats != nil {
+				return fmt.Sprintf("%s (HP %d/%d)", bc.Description, stats.CurrentHP, stats.GetMaxHP())
+			}
+		}
+		return bc.Description
+	case ConditionSurviveRounds, ConditionTurnLimit:
+		return fmt.Sprintf("%s (%d/%d)", bc.Description, cbm.roundsCompleted(), bc.Rounds)
+	default:
+		return bc.Description
+	}
+}
+
+// ProgressLines returns the progress of this condition and its children, one per line
+func (bc *BattleCondition) ProgressLines(cbm *TurnBasedCombatManager) []string {
+	if bc.Type != ConditionAll && bc.Type != ConditionAny {
+		return []string{bc.Progress(cbm)}
+	}
+
+	prefix := "ALL:"
+	if bc.Type == ConditionAny {
+		prefix = "ANY:"
+	}
+
+	lines := []string{prefix}
+	for _, child := range bc.Conditions {
+		for _, line := range child.ProgressLines(cbm) {
+			lines = append(lines, " "+line)
+		}
+	}
+	return lines
+}
+
+// joinDescriptions builds a composite description from child conditions
+func joinDescriptions(conditions []*BattleCondition, separator string) string {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		parts = append(parts, condition.Description)
+	}
+	return strings.Join(parts, separator)
+}
+
+// Condition helpers on the combat manager
+
+// countTeamAlive returns the number of living members and total members of a team
+func (cbm *TurnBasedCombatManager) countTeamAlive(team components.Team) (int, int) {
+	alive, total := 0, 0
+	for _, teamInfo := range cbm.Teams {
+		if teamInfo.Team != team {
+			continue
+		}
+		for _, member := range teamInfo.Members {
+			total++
+			if cbm.isUnitAlive(member) {
+				alive++
+			}
+		}
+	}
+	return alive, total
+}
+
+// findUnitByName finds a combat participant by character or entity name
+func (cbm *TurnBasedCombatManager) findUnitByName(name string) *ecs.Entity {
+	for _, team := range cbm.Teams {
+		for _, member := range team.Members {
+			if member.Name == name {
+				return member
+			}
+			if stats := member.RPGStats(); stats != nil && stats.Name == name {
+				return member
+			}
+		}
+	}
+	return nil
+}
+
+// isUnitAlive checks whether a unit still has HP
+func (cbm *TurnBasedCombatManager) isUnitAlive(unit *ecs.Entity) bool {
+	stats := unit.RPGStats()
+	return stats != nil && stats.IsAlive()
+}
+
+// isPlayerOnTile checks whether any living player unit occupies the tile
+func (cbm *TurnBasedCombatManager) isPlayerOnTile(tile GridPos) bool {
+	for _, team := range cbm.Teams {
+		if team.Team != components.TeamPlayer {
+			continue
+		}
+		for _, member := range team.Members {
+			transform := member.Transform()
+			if transform == nil || !cbm.isUnitAlive(member) {
+				continue
+			}
+			if cbm.worldToGridPos(transform.X, transform.Y) == tile {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roundsCompleted returns how many full rounds every team has finished
+func (cbm *TurnBasedCombatManager) roundsCompleted() int {
+	completed := cbm.CurrentRound - 1
+	if completed < 0 {
+		completed = 0
+	}
+
+	if len(cbm.Teams) == 0 {
+		return completed
+	}
+	for _, team := range cbm.Teams {
+		if !team.HasCompleted {
+			return completed
+		}
+	}
+	return completed + 1
+}
@@ -381,19 +381,19 @@ func (csw *CharacterStatsWidget) drawOverview(screen *ebiten.Image) {
 	currentY += StatsWidgetLineHeight * 2
 
 	// HP Bar
-	hpText := fmt.Sprintf("HP: %d/%d", char.CurrentHP, char.MaxHP)
+	hpText := fmt.Sprintf("HP: %d/%d", char.CurrentHP, char.GetMaxHP())
 	ebitenutil.DebugPrintAt(screen, hpText, leftX, currentY)
 	currentY += StatsWidgetLineHeight + 5
 	csw.drawProgressBar(screen, float32(leftX), float32(currentY), StatsWidgetBarWidth, StatsWidgetBarHeight,
-		char.CurrentHP, char.MaxHP, color.RGBA{StatsWidgetHPBarR, StatsWidgetHPBarG, StatsWidgetHPBarB, StatsWidgetHPBarA})
+		char.CurrentHP, char.GetMaxHP(), color.RGBA{StatsWidgetHPBarR, StatsWidgetHPBarG, StatsWidgetHPBarB, StatsWidgetHPBarA})
 	currentY += StatsWidgetBarHeight + StatsWidgetLineHeight
 
 	// MP Bar
-	mpText := fmt.Sprintf("MP: %d/%d", char.CurrentMP, char.MaxMP)
+	mpText := fmt.Sprintf("MP: %d/%d", char.CurrentMP, char.GetMaxMP())
 	ebitenutil.DebugPrintAt(screen, mpText, leftX, currentY)
 	currentY += StatsWidgetLineHeight + 5
 	csw.drawProgressBar(screen, float32(leftX), float32(currentY), StatsWidgetBarWidth, StatsWidgetBarHeight,
-		char.CurrentMP, char.MaxMP, color.RGBA{StatsWidgetMPBarR, StatsWidgetMPBarG, StatsWidgetMPBarB, StatsWidgetMPBarA})
+		char.CurrentMP, char.GetMaxMP(), color.RGBA{StatsWidgetMPBarR, StatsWidgetMPBarG, StatsWidgetMPBarB, StatsWidgetMPBarA})
 	currentY += StatsWidgetBarHeight + StatsWidgetLineHeight
 
 	// XP Bar
@@ -408,22 +408,22 @@ func (csw *CharacterStatsWidget) drawOverview(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Combat Stats:", rightX, currentY)
 	currentY += StatsWidgetLineHeight + 10
 
-	attackInfo := fmt.Sprintf("Attack: %d", char.Attack)
+	attackInfo := fmt.Sprintf("Attack: %s", formatStat(char, components.StatAttack))
 	ebitenutil.DebugPrintAt(screen, attackInfo, rightX, currentY)
 	currentY += StatsWidgetLineHeight
 
-	defenseInfo := fmt.Sprintf("Defense: %d", char.Defense)
+	defenseInfo := fmt.Sprintf("Defense: %s", formatStat(char, components.StatDefense))
 	ebitenutil.DebugPrintAt(screen, defenseInfo, rightX, currentY)
 	currentY += StatsWidgetLineHeight
 
-	speedInfo := fmt.Sprintf("Speed: %d", char.Speed)
+	speedInfo := fmt.Sprintf("Speed: %s", formatStat(char, components.StatSpeed))
 	ebitenutil.DebugPrintAt(screen, speedInfo, rightX, currentY)
 	currentY += StatsWidgetLineHeight * 2
 
 	ebitenutil.DebugPrintAt(screen, "Tactical Info:", rightX, currentY)
 	currentY += StatsWidgetLineHeight + 10
 
-	moveInfo := fmt.Sprintf("Move Range: %d", char.MoveRange)
+	moveInfo := fmt.Sprintf("Move Range: %s", formatStat(char, components.StatMoveRange))
 	ebitenutil.DebugPrintAt(screen, moveInfo, rightX, currentY)
 	currentY += StatsWidgetLineHeight
 
@@ -480,17 +480,17 @@ func (csw *CharacterStatsWidget) drawCoreStats(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Vitals:", leftX, currentY)
 	currentY += StatsWidgetLineHeight + 5
 
-	hpInfo := fmt.Sprintf("Health Points: %d / %d", char.CurrentHP, char.MaxHP)
+	hpInfo := fmt.Sprintf("Health Points: %d / %d", char.CurrentHP, char.GetMaxHP())
 	ebitenutil.DebugPrintAt(screen, hpInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
 
-	mpInfo := fmt.Sprintf("Mana Points: %d / %d", char.CurrentMP, char.MaxMP)
+	mpInfo := fmt.Sprintf("Mana Points: %d / %d", char.CurrentMP, char.GetMaxMP())
 	ebitenutil.DebugPrintAt(screen, mpInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
 
 	// Health/Mana percentages
-	hpPercent := float32(char.CurrentHP) / float32(char.MaxHP) * 100
-	mpPercent := float32(char.CurrentMP) / float32(char.MaxMP) * 100
+	hpPercent := float32(char.CurrentHP) / float32(char.GetMaxHP()) * 100
+	mpPercent := float32(char.CurrentMP) / float32(char.GetMaxMP()) * 100
 
 	percentInfo := fmt.Sprintf("HP: %.1f%%  MP: %.1f%%", hpPercent, mpPercent)
 	ebitenutil.DebugPrintAt(screen, percentInfo, leftX, currentY)
@@ -513,37 +513,37 @@ func (csw *CharacterStatsWidget) drawCombatStats(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Physical Combat:", leftX, currentY)
 	leftCurrentY := currentY + StatsWidgetLineHeight + 5
 
-	attackInfo := fmt.Sprintf("Attack Power: %d", char.Attack)
+	attackInfo := fmt.Sprintf("Attack Power: %s", formatStat(char, components.StatAttack))
 	ebitenutil.DebugPrintAt(screen, attackInfo, leftX, leftCurrentY)
 	leftCurrentY += StatsWidgetLineHeight
 
-	defenseInfo := fmt.Sprintf("Defense: %d", char.Defense)
+	defenseInfo := fmt.Sprintf("Defense: %s", formatStat(char, components.StatDefense))
 	ebitenutil.DebugPrintAt(screen, defenseInfo, leftX, leftCurrentY)
 	leftCurrentY += StatsWidgetLineHeight
 
-	speedInfo := fmt.Sprintf("Speed: %d", char.Speed)
+	speedInfo := fmt.Sprintf("Speed: %s", formatStat(char, components.StatSpeed))
 	ebitenutil.DebugPrintAt(screen, speedInfo, leftX, leftCurrentY)
 	leftCurrentY += StatsWidgetLineHeight * 2
 
 	ebitenutil.DebugPrintAt(screen, "Combat Chances:", leftX, leftCurrentY)
 	leftCurrentY += StatsWidgetLineHeight + 5
 
-	accuracyInfo := fmt.Sprintf("Accuracy: %d%%", char.Accuracy)
+	accuracyInfo := fmt.Sprintf("Accuracy: %s%%", formatStat(char, components.StatAccuracy))
 	ebitenutil.DebugPrintAt(screen, accuracyInfo, leftX, leftCurrentY)
 	leftCurrentY += StatsWidgetLineHeight
 
-	critInfo := fmt.Sprintf("Critical Rate: %d%%", char.CritRate)
+	critInfo := fmt.Sprintf("Critical Rate: %s%%", formatStat(char, components.StatCritRate))
 	ebitenutil.DebugPrintAt(screen, critInfo, leftX, leftCurrentY)
 
 	// Right column - Magical combat
 	ebitenutil.DebugPrintAt(screen, "Magical Combat:", rightX, currentY+StatsWidgetLineHeight+5)
 	rightCurrentY := currentY + StatsWidgetLineHeight*2 + 10
 
-	magicAttackInfo := fmt.Sprintf("Magic Attack: %d", char.MagicAttack)
+	magicAttackInfo := fmt.Sprintf("Magic Attack: %s", formatStat(char, components.StatMagicAttack))
 	ebitenutil.DebugPrintAt(screen, magicAttackInfo, rightX, rightCurrentY)
 	rightCurrentY += StatsWidgetLineHeight
 
-	magicDefenseInfo := fmt.Sprintf("Magic Defense: %d", char.MagicDefense)
+	magicDefenseInfo := fmt.Sprintf("Magic Defense: %s", formatStat(char, components.StatMagicDefense))
 	ebitenutil.DebugPrintAt(screen, magicDefenseInfo, rightX, rightCurrentY)
 	rightCurrentY += StatsWidgetLineHeight * 2
 
@@ -570,10 +570,14 @@ func (csw *CharacterStatsWidget) drawTacticalStats(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Movement:", leftX, currentY)
 	currentY += StatsWidgetLineHeight + 5
 
-	moveRangeInfo := fmt.Sprintf("Movement Range: %d tiles", char.MoveRange)
+	moveRangeInfo := fmt.Sprintf("Movement Range: %s tiles", formatStat(char, components.StatMoveRange))
 	ebitenutil.DebugPrintAt(screen, moveRangeInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
 
+	apInfo := fmt.Sprintf("Action Points: %s", formatStat(char, components.StatAP))
+	ebitenutil.DebugPrintAt(screen, apInfo, leftX, currentY)
+	currentY += StatsWidgetLineHeight
+
 	movesLeftInfo := fmt.Sprintf("Moves Remaining: %d", char.MovesRemaining)
 	ebitenutil.DebugPrintAt(screen, movesLeftInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
@@ -586,15 +590,15 @@ func (csw *CharacterStatsWidget) drawTacticalStats(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Combat Performance:", leftX, currentY)
 	currentY += StatsWidgetLineHeight + 5
 
-	speedInfo := fmt.Sprintf("Initiative (Speed): %d", char.Speed)
+	speedInfo := fmt.Sprintf("Initiative (Speed): %s", formatStat(char, components.StatSpeed))
 	ebitenutil.DebugPrintAt(screen, speedInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
 
-	accuracyInfo := fmt.Sprintf("Hit Chance: %d%%", char.Accuracy)
+	accuracyInfo := fmt.Sprintf("Hit Chance: %s%%", formatStat(char, components.StatAccuracy))
 	ebitenutil.DebugPrintAt(screen, accuracyInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight
 
-	critInfo := fmt.Sprintf("Critical Chance: %d%%", char.CritRate)
+	critInfo := fmt.Sprintf("Critical Chance: %s%%", formatStat(char, components.StatCritRate))
 	ebitenutil.DebugPrintAt(screen, critInfo, leftX, currentY)
 	currentY += StatsWidgetLineHeight * 2
 
@@ -607,6 +611,15 @@ func (csw *CharacterStatsWidget) drawTacticalStats(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, statusInfo, leftX, currentY)
 }
 
+// formatStat returns the effective stat followed by its base value and bonus when modified
+func formatStat(char *components.RPGStatsComponent, stat components.StatType) string {
+	bonus := char.GetStatBonus(stat)
+	if bonus == 0 {
+		return fmt.Sprintf("%d", char.GetStat(stat))
+	}
+	return fmt.Sprintf("%d (%d%+d)", char.GetStat(stat), char.GetBaseStat(stat), bonus)
+}
+
 // drawSectionHeader draws a colored section header
 func (csw *CharacterStatsWidget) drawSectionHeader(screen *ebiten.Image, title string, x, y int, headerColor color.RGBA) {
 	// Draw header background
@@ -11,6 +11,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/jrecuero/myrpg/internal/constants"
 	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/logger"
 	"github.com/jrecuero/myrpg/internal/tactical"
 )
@@ -23,6 +24,7 @@ const (
 	CombatUIStateSelectingAction
 	CombatUIStateSelectingMoveTarget
 	CombatUIStateSelectingAttackTarget
+	CombatUIStateSelectingAbilityTarget
 	CombatUIStateActionConfirmation
 )
 
@@ -36,6 +38,8 @@ func (cuis CombatUIState) String() string {
 		return "Selecting Move Target"
 	case CombatUIStateSelectingAttackTarget:
 		return "Selecting Attack Target"
+	case CombatUIStateSelectingAbilityTarget:
+		return "Selecting Ability Target"
 	case CombatUIStateActionConfirmation:
 		return "Action Confirmation"
 	default:
@@ -63,6 +67,11 @@ type CombatUI struct {
 	HoveredPosition    *tactical.GridPos
 	SelectedTarget     *ecs.Entity
 
+	// Ability command: usable abilities of the active unit and targets of the selected one
+	Abilities           []*components.Skill
+	SelectedAbility     int
+	ValidAbilityTargets []*ecs.Entity
+
 	// Current context for action calculations
 	CurrentCombatManager *tactical.TurnBasedCombatManager
 	CurrentActiveUnit    *ecs.Entity
@@ -86,6 +95,7 @@ type CombatUI struct {
 	OnActionSelected func(tactical.ActionType)
 	OnMoveTarget     func(tactical.GridPos)
 	OnAttackTarget   func(*ecs.Entity)
+	OnAbilityTarget  func(*components.Skill, *ecs.Entity)
 	OnCancel         func()
 }
 
@@ -131,6 +141,7 @@ func (cui *CombatUI) createActionButtons() {
 	}{
 		{tactical.ActionMove, "Move (M)", ebiten.KeyM},
 		{tactical.ActionAttack, "Attack (A)", ebiten.KeyA},
+		{tactical.ActionSkill, "Ability (S)", ebiten.KeyS},
 		{tactical.ActionWait, "End Turn (E)", ebiten.KeyE},
 	}
 
@@ -186,6 +197,8 @@ func (cui *CombatUI) Update(combatManager *tactical.TurnBasedCombatManager, acti
 		return cui.updateMoveTargetSelection(combatManager, activeUnit)
 	case CombatUIStateSelectingAttackTarget:
 		return cui.updateAttackTargetSelection(combatManager, activeUnit)
+	case CombatUIStateSelectingAbilityTarget:
+		return cui.updateAbilityTargetSelection(combatManager)
 	}
 
 	return nil
@@ -209,6 +222,8 @@ func (cui *CombatUI) updateValidActions(combatManager *tactical.TurnBasedCombatM
 			button.Enabled = len(cui.ValidMovePositions) > 0 && actionPoints.Current >= constants.MovementAPCost
 		case tactical.ActionAttack:
 			button.Enabled = len(cui.ValidAttackTargets) > 0 && actionPoints.Current >= constants.AttackAPCost
+		case tactical.ActionSkill:
+			button.Enabled = len(cui.Abilities) > 0
 		case tactical.ActionWait:
 			button.Enabled = true // End turn is always available
 		}
@@ -226,6 +241,9 @@ func (cui *CombatUI) initializeValidActions() {
 	cui.SelectedAction = 0 // Reset to no action selected
 	cui.ValidMovePositions = cui.ValidMovePositions[:0]
 	cui.ValidAttackTargets = cui.ValidAttackTargets[:0]
+	cui.ValidAbilityTargets = cui.ValidAbilityTargets[:0]
+	cui.Abilities = cui.Abilities[:0]
+	cui.SelectedAbility = 0
 	cui.HoveredPosition = nil
 	cui.SelectedTarget = nil
 
@@ -237,8 +255,9 @@ func (cui *CombatUI) initializeValidActions() {
 	if cui.CurrentCombatManager != nil && cui.CurrentActiveUnit != nil {
 		cui.ValidMovePositions = cui.CurrentCombatManager.GetValidMovesForUnit(cui.CurrentActiveUnit)
 		cui.MovesCalculatedForUnit = cui.CurrentActiveUnit
-		logger.Debug("Initialized valid actions for %s: %d moves available",
-			cui.CurrentActiveUnit.GetID(), len(cui.ValidMovePositions))
+		cui.Abilities = cui.CurrentCombatManager.GetAbilitiesForUnit(cui.CurrentActiveUnit)
+		logger.Debug("Initialized valid actions for %s: %d moves, %d abilities available",
+			cui.CurrentActiveUnit.GetID(), len(cui.ValidMovePositions), len(cui.Abilities))
 	}
 }
 
@@ -316,6 +335,10 @@ func (cui *CombatUI) selectAction(actionType tactical.ActionType) {
 			// Stay in action selection mode
 			cui.State = CombatUIStateSelectingAction
 		}
+	case tactical.ActionSkill:
+		// Targets depend on the unit's current MP, AP and cooldowns, recalculate them every time
+		cui.refreshAbilityTargets()
+		cui.State = CombatUIStateSelectingAbilityTarget
 	case tactical.ActionWait:
 		// End turn immediately
 		if cui.OnActionSelected != nil {
@@ -413,6 +436,73 @@ func (cui *CombatUI) updateAttackTargetSelection(combatManager *tactical.TurnBas
 	return nil
 }
 
+// selectedAbility returns the ability chosen in the ability list, nil if the unit has none
+func (cui *CombatUI) selectedAbility() *components.Skill {
+	if len(cui.Abilities) == 0 {
+		return nil
+	}
+	return cui.Abilities[cui.SelectedAbility%len(cui.Abilities)]
+}
+
+// refreshAbilityTargets recalculates the targets of the selected ability
+func (cui *CombatUI) refreshAbilityTargets() {
+	cui.ValidAbilityTargets = cui.ValidAbilityTargets[:0]
+	skill := cui.selectedAbility()
+	if skill == nil || cui.CurrentCombatManager == nil || cui.CurrentActiveUnit == nil {
+		return
+	}
+	cui.ValidAbilityTargets = cui.CurrentCombatManager.GetValidAbilityTargetsForUnit(cui.CurrentActiveUnit, skill)
+	logger.Debug("✅ Calculated %d valid targets of %s for %s",
+		len(cui.ValidAbilityTargets), skill.Name, cui.CurrentActiveUnit.GetID())
+}
+
+// updateAbilityTargetSelection handles ability choice and target selection
+func (cui *CombatUI) updateAbilityTargetSelection(combatManager *tactical.TurnBasedCombatManager) error {
+	// Cycle through the unit's abilities
+	if len(cui.Abilities) > 1 {
+		if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+			cui.SelectedAbility = (cui.SelectedAbility + 1) % len(cui.Abilities)
+			cui.refreshAbilityTargets()
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+			cui.SelectedAbility = (cui.SelectedAbility + len(cui.Abilities) - 1) % len(cui.Abilities)
+			cui.refreshAbilityTargets()
+		}
+	}
+
+	// Handle mouse click on grid
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		screenX, screenY := float64(x), float64(y)
+		offsetX, offsetY := float64(constants.GridOffsetX), float64(constants.GridOffsetY)
+
+		if screenX >= offsetX && screenY >= offsetY {
+			gridPos := combatManager.Grid.WorldToGrid(screenX-offsetX, screenY-offsetY)
+			for _, target := range cui.ValidAbilityTargets {
+				if transform := target.Transform(); transform != nil {
+					targetPos := combatManager.Grid.WorldToGrid(transform.X-offsetX, transform.Y-offsetY)
+					if targetPos.X == gridPos.X && targetPos.Y == gridPos.Y {
+						if cui.OnAbilityTarget != nil {
+							cui.OnAbilityTarget(cui.selectedAbility(), target)
+						}
+						cui.State = CombatUIStateNone
+						return nil
+					}
+				}
+			}
+			if len(cui.ValidAbilityTargets) > 0 {
+				logger.Info("❌ Click on a highlighted unit to use the ability (found %d valid targets)", len(cui.ValidAbilityTargets))
+			}
+		}
+	}
+
+	// Handle escape to cancel
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		cui.State = CombatUIStateSelectingAction
+	}
+
+	return nil
+}
+
 // Draw renders the combat UI
 func (cui *CombatUI) Draw(screen *ebiten.Image, combatManager *tactical.TurnBasedCombatManager, activeUnit *ecs.Entity) {
 	if combatManager == nil || !combatManager.IsPlayerTurn() {
@@ -428,10 +518,18 @@ func (cui *CombatUI) Draw(screen *ebiten.Image, combatManager *tactical.TurnBase
 		cui.drawMoveTargetSelection(screen)
 	case CombatUIStateSelectingAttackTarget:
 		cui.drawAttackTargetSelection(screen)
+	case CombatUIStateSelectingAbilityTarget:
+		cui.drawAbilityTargetSelection(screen, activeUnit)
 	}
 
 	// Draw turn information
 	cui.drawTurnInfo(screen, combatManager, activeUnit)
+
+	// Draw battle objectives
+	cui.drawObjectives(screen, combatManager)
+
+	// Draw turn order timeline (charge-time mode)
+	cui.drawTurnOrderTimeline(screen, combatManager)
 }
 
 // drawActionButtons renders the action buttons
@@ -539,6 +637,43 @@ func (cui *CombatUI) drawAttackTargetSelection(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, instruction, 10, int(instructionY))
 }
 
+// drawAbilityTargetSelection renders the selected ability and highlights its targets
+func (cui *CombatUI) drawAbilityTargetSelection(screen *ebiten.Image, activeUnit *ecs.Entity) {
+	skill := cui.selectedAbility()
+	if skill == nil {
+		return
+	}
+
+	abilityColor := color.RGBA{180, 100, 255, 150} // Purple
+	offsetX, offsetY := float64(constants.GridOffsetX), float64(constants.GridOffsetY)
+
+	for _, target := range cui.ValidAbilityTargets {
+		if transform := target.Transform(); transform != nil {
+			gridX := int((transform.X - offsetX) / constants.TileSize)
+			gridY := int((transform.Y - offsetY) / constants.TileSize)
+
+			screenX := float32(gridX*constants.TileSize) + float32(offsetX)
+			screenY := float32(gridY*constants.TileSize) + float32(offsetY)
+
+			vector.FillRect(screen, screenX, screenY, constants.TileSize, constants.TileSize, abilityColor, false)
+		}
+	}
+
+	// Ability costs, range and cooldown above the instruction text
+	abilityInfo := fmt.Sprintf("%s (%d/%d)  MP %d  AP %d  Range %d", skill.Name,
+		cui.SelectedAbility%len(cui.Abilities)+1, len(cui.Abilities),
+		tactical.AbilityMPCost(skill), tactical.AbilityAPCost(skill), tactical.AbilityRange(skill))
+	if skills := activeUnit.Skills(); skills != nil && (skill.Cooldown > 0 || skill.Charges > 0) {
+		abilityInfo += "  " + skills.GetAbilityStatus(skill)
+	}
+
+	instructionY := float32(constants.GameWorldY + constants.GameWorldHeight - 30)
+	instruction := fmt.Sprintf("Click on a purple tile to use it (%d targets), LEFT/RIGHT to change ability, ESC to cancel",
+		len(cui.ValidAbilityTargets))
+	ebitenutil.DebugPrintAt(screen, abilityInfo, 10, int(instructionY)-15)
+	ebitenutil.DebugPrintAt(screen, instruction, 10, int(instructionY))
+}
+
 // drawTurnInfo renders turn and AP information
 func (cui *CombatUI) drawTurnInfo(screen *ebiten.Image, combatManager *tactical.TurnBasedCombatManager, activeUnit *ecs.Entity) {
 	if activeUnit == nil {
@@ -582,7 +717,7 @@ func (cui *CombatUI) drawTurnInfo(screen *ebiten.Image, combatManager *tactical.
 	ebitenutil.DebugPrintAt(screen, apInfo, int(panelX+5), int(panelY+55))
 
 	// Movement info (from legacy system for verification)
-	moveInfo := fmt.Sprintf("Moves: %d/%d", stats.MovesRemaining, stats.MoveRange)
+	moveInfo := fmt.Sprintf("Moves: %d/%d", stats.MovesRemaining, stats.GetStat(components.StatMoveRange))
 	ebitenutil.DebugPrintAt(screen, moveInfo, int(panelX+5), int(panelY+70))
 
 	// Current state
@@ -590,6 +725,91 @@ func (cui *CombatUI) drawTurnInfo(screen *ebiten.Image, combatManager *tactical.
 	ebitenutil.DebugPrintAt(screen, stateInfo, int(panelX+5), int(panelY+85))
 }
 
+// drawObjectives renders the current victory/defeat objectives and their progress
+func (cui *CombatUI) drawObjectives(screen *ebiten.Image, combatManager *tactical.TurnBasedCombatManager) {
+	objectives := combatManager.GetObjectives()
+	if objectives == nil {
+		return
+	}
+
+	lines := []string{"Objective:"}
+	if objectives.Victory != nil {
+		for _, line := range objectives.Victory.ProgressLines(combatManager) {
+			lines = append(lines, " "+line)
+		}
+	}
+	if objectives.Defeat != nil {
+		lines = append(lines, "Defeat if:")
+		for _, line := range objectives.Defeat.ProgressLines(combatManager) {
+			lines = append(lines, " "+line)
+		}
+	}
+
+	// Place the panel below the action buttons
+	lineHeight := float32(14)
+	panelX := cui.ButtonAreaX
+	panelY := cui.ButtonAreaY + float32(len(cui.ActionButtons))*(cui.ButtonHeight+cui.ButtonSpacing) + 5
+	panelWidth := cui.ButtonWidth + 10
+	panelHeight := float32(len(lines))*lineHeight + 10
+
+	vector.FillRect(screen, panelX, panelY, panelWidth, panelHeight, color.RGBA{30, 30, 30, 200}, false)
+	vector.StrokeRect(screen, panelX, panelY, panelWidth, panelHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+
+	maxChars := int(panelWidth-10) / 6 // Debug font is 6px wide
+	for i, line := range lines {
+		if len(line) > maxChars {
+			line = line[:maxChars-2] + ".."
+		}
+		ebitenutil.DebugPrintAt(screen, line, int(panelX+5), int(panelY+5+float32(i)*lineHeight))
+	}
+}
+
+// drawTurnOrderTimeline renders the upcoming units in charge-time mode below the grid
+func (cui *CombatUI) drawTurnOrderTimeline(screen *ebiten.Image, combatManager *tactical.TurnBasedCombatManager) {
+	if combatManager.GetTurnMode() != tactical.TurnModeChargeTime {
+		return
+	}
+
+	order := combatManager.GetTurnOrder(8)
+	if len(order) == 0 {
+		return
+	}
+
+	entryWidth := float32(70)
+	entryHeight := float32(20)
+	startX := float32(constants.GridOffsetX)
+	startY := float32(constants.GridOffsetY) + float32(constants.GridHeight*constants.TileSize) + 10
+
+	ebitenutil.DebugPrintAt(screen, "Next:", int(startX), int(startY+4))
+	startX += 35
+
+	for i, unit := range order {
+		entryX := startX + float32(i)*(entryWidth+4)
+
+		// Player units in blue, enemies in red; the current unit is highlighted
+		entryColor := color.RGBA{50, 70, 140, 200}
+		if combatState := unit.CombatState(); combatState != nil && combatState.Team != components.TeamPlayer {
+			entryColor = color.RGBA{140, 50, 50, 200}
+		}
+		vector.FillRect(screen, entryX, startY, entryWidth, entryHeight, entryColor, false)
+
+		borderColor := color.RGBA{100, 100, 100, 255}
+		if i == 0 {
+			borderColor = color.RGBA{255, 255, 0, 255}
+		}
+		vector.StrokeRect(screen, entryX, startY, entryWidth, entryHeight, 1, borderColor, false)
+
+		name := unit.GetID()
+		if stats := unit.RPGStats(); stats != nil {
+			name = stats.Name
+		}
+		if len(name) > 10 {
+			name = name[:10]
+		}
+		ebitenutil.DebugPrintAt(screen, name, int(entryX+4), int(startY+4))
+	}
+}
+
 // Helper methods
 
 // isPointInButton checks if a point is inside a button
@@ -603,11 +823,13 @@ func (cui *CombatUI) SetCallbacks(
 	onActionSelected func(tactical.ActionType),
 	onMoveTarget func(tactical.GridPos),
 	onAttackTarget func(*ecs.Entity),
+	onAbilityTarget func(*components.Skill, *ecs.Entity),
 	onCancel func(),
 ) {
 	cui.OnActionSelected = onActionSelected
 	cui.OnMoveTarget = onMoveTarget
 	cui.OnAttackTarget = onAttackTarget
+	cui.OnAbilityTarget = onAbilityTarget
 	cui.OnCancel = onCancel
 }
 
@@ -617,6 +839,9 @@ func (cui *CombatUI) Reset() {
 	cui.SelectedAction = tactical.ActionMove // Default
 	cui.ValidMovePositions = cui.ValidMovePositions[:0]
 	cui.ValidAttackTargets = cui.ValidAttackTargets[:0]
+	cui.ValidAbilityTargets = cui.ValidAbilityTargets[:0]
+	cui.Abilities = cui.Abilities[:0]
+	cui.SelectedAbility = 0
 	cui.HoveredPosition = nil
 	cui.SelectedTarget = nil
 }
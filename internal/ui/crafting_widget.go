@@ -0,0 +1,510 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/jrecuero/myrpg/internal/crafting"
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+)
+
+// Crafting widget tabs
+const (
+	craftingTabCraft   = 0
+	craftingTabUpgrade = 1
+)
+
+// upgradeEntry is a piece of equipment the crafter can upgrade
+type upgradeEntry struct {
+	Equipment *components.Equipment     // Equipment upgraded
+	Slot      *components.InventorySlot // Inventory slot holding it, nil if equipped
+}
+
+// CraftingWidget lets a character craft known recipes and upgrade equipment
+type CraftingWidget struct {
+	// Widget properties
+	X, Y          int
+	Width, Height int
+	Visible       bool
+	Enabled       bool
+
+	registry *crafting.CraftingRegistry // Recipes and upgrade rule
+	crafter  *ecs.Entity                // Character whose inventory and equipment are used
+	wallet   crafting.Wallet            // Gold the party pays with
+	rng      *rand.Rand                 // Success rolls
+
+	selectedTab   int    // craftingTabCraft or craftingTabUpgrade
+	selectedIdx   int    // Selected row of the current tab
+	scrollOffset  int    // First visible row
+	confirming    bool   // Waiting for the attempt to be confirmed
+	statusMessage string // Result of the last attempt
+
+	// UI Layout
+	rowHeight  int
+	maxVisible int
+	listWidth  int
+
+	// Colors
+	colorBackground  color.RGBA
+	colorBorder      color.RGBA
+	colorTabActive   color.RGBA
+	colorTabInactive color.RGBA
+	colorSelected    color.RGBA
+	colorConfirm     color.RGBA
+	colorMissing     color.RGBA
+}
+
+// NewCraftingWidget creates a crafting widget for a crafter paying from a wallet
+func NewCraftingWidget(x, y, width, height int, registry *crafting.CraftingRegistry, crafter *ecs.Entity, wallet crafting.Wallet, rng *rand.Rand) *CraftingWidget {
+	return &CraftingWidget{
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Height:   height,
+		Visible:  true,
+		Enabled:  true,
+		registry: registry,
+		crafter:  crafter,
+		wallet:   wallet,
+		rng:      rng,
+
+		// Layout
+		rowHeight:  20,
+		maxVisible: (height - 130) / 20, // Header, tabs and footer take the rest
+		listWidth:  width/2 - 15,
+
+		// Colors
+		colorBackground:  color.RGBA{20, 25, 25, 245},
+		colorBorder:      color.RGBA{120, 170, 150, 255},
+		colorTabActive:   color.RGBA{40, 90, 70, 255},
+		colorTabInactive: color.RGBA{25, 35, 30, 255},
+		colorSelected:    color.RGBA{80, 100, 140, 255},
+		colorConfirm:     color.RGBA{60, 90, 60, 255},
+		colorMissing:     color.RGBA{90, 40, 40, 255},
+	}
+}
+
+// Update handles input for browsing recipes and equipment and confirming attempts
+func (cw *CraftingWidget) Update() InputResult {
+	result := NewInputResult()
+
+	if !cw.Visible || !cw.Enabled {
+		return result
+	}
+
+	// ESC cancels a pending confirmation, otherwise closes the widget
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if cw.confirming {
+			cw.confirming = false
+			cw.statusMessage = ""
+		} else {
+			cw.Visible = false
+		}
+		result.EscConsumed = true
+		return result
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if mouseX >= cw.X && mouseX <= cw.X+cw.Width && mouseY >= cw.Y && mouseY <= cw.Y+cw.Height {
+		result.MouseConsumed = true
+	}
+
+	if cw.confirming {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			cw.confirmAttempt()
+		}
+		return result
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		cw.selectedTab = (cw.selectedTab + 1) % 2
+		cw.selectedIdx = 0
+		cw.scrollOffset = 0
+		cw.statusMessage = ""
+	}
+
+	count := cw.rowCount()
+	if count == 0 {
+		return result
+	}
+	if cw.selectedIdx >= count {
+		cw.selectedIdx = count - 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) && cw.selectedIdx > 0 {
+		cw.selectedIdx--
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) && cw.selectedIdx < count-1 {
+		cw.selectedIdx++
+	}
+	cw.adjustScroll()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		cw.requestAttempt()
+	}
+
+	return result
+}
+
+// rowCount returns the rows of the current tab
+func (cw *CraftingWidget) rowCount() int {
+	if cw.selectedTab == craftingTabCraft {
+		return len(cw.registry.GetAllRecipes())
+	}
+	return len(cw.getUpgradeEntries())
+}
+
+// adjustScroll keeps the selected row visible
+func (cw *CraftingWidget) adjustScroll() {
+	if cw.selectedIdx < cw.scrollOffset {
+		cw.scrollOffset = cw.selectedIdx
+	}
+	if cw.selectedIdx >= cw.scrollOffset+cw.maxVisible {
+		cw.scrollOffset = cw.selectedIdx - cw.maxVisible + 1
+	}
+}
+
+// selectedRecipe returns the recipe under the cursor in the craft tab
+func (cw *CraftingWidget) selectedRecipe() *crafting.Recipe {
+	recipes := cw.registry.GetAllRecipes()
+	if cw.selectedTab != craftingTabCraft || cw.selectedIdx >= len(recipes) {
+		return nil
+	}
+	return recipes[cw.selectedIdx]
+}
+
+// selectedUpgrade returns the equipment under the cursor in the upgrade tab
+func (cw *CraftingWidget) selectedUpgrade() *upgradeEntry {
+	entries := cw.getUpgradeEntries()
+	if cw.selectedTab != craftingTabUpgrade || cw.selectedIdx >= len(entries) {
+		return nil
+	}
+	return entries[cw.selectedIdx]
+}
+
+// getUpgradeEntries returns the crafter's equipped gear followed by the equipment in the inventory
+func (cw *CraftingWidget) getUpgradeEntries() []*upgradeEntry {
+	entries := make([]*upgradeEntry, 0)
+	if equipment := cw.crafter.Equipment(); equipment != nil {
+		for _, equipped := range equipment.GetEquipmentList() {
+			entries = append(entries, &upgradeEntry{Equipment: equipped})
+		}
+	}
+	if inventory := cw.crafter.Inventory(); inventory != nil {
+		for i := range inventory.Slots {
+			slot := &inventory.Slots[i]
+			if slot.IsEmpty() || slot.Item.Equipment == nil {
+				continue
+			}
+			entries = append(entries, &upgradeEntry{Equipment: slot.Item.Equipment, Slot: slot})
+		}
+	}
+	return entries
+}
+
+// requestAttempt checks the selected recipe or upgrade and asks for confirmation
+func (cw *CraftingWidget) requestAttempt() {
+	inventory := cw.crafter.Inventory()
+	if inventory == nil {
+		cw.statusMessage = "No inventory"
+		return
+	}
+
+	if cw.selectedTab == craftingTabCraft {
+		recipe := cw.selectedRecipe()
+		if recipe == nil {
+			return
+		}
+		if err := recipe.CanCraft(inventory, cw.wallet); err != nil {
+			cw.statusMessage = fmt.Sprintf("Cannot craft: %v", err)
+			return
+		}
+		cw.statusMessage = fmt.Sprintf("Craft %s for %d gold (%d%%)? Enter: yes, Esc: no",
+			recipe.Name, recipe.GoldCost, recipe.SuccessRate)
+	} else {
+		rule := cw.registry.GetUpgradeRule()
+		entry := cw.selectedUpgrade()
+		if rule == nil || entry == nil {
+			return
+		}
+		if err := rule.CanUpgrade(entry.Equipment, inventory, cw.wallet); err != nil {
+			cw.statusMessage = fmt.Sprintf("Cannot upgrade: %v", err)
+			return
+		}
+		gold, _ := rule.Cost(entry.Equipment)
+		cw.statusMessage = fmt.Sprintf("Upgrade %s for %d gold (%d%%)? Enter: yes, Esc: no",
+			entry.Equipment.Name, gold, rule.SuccessRate(entry.Equipment))
+	}
+	cw.confirming = true
+}
+
+// confirmAttempt performs the confirmed craft or upgrade
+func (cw *CraftingWidget) confirmAttempt() {
+	cw.confirming = false
+	inventory := cw.crafter.Inventory()
+	if inventory == nil {
+		cw.statusMessage = "No inventory"
+		return
+	}
+
+	var result *crafting.Result
+	var err error
+	if cw.selectedTab == craftingTabCraft {
+		if recipe := cw.selectedRecipe(); recipe != nil {
+			result, err = recipe.Craft(inventory, cw.wallet, cw.rng)
+		}
+	} else if rule := cw.registry.GetUpgradeRule(); rule != nil {
+		if entry := cw.selectedUpgrade(); entry != nil {
+			result, err = rule.Upgrade(entry.Equipment, inventory, cw.wallet, cw.rng)
+			if err == nil {
+				cw.applyUpgrade(entry, result)
+			}
+		}
+	}
+
+	if err != nil {
+		cw.statusMessage = fmt.Sprintf("Failed: %v", err)
+		logger.Warn("Crafting attempt failed: %v", err)
+		return
+	}
+	if result == nil {
+		return
+	}
+	cw.statusMessage = result.Message
+	logger.Info("Crafting: %s", result.Message)
+
+	// Destroyed equipment and used up materials can remove rows
+	if count := cw.rowCount(); cw.selectedIdx >= count && count > 0 {
+		cw.selectedIdx = count - 1
+	}
+}
+
+// applyUpgrade keeps the inventory item and the crafter's stats in line with upgraded
+// equipment, removing it if the upgrade destroyed it
+func (cw *CraftingWidget) applyUpgrade(entry *upgradeEntry, result *crafting.Result) {
+	if entry.Slot != nil {
+		if result.Destroyed {
+			entry.Slot.RemoveItem(1)
+		} else {
+			entry.Slot.Item.Name = entry.Equipment.Name
+			entry.Slot.Item.InstanceID = entry.Equipment.InstanceID
+		}
+		return
+	}
+
+	if result.Destroyed {
+		if equipment := cw.crafter.Equipment(); equipment != nil {
+			equipment.Unequip(entry.Equipment.Slot)
+		}
+	}
+	cw.crafter.RefreshStatModifiers()
+}
+
+// Draw renders the crafting widget
+func (cw *CraftingWidget) Draw(screen *ebiten.Image) {
+	if !cw.Visible {
+		return
+	}
+
+	// Background and border
+	ebitenutil.DrawRect(screen, float64(cw.X), float64(cw.Y), float64(cw.Width), float64(cw.Height), cw.colorBackground)
+	ebitenutil.DrawRect(screen, float64(cw.X), float64(cw.Y), float64(cw.Width), 2, cw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(cw.X), float64(cw.Y+cw.Height-2), float64(cw.Width), 2, cw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(cw.X), float64(cw.Y), 2, float64(cw.Height), cw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(cw.X+cw.Width-2), float64(cw.Y), 2, float64(cw.Height), cw.colorBorder)
+
+	// Header: crafter and gold
+	title := "Crafting"
+	if stats := cw.crafter.RPGStats(); stats != nil {
+		title = fmt.Sprintf("Crafting - %s", stats.Name)
+	}
+	ebitenutil.DebugPrintAt(screen, title, cw.X+10, cw.Y+10)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Gold: %d", cw.wallet.GetGold()), cw.X+cw.Width-120, cw.Y+10)
+
+	// Tabs
+	tabY := cw.Y + 35
+	for i, name := range []string{"Craft", "Upgrade"} {
+		tabColor := cw.colorTabInactive
+		if i == cw.selectedTab {
+			tabColor = cw.colorTabActive
+		}
+		tabX := cw.X + 10 + i*90
+		ebitenutil.DrawRect(screen, float64(tabX), float64(tabY), 80, 20, tabColor)
+		ebitenutil.DebugPrintAt(screen, name, tabX+15, tabY+3)
+	}
+
+	listY := tabY + 30
+	detailsX := cw.X + cw.listWidth + 20
+	if cw.selectedTab == craftingTabCraft {
+		if recipe := cw.drawRecipeList(screen, listY); recipe != nil {
+			cw.drawRecipeDetails(screen, recipe, detailsX, listY)
+		}
+	} else {
+		if entry := cw.drawUpgradeList(screen, listY); entry != nil {
+			cw.drawUpgradeDetails(screen, entry, detailsX, listY)
+		}
+	}
+
+	// Footer: status and controls
+	footerY := cw.Y + cw.Height - 40
+	if cw.statusMessage != "" {
+		if cw.confirming {
+			ebitenutil.DrawRect(screen, float64(cw.X+5), float64(footerY-3), float64(cw.Width-10), 20, cw.colorConfirm)
+		}
+		ebitenutil.DebugPrintAt(screen, cw.statusMessage, cw.X+10, footerY)
+	}
+	ebitenutil.DebugPrintAt(screen, "Tab: craft/upgrade  Up/Down: select  Enter: attempt  Esc: close",
+		cw.X+10, cw.Y+cw.Height-20)
+}
+
+// drawRecipeList renders the recipes, marking those missing ingredients, and returns the
+// selected recipe
+func (cw *CraftingWidget) drawRecipeList(screen *ebiten.Image, y int) *crafting.Recipe {
+	recipes := cw.registry.GetAllRecipes()
+	if len(recipes) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No known recipes", cw.X+10, y)
+		return nil
+	}
+
+	inventory := cw.crafter.Inventory()
+	var selected *crafting.Recipe
+	for i := cw.scrollOffset; i < len(recipes) && i < cw.scrollOffset+cw.maxVisible; i++ {
+		recipe := recipes[i]
+		rowY := y + (i-cw.scrollOffset)*cw.rowHeight
+		if i == cw.selectedIdx {
+			ebitenutil.DrawRect(screen, float64(cw.X+5), float64(rowY-2), float64(cw.listWidth), float64(cw.rowHeight), cw.colorSelected)
+			selected = recipe
+		} else if inventory == nil || len(recipe.MissingIngredients(inventory)) > 0 {
+			ebitenutil.DrawRect(screen, float64(cw.X+5), float64(rowY-2), float64(cw.listWidth), float64(cw.rowHeight), cw.colorMissing)
+		}
+
+		line := fmt.Sprintf("%-20s %5dg %3d%%", recipe.Name, recipe.GoldCost, recipe.SuccessRate)
+		ebitenutil.DebugPrintAt(screen, line, cw.X+10, rowY)
+	}
+	return selected
+}
+
+// drawRecipeDetails renders the ingredients of a recipe against the crafter's inventory
+func (cw *CraftingWidget) drawRecipeDetails(screen *ebiten.Image, recipe *crafting.Recipe, x, y int) {
+	lineHeight := 15
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s x%d", recipe.Result.Name, recipe.ResultQuantity), x, y)
+	y += lineHeight
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s - %s", recipe.Result.Type.String(), recipe.Result.Rarity.String()), x, y)
+	y += 2 * lineHeight
+
+	ebitenutil.DebugPrintAt(screen, "Ingredients (owned/needed):", x, y)
+	y += lineHeight
+	inventory := cw.crafter.Inventory()
+	for _, ingredient := range recipe.Ingredients {
+		owned := 0
+		if inventory != nil {
+			owned = inventory.GetItemCount(ingredient.Item.ID)
+		}
+		mark := " "
+		if owned < ingredient.Quantity {
+			mark = "!"
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s %-18s %d/%d", mark, ingredient.Item.Name, owned, ingredient.Quantity), x+10, y)
+		y += lineHeight
+	}
+	y += lineHeight
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Cost: %d gold  Success: %d%%", recipe.GoldCost, recipe.SuccessRate), x, y)
+	y += lineHeight
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("On failure: %s", failureText(recipe.Failure)), x, y)
+}
+
+// drawUpgradeList renders the crafter's equipment and returns the selected entry
+func (cw *CraftingWidget) drawUpgradeList(screen *ebiten.Image, y int) *upgradeEntry {
+	if cw.registry.GetUpgradeRule() == nil {
+		ebitenutil.DebugPrintAt(screen, "Upgrades are not available", cw.X+10, y)
+		return nil
+	}
+	entries := cw.getUpgradeEntries()
+	if len(entries) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No equipment to upgrade", cw.X+10, y)
+		return nil
+	}
+
+	var selected *upgradeEntry
+	for i := cw.scrollOffset; i < len(entries) && i < cw.scrollOffset+cw.maxVisible; i++ {
+		entry := entries[i]
+		rowY := y + (i-cw.scrollOffset)*cw.rowHeight
+		if i == cw.selectedIdx {
+			ebitenutil.DrawRect(screen, float64(cw.X+5), float64(rowY-2), float64(cw.listWidth), float64(cw.rowHeight), cw.colorSelected)
+			selected = entry
+		}
+
+		where := "bag"
+		if entry.Slot == nil {
+			where = "equipped"
+		}
+		line := fmt.Sprintf("%-24s %s", entry.Equipment.Name, where)
+		ebitenutil.DebugPrintAt(screen, line, cw.X+10, rowY)
+	}
+	return selected
+}
+
+// drawUpgradeDetails renders the cost, success rate and stat gains of the next upgrade
+func (cw *CraftingWidget) drawUpgradeDetails(screen *ebiten.Image, entry *upgradeEntry, x, y int) {
+	lineHeight := 15
+	rule := cw.registry.GetUpgradeRule()
+	equipment := entry.Equipment
+	ebitenutil.DebugPrintAt(screen, equipment.Name, x, y)
+	y += lineHeight
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Level: +%d / +%d", equipment.UpgradeLevel, rule.MaxLevel), x, y)
+	y += 2 * lineHeight
+
+	if equipment.UpgradeLevel >= rule.MaxLevel {
+		ebitenutil.DebugPrintAt(screen, "Fully upgraded", x, y)
+		return
+	}
+
+	gold, materials := rule.Cost(equipment)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Cost: %d gold", gold), x, y)
+	y += lineHeight
+	if rule.Material != nil && materials > 0 {
+		owned := 0
+		if inventory := cw.crafter.Inventory(); inventory != nil {
+			owned = inventory.GetItemCount(rule.Material.ID)
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Material: %s %d/%d", rule.Material.Name, owned, materials), x, y)
+		y += lineHeight
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Success: %d%%", rule.SuccessRate(equipment)), x, y)
+	y += lineHeight
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("On failure: %s", failureText(rule.Failure)), x, y)
+	y += 2 * lineHeight
+
+	// Preview the stats of the next level on a copy
+	next := *equipment
+	next.Stats = equipment.Stats.Add(rule.UpgradeStep(equipment))
+	ebitenutil.DebugPrintAt(screen, "Next level:", x, y)
+	y += lineHeight
+	for _, line := range compareEquipmentStats(&next, equipment) {
+		ebitenutil.DebugPrintAt(screen, line, x+10, y)
+		y += lineHeight
+	}
+}
+
+// failureText describes a failure outcome for the player
+func failureText(outcome crafting.FailureOutcome) string {
+	switch outcome {
+	case crafting.FailureLoseHalf:
+		return "half of the materials are lost"
+	case crafting.FailureLoseMaterials:
+		return "the materials are lost"
+	case crafting.FailureDowngrade:
+		return "materials lost, drops one level"
+	case crafting.FailureDestroy:
+		return "materials lost, item destroyed"
+	default:
+		return "only the gold is lost"
+	}
+}
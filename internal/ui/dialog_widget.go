@@ -496,8 +496,8 @@ func (dw *DialogWidget) executeAction(action Action) {
 		// TODO: Integrate with audio system
 		logger.Info("Would play sound: %s", action.SoundID)
 	case "open_shop":
-		// TODO: Integrate with shop system
-		logger.Info("Would open shop: %s", action.ShopID)
+		// The shop is opened by the OnActionExecute handler
+		logger.Info("Opening shop: %s", action.ShopID)
 	case "set_flag":
 		// TODO: Integrate with game flag system
 		logger.Info("Would set flag %s = %v", action.Flag, action.Value)
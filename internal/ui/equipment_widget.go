@@ -6,6 +6,7 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -62,6 +63,14 @@ const (
 	StatComparisonArrowWidth  = 12
 	StatComparisonValueWidth  = 40
 
+	// Equipment set panel (shown while no equipment is hovered)
+	SetPanelX          = 360
+	SetPanelY          = 50
+	SetPanelWidth      = 128
+	SetPanelHeight     = 340
+	SetPanelLineHeight = 14
+	SetPanelMaxChars   = 20 // Characters that fit on a line of the panel
+
 	// Equipment details panel
 	EquipmentDetailsPanelX      = 20
 	EquipmentDetailsPanelY      = 420
@@ -355,21 +364,12 @@ func (ew *EquipmentWidget) unequipItem(slot components.EquipmentSlot) {
 
 	unequippedItem := ew.EquipmentComp.Unequip(slot)
 	if unequippedItem != nil {
+		ew.refreshStats()
+
 		// Return the unequipped item to player's inventory
 		if ew.Entity != nil && ew.Entity.Inventory() != nil {
 			// Create an inventory item for the unequipped equipment
-			inventoryItem := &components.Item{
-				ID:          unequippedItem.ID,
-				Name:        unequippedItem.Name,
-				Description: unequippedItem.Description,
-				Type:        components.ItemTypeEquipment,
-				Rarity:      components.ItemRarity(unequippedItem.Rarity),
-				Value:       unequippedItem.Value,
-				IconID:      unequippedItem.IconID,
-				Equipment:   unequippedItem,
-				Stackable:   false,
-				MaxStack:    1,
-			}
+			inventoryItem := components.NewEquipmentItem(unequippedItem)
 
 			// Try to add to inventory
 			remaining := ew.Entity.Inventory().AddItem(inventoryItem, 1)
@@ -395,6 +395,7 @@ func (ew *EquipmentWidget) equipFirstCompatibleItem(slot components.EquipmentSlo
 		if equipment.Slot == slot && ew.canEquipItem(equipment) {
 			// Equip the item
 			ew.EquipmentComp.Equip(equipment)
+			ew.refreshStats()
 
 			// Remove from available equipment (move to end and slice off)
 			ew.AvailableEquipment[i] = ew.AvailableEquipment[len(ew.AvailableEquipment)-1]
@@ -405,6 +406,13 @@ func (ew *EquipmentWidget) equipFirstCompatibleItem(slot components.EquipmentSlo
 	}
 }
 
+// refreshStats applies the equipment change to the character's effective stats
+func (ew *EquipmentWidget) refreshStats() {
+	if ew.Entity != nil {
+		ew.Entity.RefreshStatModifiers()
+	}
+}
+
 // canEquipItem checks if the character can equip the given item
 func (ew *EquipmentWidget) canEquipItem(equipment *components.Equipment) bool {
 	if ew.CharacterStats == nil {
@@ -456,9 +464,11 @@ func (ew *EquipmentWidget) Draw(screen *ebiten.Image) {
 	// Draw equipment slots
 	ew.drawEquipmentSlots(screen)
 
-	// Draw stat comparison panel (if hovering over equipment)
+	// Draw stat comparison panel (if hovering over equipment), otherwise the set progress
 	if ew.HoveredEquipment != nil {
 		ew.drawStatComparison(screen)
+	} else {
+		ew.drawSetPanel(screen)
 	}
 
 	// Draw equipment details panel
@@ -562,6 +572,81 @@ func (ew *EquipmentWidget) drawStatComparison(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, ew.HoveredEquipment.Rarity.String(), panelX+5, textY)
 }
 
+// drawSetPanel renders the progress of every equipped set and its bonuses, active ones marked
+func (ew *EquipmentWidget) drawSetPanel(screen *ebiten.Image) {
+	if ew.EquipmentComp == nil {
+		return
+	}
+	panelX := ew.X + SetPanelX
+	panelY := ew.Y + SetPanelY
+
+	panelBg := color.RGBA{40, 40, 50, 200}
+	vector.FillRect(screen,
+		float32(panelX), float32(panelY),
+		float32(SetPanelWidth), float32(SetPanelHeight),
+		panelBg, false)
+	panelBorder := color.RGBA{100, 100, 120, 255}
+	vector.StrokeRect(screen,
+		float32(panelX), float32(panelY),
+		float32(SetPanelWidth), float32(SetPanelHeight),
+		1, panelBorder, false)
+
+	textX := panelX + 5
+	textY := panelY + 5
+	ebitenutil.DebugPrintAt(screen, "Set Bonuses", textX, textY)
+	textY += SetPanelLineHeight + 4
+
+	progress := ew.EquipmentComp.GetSetProgress()
+	if len(progress) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No set equipped", textX, textY)
+		return
+	}
+
+	for _, setProgress := range progress {
+		header := fmt.Sprintf("%s %d/%d", setProgress.Set.Name, setProgress.Pieces, len(setProgress.Set.Members))
+		ebitenutil.DebugPrintAt(screen, truncateText(header, SetPanelMaxChars), textX, textY)
+		textY += SetPanelLineHeight
+
+		for _, bonus := range setProgress.Set.Bonuses {
+			mark := " "
+			if bonus.Pieces <= setProgress.Pieces {
+				mark = "*"
+			}
+			line := fmt.Sprintf("%s%d: %s", mark, bonus.Pieces, setBonusText(bonus))
+			ebitenutil.DebugPrintAt(screen, truncateText(line, SetPanelMaxChars), textX, textY)
+			textY += SetPanelLineHeight
+		}
+		textY += 4
+
+		if textY > panelY+SetPanelHeight-SetPanelLineHeight {
+			return
+		}
+	}
+}
+
+// setBonusText describes a set bonus, using its description when it has one
+func setBonusText(bonus components.SetBonus) string {
+	if bonus.Description != "" {
+		return bonus.Description
+	}
+	parts := make([]string, 0)
+	for _, modifier := range bonus.Stats.ToModifiers(components.SourceSet, "") {
+		parts = append(parts, fmt.Sprintf("%s %+d", modifier.Stat.String(), modifier.Value))
+	}
+	for _, skill := range bonus.Skills {
+		parts = append(parts, skill.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// truncateText shortens text to a number of characters, ending it with "..." when cut
+func truncateText(text string, maxChars int) string {
+	if len(text) <= maxChars {
+		return text
+	}
+	return text[:maxChars-3] + "..."
+}
+
 // drawEquipmentDetails renders detailed information about the currently selected equipment
 func (ew *EquipmentWidget) drawEquipmentDetails(screen *ebiten.Image) {
 	panelX := ew.X + EquipmentDetailsPanelX
@@ -709,6 +794,21 @@ func (ew *EquipmentWidget) drawEquipmentInfo(screen *ebiten.Image, equipment *co
 	} else {
 		ebitenutil.DebugPrintAt(screen, "Stats: None", startX, textY)
 	}
+	textY += EquipmentDetailsLineHeight + 2
+
+	// Set membership and the pieces of the set currently equipped
+	if set, exists := components.GetSetRegistry().GetSet(equipment.SetID); exists {
+		pieces := 0
+		if ew.EquipmentComp != nil {
+			for _, progress := range ew.EquipmentComp.GetSetProgress() {
+				if progress.Set == set {
+					pieces = progress.Pieces
+				}
+			}
+		}
+		setText := fmt.Sprintf("Set: %s (%d/%d equipped)", set.Name, pieces, len(set.Members))
+		ebitenutil.DebugPrintAt(screen, setText, startX, textY)
+	}
 }
 
 // drawHelpText renders the help text at the bottom of the widget
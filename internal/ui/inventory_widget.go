@@ -429,18 +429,7 @@ func (iw *InventoryWidget) tryEquipItem(slot *InventorySlot) {
 		unequipped := equipmentComp.Unequip(equipSlot)
 		if unequipped != nil {
 			// Create an inventory item for the unequipped equipment
-			unequippedItem := &components.Item{
-				ID:          unequipped.ID,
-				Name:        unequipped.Name,
-				Description: unequipped.Description,
-				Type:        components.ItemTypeEquipment,
-				Rarity:      components.ItemRarity(unequipped.Rarity),
-				Value:       unequipped.Value,
-				IconID:      unequipped.IconID,
-				Equipment:   unequipped,
-				Stackable:   false,
-				MaxStack:    1,
-			}
+			unequippedItem := components.NewEquipmentItem(unequipped)
 
 			// Try to add unequipped item back to inventory
 			remaining := iw.inventory.AddItem(unequippedItem, 1)
@@ -454,9 +443,10 @@ func (iw *InventoryWidget) tryEquipItem(slot *InventorySlot) {
 
 	// Equip the new item
 	equipmentComp.Equip(equipment)
+	iw.entity.RefreshStatModifiers()
 
 	// Remove the item from inventory
-	iw.inventory.RemoveItem(slot.Item.ID, 1)
+	iw.inventory.RemoveInstance(slot.Item, 1)
 
 	// Update the slot display to reflect changes
 	iw.initializeSlots()
@@ -953,6 +943,15 @@ func (iw *InventoryWidget) drawTooltip(screen *ebiten.Image) {
 		}
 	}
 
+	// Add rolled affixes of generated equipment
+	if item.Equipment != nil && len(item.Equipment.Affixes) > 0 {
+		contentLines = append(contentLines, "")
+		contentLines = append(contentLines, "Affixes:")
+		for _, affix := range item.Equipment.Affixes {
+			contentLines = append(contentLines, iw.wrapText("  "+affix.Description(), maxLineChars)...)
+		}
+	}
+
 	// Add stackable information
 	if item.Stackable {
 		contentLines = append(contentLines, "")
@@ -144,6 +144,10 @@ func (p *PopupSelectionWidget) Show(title string, options []string) {
 	p.ScrollOffset = 0
 	p.updateMaxVisibleItems()
 	p.ensureSelectedVisible()
+
+	// Ignore keys still held from the action that opened the popup
+	p.lastEnterPressed = ebiten.IsKeyPressed(ebiten.KeyEnter) || ebiten.IsKeyPressed(ebiten.KeySpace)
+	p.lastEscapePressed = ebiten.IsKeyPressed(ebiten.KeyEscape)
 }
 
 // Hide closes the popup
@@ -180,21 +184,21 @@ func (p *PopupSelectionWidget) Update() bool {
 		}
 	}
 
-	// Select current option
+	// Select current option; hide first so the callback can open another popup
 	if enterPressed && !p.lastEnterPressed {
+		p.Hide()
 		if p.OnSelection != nil {
 			p.OnSelection(p.SelectedIndex, p.Options[p.SelectedIndex])
 		}
-		p.Hide()
 	}
 
 	// Cancel selection
 	escConsumed := false
 	if escapePressed && !p.lastEscapePressed {
+		p.Hide()
 		if p.OnCancel != nil {
 			p.OnCancel()
 		}
-		p.Hide()
 		escConsumed = true
 	}
 
@@ -0,0 +1,539 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/jrecuero/myrpg/internal/ecs"
+	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/shop"
+)
+
+// Shop widget tabs
+const (
+	shopTabBuy  = 0
+	shopTabSell = 1
+)
+
+// shopSellEntry is an inventory item the customer can offer to the shop
+type shopSellEntry struct {
+	Item     *components.Item // Item in the inventory
+	Quantity int              // Total quantity owned
+}
+
+// ShopWidget lets the party browse a shop, compare equipment and confirm purchases and sales
+type ShopWidget struct {
+	// Widget properties
+	X, Y          int
+	Width, Height int
+	Visible       bool
+	Enabled       bool
+
+	shop     *shop.Shop       // Shop being browsed
+	customer *ecs.Entity      // Character whose inventory and equipment are used
+	wallet   shop.Wallet      // Gold the party pays with
+	now      func() time.Time // Clock of the restock timers
+
+	selectedTab   int    // shopTabBuy or shopTabSell
+	selectedIdx   int    // Selected row of the current tab
+	scrollOffset  int    // First visible row
+	quantity      int    // Units of the selected row to buy or sell
+	confirming    bool   // Waiting for the transaction to be confirmed
+	statusMessage string // Result of the last transaction
+
+	// UI Layout
+	rowHeight  int
+	maxVisible int
+	listWidth  int
+
+	// Colors
+	colorBackground  color.RGBA
+	colorBorder      color.RGBA
+	colorTabActive   color.RGBA
+	colorTabInactive color.RGBA
+	colorSelected    color.RGBA
+	colorConfirm     color.RGBA
+}
+
+// NewShopWidget creates a shop widget for a customer paying from a wallet
+func NewShopWidget(x, y, width, height int, s *shop.Shop, customer *ecs.Entity, wallet shop.Wallet) *ShopWidget {
+	widget := &ShopWidget{
+		X:        x,
+		Y:        y,
+		Width:    width,
+		Height:   height,
+		Visible:  true,
+		Enabled:  true,
+		shop:     s,
+		customer: customer,
+		wallet:   wallet,
+		now:      time.Now,
+		quantity: 1,
+
+		// Layout
+		rowHeight:  20,
+		maxVisible: (height - 130) / 20, // Header, tabs and footer take the rest
+		listWidth:  width/2 - 15,
+
+		// Colors
+		colorBackground:  color.RGBA{20, 20, 30, 245},
+		colorBorder:      color.RGBA{180, 160, 100, 255},
+		colorTabActive:   color.RGBA{90, 70, 30, 255},
+		colorTabInactive: color.RGBA{35, 30, 25, 255},
+		colorSelected:    color.RGBA{80, 100, 140, 255},
+		colorConfirm:     color.RGBA{60, 90, 60, 255},
+	}
+
+	s.Update(widget.now())
+	return widget
+}
+
+// Update handles input for browsing and trading
+func (sw *ShopWidget) Update() InputResult {
+	result := NewInputResult()
+
+	if !sw.Visible || !sw.Enabled {
+		return result
+	}
+	sw.shop.Update(sw.now())
+
+	// ESC cancels a pending confirmation, otherwise closes the shop
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		if sw.confirming {
+			sw.confirming = false
+			sw.statusMessage = ""
+		} else {
+			sw.Visible = false
+		}
+		result.EscConsumed = true
+		return result
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if mouseX >= sw.X && mouseX <= sw.X+sw.Width && mouseY >= sw.Y && mouseY <= sw.Y+sw.Height {
+		result.MouseConsumed = true
+	}
+
+	if sw.confirming {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			sw.confirmTransaction()
+		}
+		return result
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		sw.selectedTab = (sw.selectedTab + 1) % 2
+		sw.selectedIdx = 0
+		sw.scrollOffset = 0
+		sw.quantity = 1
+		sw.statusMessage = ""
+	}
+
+	count := sw.rowCount()
+	if count == 0 {
+		return result
+	}
+	if sw.selectedIdx >= count {
+		sw.selectedIdx = count - 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowUp) && sw.selectedIdx > 0 {
+		sw.selectedIdx--
+		sw.quantity = 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowDown) && sw.selectedIdx < count-1 {
+		sw.selectedIdx++
+		sw.quantity = 1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) && sw.quantity > 1 {
+		sw.quantity--
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) && sw.quantity < sw.maxQuantity() {
+		sw.quantity++
+	}
+	sw.adjustScroll()
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		sw.requestTransaction()
+	}
+
+	return result
+}
+
+// rowCount returns the rows of the current tab
+func (sw *ShopWidget) rowCount() int {
+	if sw.selectedTab == shopTabBuy {
+		return len(sw.shop.Stock)
+	}
+	return len(sw.getSellEntries())
+}
+
+// adjustScroll keeps the selected row visible
+func (sw *ShopWidget) adjustScroll() {
+	if sw.selectedIdx < sw.scrollOffset {
+		sw.scrollOffset = sw.selectedIdx
+	}
+	if sw.selectedIdx >= sw.scrollOffset+sw.maxVisible {
+		sw.scrollOffset = sw.selectedIdx - sw.maxVisible + 1
+	}
+}
+
+// maxQuantity returns the most units of the selected row that can be traded at once
+func (sw *ShopWidget) maxQuantity() int {
+	if sw.selectedTab == shopTabBuy {
+		entry := sw.selectedStock()
+		if entry == nil {
+			return 1
+		}
+		limit := entry.Item.MaxStack
+		if !entry.Item.Stackable || limit <= 0 {
+			limit = 1
+		}
+		if !entry.IsUnlimited() && entry.Quantity < limit {
+			limit = entry.Quantity
+		}
+		if limit < 1 {
+			limit = 1
+		}
+		return limit
+	}
+
+	if entry := sw.selectedSellEntry(); entry != nil {
+		return entry.Quantity
+	}
+	return 1
+}
+
+// selectedStock returns the stock entry under the cursor in the buy tab
+func (sw *ShopWidget) selectedStock() *shop.StockEntry {
+	if sw.selectedTab != shopTabBuy || sw.selectedIdx >= len(sw.shop.Stock) {
+		return nil
+	}
+	return sw.shop.Stock[sw.selectedIdx]
+}
+
+// selectedSellEntry returns the inventory item under the cursor in the sell tab
+func (sw *ShopWidget) selectedSellEntry() *shopSellEntry {
+	entries := sw.getSellEntries()
+	if sw.selectedTab != shopTabSell || sw.selectedIdx >= len(entries) {
+		return nil
+	}
+	return entries[sw.selectedIdx]
+}
+
+// getSellEntries returns the customer's items, one entry per registry item and one per
+// item instance
+func (sw *ShopWidget) getSellEntries() []*shopSellEntry {
+	entries := make([]*shopSellEntry, 0)
+	inventory := sw.customer.Inventory()
+	if inventory == nil {
+		return entries
+	}
+
+	byID := make(map[[2]int]*shopSellEntry)
+	for i := range inventory.Slots {
+		slot := &inventory.Slots[i]
+		if slot.IsEmpty() {
+			continue
+		}
+		key := [2]int{slot.Item.ID, slot.Item.InstanceID}
+		if entry, exists := byID[key]; exists {
+			entry.Quantity += slot.Quantity
+			continue
+		}
+		entry := &shopSellEntry{Item: slot.Item, Quantity: slot.Quantity}
+		byID[key] = entry
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// requestTransaction checks the selected trade and asks for confirmation
+func (sw *ShopWidget) requestTransaction() {
+	if sw.selectedTab == shopTabBuy {
+		entry := sw.selectedStock()
+		if entry == nil {
+			return
+		}
+		if entry.IsSoldOut() {
+			sw.statusMessage = fmt.Sprintf("%s is sold out", entry.Item.Name)
+			return
+		}
+		cost := sw.shop.BuyPrice(entry.Item) * sw.quantity
+		if sw.wallet.GetGold() < cost {
+			sw.statusMessage = fmt.Sprintf("Not enough gold (%d needed)", cost)
+			return
+		}
+		sw.statusMessage = fmt.Sprintf("Buy %d x %s for %d gold? Enter: yes, Esc: no", sw.quantity, entry.Item.Name, cost)
+	} else {
+		entry := sw.selectedSellEntry()
+		if entry == nil {
+			return
+		}
+		if err := sw.shop.CanSell(entry.Item); err != nil {
+			sw.statusMessage = err.Error()
+			return
+		}
+		sw.statusMessage = fmt.Sprintf("Sell %d x %s for %d gold? Enter: yes, Esc: no",
+			sw.quantity, entry.Item.Name, sw.shop.SellPrice(entry.Item)*sw.quantity)
+	}
+	sw.confirming = true
+}
+
+// confirmTransaction performs the confirmed trade
+func (sw *ShopWidget) confirmTransaction() {
+	sw.confirming = false
+	inventory := sw.customer.Inventory()
+	if inventory == nil {
+		sw.statusMessage = "No inventory"
+		return
+	}
+
+	var err error
+	var done string
+	if sw.selectedTab == shopTabBuy {
+		if entry := sw.selectedStock(); entry != nil {
+			err = sw.shop.Buy(entry, sw.quantity, sw.wallet, inventory)
+			done = fmt.Sprintf("Bought %d x %s", sw.quantity, entry.Item.Name)
+		}
+	} else {
+		if entry := sw.selectedSellEntry(); entry != nil {
+			err = sw.shop.Sell(entry.Item, sw.quantity, sw.wallet, inventory)
+			done = fmt.Sprintf("Sold %d x %s", sw.quantity, entry.Item.Name)
+		}
+	}
+
+	if err != nil {
+		sw.statusMessage = fmt.Sprintf("Cannot trade: %v", err)
+		logger.Warn("Shop %s trade failed: %v", sw.shop.ID, err)
+		return
+	}
+	sw.statusMessage = done
+	logger.Info("Shop %s: %s", sw.shop.ID, done)
+
+	// Selling the last units removes the row
+	sw.quantity = 1
+	if count := sw.rowCount(); sw.selectedIdx >= count && count > 0 {
+		sw.selectedIdx = count - 1
+	}
+}
+
+// Draw renders the shop
+func (sw *ShopWidget) Draw(screen *ebiten.Image) {
+	if !sw.Visible {
+		return
+	}
+
+	// Background and border
+	ebitenutil.DrawRect(screen, float64(sw.X), float64(sw.Y), float64(sw.Width), float64(sw.Height), sw.colorBackground)
+	ebitenutil.DrawRect(screen, float64(sw.X), float64(sw.Y), float64(sw.Width), 2, sw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(sw.X), float64(sw.Y+sw.Height-2), float64(sw.Width), 2, sw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(sw.X), float64(sw.Y), 2, float64(sw.Height), sw.colorBorder)
+	ebitenutil.DrawRect(screen, float64(sw.X+sw.Width-2), float64(sw.Y), 2, float64(sw.Height), sw.colorBorder)
+
+	// Header: shop name, gold and restock timer
+	ebitenutil.DebugPrintAt(screen, sw.shop.Name, sw.X+10, sw.Y+10)
+	goldText := fmt.Sprintf("Gold: %d", sw.wallet.GetGold())
+	ebitenutil.DebugPrintAt(screen, goldText, sw.X+sw.Width-120, sw.Y+10)
+	if left := sw.shop.TimeToRestock(sw.now()); left > 0 {
+		restockText := fmt.Sprintf("Restock in %d:%02d", int(left.Minutes()), int(left.Seconds())%60)
+		ebitenutil.DebugPrintAt(screen, restockText, sw.X+sw.Width-270, sw.Y+10)
+	}
+
+	// Tabs
+	tabY := sw.Y + 35
+	for i, name := range []string{"Buy", "Sell"} {
+		tabColor := sw.colorTabInactive
+		if i == sw.selectedTab {
+			tabColor = sw.colorTabActive
+		}
+		tabX := sw.X + 10 + i*90
+		ebitenutil.DrawRect(screen, float64(tabX), float64(tabY), 80, 20, tabColor)
+		ebitenutil.DebugPrintAt(screen, name, tabX+25, tabY+3)
+	}
+
+	// Item list and details of the selected item
+	listY := tabY + 30
+	var selected *components.Item
+	if sw.selectedTab == shopTabBuy {
+		selected = sw.drawBuyList(screen, listY)
+	} else {
+		selected = sw.drawSellList(screen, listY)
+	}
+	if selected != nil {
+		sw.drawItemDetails(screen, selected, sw.X+sw.listWidth+20, listY)
+	}
+
+	// Footer: status and controls
+	footerY := sw.Y + sw.Height - 40
+	if sw.statusMessage != "" {
+		if sw.confirming {
+			ebitenutil.DrawRect(screen, float64(sw.X+5), float64(footerY-3), float64(sw.Width-10), 20, sw.colorConfirm)
+		}
+		ebitenutil.DebugPrintAt(screen, sw.statusMessage, sw.X+10, footerY)
+	}
+	ebitenutil.DebugPrintAt(screen, "Tab: buy/sell  Up/Down: select  Left/Right: quantity  Enter: trade  Esc: leave",
+		sw.X+10, sw.Y+sw.Height-20)
+}
+
+// drawBuyList renders the shop stock and returns the selected item
+func (sw *ShopWidget) drawBuyList(screen *ebiten.Image, y int) *components.Item {
+	var selected *components.Item
+	for i := sw.scrollOffset; i < len(sw.shop.Stock) && i < sw.scrollOffset+sw.maxVisible; i++ {
+		entry := sw.shop.Stock[i]
+		rowY := y + (i-sw.scrollOffset)*sw.rowHeight
+		if i == sw.selectedIdx {
+			ebitenutil.DrawRect(screen, float64(sw.X+5), float64(rowY-2), float64(sw.listWidth), float64(sw.rowHeight), sw.colorSelected)
+			selected = entry.Item
+		}
+
+		stockText := "--"
+		if !entry.IsUnlimited() {
+			stockText = fmt.Sprintf("x%d", entry.Quantity)
+		}
+		if entry.IsSoldOut() {
+			stockText = "sold out"
+		}
+		line := fmt.Sprintf("%-20s %5dg %s", entry.Item.Name, sw.shop.BuyPrice(entry.Item), stockText)
+		ebitenutil.DebugPrintAt(screen, line, sw.X+10, rowY)
+	}
+	return selected
+}
+
+// drawSellList renders the customer's items with their sell price and returns the selected item
+func (sw *ShopWidget) drawSellList(screen *ebiten.Image, y int) *components.Item {
+	entries := sw.getSellEntries()
+	if len(entries) == 0 {
+		ebitenutil.DebugPrintAt(screen, "Nothing to sell", sw.X+10, y)
+		return nil
+	}
+
+	var selected *components.Item
+	for i := sw.scrollOffset; i < len(entries) && i < sw.scrollOffset+sw.maxVisible; i++ {
+		entry := entries[i]
+		rowY := y + (i-sw.scrollOffset)*sw.rowHeight
+		if i == sw.selectedIdx {
+			ebitenutil.DrawRect(screen, float64(sw.X+5), float64(rowY-2), float64(sw.listWidth), float64(sw.rowHeight), sw.colorSelected)
+			selected = entry.Item
+		}
+
+		priceText := "   --"
+		if sw.shop.CanSell(entry.Item) == nil {
+			priceText = fmt.Sprintf("%5dg", sw.shop.SellPrice(entry.Item))
+		}
+		line := fmt.Sprintf("%-20s %s x%d", entry.Item.Name, priceText, entry.Quantity)
+		ebitenutil.DebugPrintAt(screen, line, sw.X+10, rowY)
+	}
+	return selected
+}
+
+// drawItemDetails renders the selected item, the trade quantity and, for equipment, the
+// comparison with what the customer has equipped in the same slot
+func (sw *ShopWidget) drawItemDetails(screen *ebiten.Image, item *components.Item, x, y int) {
+	lineHeight := 15
+	ebitenutil.DebugPrintAt(screen, item.Name, x, y)
+	y += lineHeight
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s - %s", item.Type.String(), item.Rarity.String()), x, y)
+	y += lineHeight
+	for _, line := range sw.wrapText(item.Description, 45) {
+		ebitenutil.DebugPrintAt(screen, line, x, y)
+		y += lineHeight
+	}
+	y += lineHeight
+
+	if sw.selectedTab == shopTabBuy {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Quantity: %d  Total: %d gold", sw.quantity, sw.shop.BuyPrice(item)*sw.quantity), x, y)
+	} else if sw.shop.CanSell(item) == nil {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Quantity: %d  Total: %d gold", sw.quantity, sw.shop.SellPrice(item)*sw.quantity), x, y)
+	}
+	y += 2 * lineHeight
+
+	if item.Equipment == nil {
+		return
+	}
+	if stats := sw.customer.RPGStats(); stats != nil && !item.Equipment.CanEquip(stats.Level, stats.Job) {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s cannot equip this", stats.Name), x, y)
+		y += lineHeight
+	}
+
+	var equipped *components.Equipment
+	if equipment := sw.customer.Equipment(); equipment != nil {
+		equipped = equipment.GetEquipped(item.Equipment.Slot)
+	}
+	if equipped == nil {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Compared to: (empty %s)", item.Equipment.Slot.String()), x, y)
+	} else {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Compared to: %s", equipped.Name), x, y)
+	}
+	y += lineHeight
+	for _, line := range compareEquipmentStats(item.Equipment, equipped) {
+		ebitenutil.DebugPrintAt(screen, line, x+10, y)
+		y += lineHeight
+	}
+}
+
+// wrapText wraps text at spaces to fit within a character width
+func (sw *ShopWidget) wrapText(text string, maxWidth int) []string {
+	var lines []string
+	for len(text) > maxWidth {
+		breakPoint := maxWidth
+		for breakPoint > 0 && text[breakPoint] != ' ' {
+			breakPoint--
+		}
+		if breakPoint == 0 {
+			breakPoint = maxWidth
+		}
+		lines = append(lines, text[:breakPoint])
+		text = strings.TrimPrefix(text[breakPoint:], " ")
+	}
+	if len(text) > 0 {
+		lines = append(lines, text)
+	}
+	return lines
+}
+
+// compareEquipmentStats returns the stat differences of a piece of equipment against the
+// equipped one (nil = empty slot)
+func compareEquipmentStats(candidate, equipped *components.Equipment) []string {
+	var current components.EquipmentStats
+	if equipped != nil {
+		current = equipped.Stats
+	}
+	next := candidate.Stats
+
+	stats := []struct {
+		name       string
+		next, curr int
+	}{
+		{"Attack", next.AttackBonus, current.AttackBonus},
+		{"Defense", next.DefenseBonus, current.DefenseBonus},
+		{"Magic Power", next.MagicPowerBonus, current.MagicPowerBonus},
+		{"Magic Defense", next.MagicDefBonus, current.MagicDefBonus},
+		{"Speed", next.SpeedBonus, current.SpeedBonus},
+		{"Max HP", next.HPBonus, current.HPBonus},
+		{"Max MP", next.MPBonus, current.MPBonus},
+		{"Crit Chance", next.CritChanceBonus, current.CritChanceBonus},
+		{"Crit Damage", next.CritDamageBonus, current.CritDamageBonus},
+		{"Accuracy", next.AccuracyBonus, current.AccuracyBonus},
+		{"Evasion", next.EvasionBonus, current.EvasionBonus},
+		{"Movement", next.MovementBonus, current.MovementBonus},
+		{"AP", next.APBonus, current.APBonus},
+	}
+
+	lines := make([]string, 0)
+	for _, stat := range stats {
+		if diff := stat.next - stat.curr; diff != 0 {
+			lines = append(lines, fmt.Sprintf("%s: %d (%+d)", stat.name, stat.next, diff))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No stat changes")
+	}
+	return lines
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jrecuero/myrpg/internal/ecs"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
+	"github.com/jrecuero/myrpg/internal/logger"
 	"github.com/jrecuero/myrpg/internal/skills"
 )
 
@@ -42,6 +43,11 @@ type SkillsWidget struct {
 	tooltipX        int
 	tooltipY        int
 
+	// Skill refunds
+	respecHandler func(entity *ecs.Entity, skill *components.Skill) error // Pays for and performs refunds (nil = no refunds)
+	confirmReset  bool                                                    // Reset button clicked once, waiting for confirmation
+	statusMessage string                                                  // Result of the last refund
+
 	// Layout constants
 	nodeWidth    int
 	nodeHeight   int
@@ -193,15 +199,34 @@ func (sw *SkillsWidget) Update() InputResult {
 				sw.tooltipY = mouseY + 10
 				sw.selectedSkill = node.Skill
 
-				// Handle click to learn skill
+				// Handle click to learn skill, right-click to refund it
 				if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 					if node.IsAvailable && !node.IsLearned {
 						sw.learnSkill(node.Skill)
 					}
 				}
+				if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && node.IsLearned && sw.respecHandler != nil {
+					sw.respec(node.Skill)
+				}
 				break
 			}
 		}
+
+		// Handle the reset button, which needs a second click to confirm
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && sw.respecHandler != nil {
+			bx, by, bw, bh := sw.resetButtonBounds()
+			if mouseX >= bx && mouseX <= bx+bw && mouseY >= by && mouseY <= by+bh {
+				if sw.confirmReset {
+					sw.respec(nil)
+				} else {
+					sw.confirmReset = true
+					sw.statusMessage = "Click again to reset all skills"
+				}
+			} else if sw.confirmReset {
+				sw.confirmReset = false
+				sw.statusMessage = ""
+			}
+		}
 	}
 
 	// Hide tooltip if not hovering over any node
@@ -229,50 +254,51 @@ func (sw *SkillsWidget) learnSkill(skill *components.Skill) {
 		return
 	}
 
-	if sw.skillsComponent.LearnSkill(skill) {
-		// Rebuild UI to reflect changes
-		sw.buildSkillNodeUI()
-
-		// Apply skill effects (this would be handled by a skill system)
-		sw.applySkillEffects(skill)
+	if sw.entity == nil {
+		return
 	}
-}
 
-// applySkillEffects applies the effects of a learned skill to the character
-func (sw *SkillsWidget) applySkillEffects(skill *components.Skill) {
-	if sw.entity == nil {
+	// The skill effect handlers apply stat bonuses, unlocked abilities and passive effects
+	if err := skills.LearnSkill(sw.entity, skill); err != nil {
+		logger.Warn("Failed to learn skill %s: %v", skill.ID, err)
 		return
 	}
 
-	stats := sw.entity.RPGStats()
-	if stats == nil {
+	// Rebuild UI to reflect changes
+	sw.buildSkillNodeUI()
+}
+
+// SetRespecHandler sets the function that pays for and performs skill refunds. Without one the
+// widget offers no refunds, which are only available at job shrines.
+func (sw *SkillsWidget) SetRespecHandler(handler func(entity *ecs.Entity, skill *components.Skill) error) {
+	sw.respecHandler = handler
+}
+
+// respec refunds a leaf skill, or every learned skill when skill is nil
+func (sw *SkillsWidget) respec(skill *components.Skill) {
+	sw.confirmReset = false
+	if sw.skillsComponent == nil || sw.entity == nil || sw.respecHandler == nil {
 		return
 	}
 
-	// Apply each effect
-	for _, effect := range skill.Effects {
-		switch effect.Type {
-		case "stat_bonus":
-			switch effect.Target {
-			case "MaxHP":
-				stats.MaxHP += effect.Value
-				stats.CurrentHP += effect.Value // Also heal current HP
-			case "MaxMP":
-				stats.MaxMP += effect.Value
-				stats.CurrentMP += effect.Value // Also restore current MP
-			case "Attack":
-				stats.Attack += effect.Value
-			case "Defense":
-				stats.Defense += effect.Value
-			case "MagicAttack":
-				// Handle magic attack if implemented
-			}
-		case "ability_unlock":
-			// Active abilities would be handled by combat system
-		case "passive_effect":
-			// Passive effects would be handled by appropriate systems
-		}
+	err := sw.respecHandler(sw.entity, skill)
+	switch {
+	case err != nil:
+		sw.statusMessage = fmt.Sprintf("Cannot refund: %v", err)
+		logger.Warn("Skill refund failed: %v", err)
+	case skill != nil:
+		sw.statusMessage = fmt.Sprintf("Refunded %s (+%d SP)", skill.Name, skill.SkillPoints)
+	default:
+		sw.statusMessage = "All skills reset"
 	}
+
+	// Rebuild UI to reflect changes
+	sw.buildSkillNodeUI()
+}
+
+// resetButtonBounds returns the position and size of the reset button
+func (sw *SkillsWidget) resetButtonBounds() (int, int, int, int) {
+	return sw.X + sw.Width - 150, sw.Y + 10, 140, 20
 }
 
 // Draw renders the skills widget
@@ -295,6 +321,29 @@ func (sw *SkillsWidget) Draw(screen *ebiten.Image) {
 	if sw.skillsComponent != nil {
 		skillPointsText := fmt.Sprintf("Available Skill Points: %d", sw.skillsComponent.AvailablePoints)
 		ebitenutil.DebugPrintAt(screen, skillPointsText, sw.X+10, sw.Y+30)
+		helpText := "Left-click: learn  (refunds at a job shrine)"
+		if sw.respecHandler != nil {
+			helpText = "Left-click: learn  Right-click: refund"
+		}
+		ebitenutil.DebugPrintAt(screen, helpText, sw.X+10, sw.Y+50)
+	}
+
+	// Draw reset button
+	if sw.respecHandler != nil {
+		bx, by, bw, bh := sw.resetButtonBounds()
+		buttonColor := color.RGBA{90, 40, 40, 255}
+		buttonText := "Reset All Skills"
+		if sw.confirmReset {
+			buttonColor = color.RGBA{160, 50, 50, 255}
+			buttonText = "Confirm Reset"
+		}
+		ebitenutil.DrawRect(screen, float64(bx), float64(by), float64(bw), float64(bh), buttonColor)
+		ebitenutil.DebugPrintAt(screen, buttonText, bx+10, by+3)
+	}
+
+	// Draw result of the last refund
+	if sw.statusMessage != "" {
+		ebitenutil.DebugPrintAt(screen, sw.statusMessage, sw.X+10, sw.Y+sw.Height-20)
 	}
 
 	// Draw skill nodes
@@ -414,6 +463,19 @@ func (sw *SkillsWidget) drawLine(screen *ebiten.Image, x1, y1, x2, y2 int, lineC
 func (sw *SkillsWidget) drawTooltip(screen *ebiten.Image, skill *components.Skill, x, y int) {
 	tooltipWidth := 250
 	tooltipHeight := 120
+	if skill.Type == components.SkillTypeActive {
+		tooltipHeight += 30 // Ability costs and cooldown state
+	}
+	refundText := ""
+	if sw.skillsComponent != nil && sw.respecHandler != nil {
+		if _, learned := sw.skillsComponent.LearnedSkills[skill.ID]; learned {
+			refundText = "Right-click to refund"
+			if err := sw.skillsComponent.CanForgetSkill(skill.ID); err != nil {
+				refundText = "Refund: required by other skills"
+			}
+			tooltipHeight += 15
+		}
+	}
 
 	// Draw tooltip background - much more opaque for better text readability
 	tooltipBg := color.RGBA{15, 15, 15, 250}
@@ -442,6 +504,29 @@ func (sw *SkillsWidget) drawTooltip(screen *ebiten.Image, skill *components.Skil
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Tier: %d", skill.Tier), x+5, currentY)
 	currentY += lineHeight
 
+	// Active abilities show their costs and current cooldown state
+	if skill.Type == components.SkillTypeActive {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("MP: %d  AP: %d  Range: %d", skill.MPCost, skill.APCost, skill.Range), x+5, currentY)
+		currentY += lineHeight
+
+		abilityText := fmt.Sprintf("Cooldown: %d", skill.Cooldown)
+		if skill.Charges > 0 {
+			abilityText += fmt.Sprintf("  Charges: %d", skill.Charges)
+		}
+		if sw.skillsComponent != nil {
+			if _, learned := sw.skillsComponent.LearnedSkills[skill.ID]; learned {
+				abilityText += "  " + sw.skillsComponent.GetAbilityStatus(skill)
+			}
+		}
+		ebitenutil.DebugPrintAt(screen, abilityText, x+5, currentY)
+		currentY += lineHeight
+	}
+
+	if refundText != "" {
+		ebitenutil.DebugPrintAt(screen, refundText, x+5, currentY)
+		currentY += lineHeight
+	}
+
 	// Draw description (wrap text if too long)
 	desc := skill.Description
 	if len(desc) > 35 {
@@ -7,15 +7,18 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"math/rand"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/jrecuero/myrpg/internal/constants"
+	"github.com/jrecuero/myrpg/internal/crafting"
 	"github.com/jrecuero/myrpg/internal/ecs"
 	"github.com/jrecuero/myrpg/internal/ecs/components"
 	"github.com/jrecuero/myrpg/internal/logger"
+	"github.com/jrecuero/myrpg/internal/shop"
 )
 
 // Use constants from the constants package
@@ -105,6 +108,8 @@ type UIManager struct {
 	inventory      *InventoryWidget      // Inventory management widget
 	skills         *SkillsWidget         // Skills and abilities widget
 	questJournal   *QuestJournalWidget   // Quest journal widget
+	shop           *ShopWidget           // Shop widget, set while a shop is open
+	crafting       *CraftingWidget       // Crafting widget, set while crafting
 	infoWidget     *InfoWidget           // Event information display widget
 }
 
@@ -160,12 +165,40 @@ const (
 )
 
 // DrawTopPanel renders the player information panel - always shows exploration view
-func (ui *UIManager) DrawTopPanel(screen *ebiten.Image, activePlayer *components.RPGStatsComponent, gameMode GameMode, partyMembers []*components.RPGStatsComponent, gridPosition string) {
+func (ui *UIManager) DrawTopPanel(screen *ebiten.Image, activePlayer *components.RPGStatsComponent, gameMode GameMode, partyMembers []*components.RPGStatsComponent, gridPosition string, wallet *components.WalletComponent) {
 	// Draw background
 	vector.FillRect(screen, 0, 0, constants.BackgroundWidth, TopPanelHeight, TopPanelColor, false)
 
 	// Always use exploration panel - tactical UI is disabled
 	ui.drawExplorationPanel(screen, partyMembers)
+	ui.drawWalletPanel(screen, wallet)
+}
+
+// drawWalletPanel renders the party gold, other currencies and last transaction at the top right
+func (ui *UIManager) drawWalletPanel(screen *ebiten.Image, wallet *components.WalletComponent) {
+	if wallet == nil {
+		return
+	}
+
+	x := constants.BackgroundWidth - 380
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Gold: %d", wallet.Balance(components.CurrencyGold)), x, 40)
+
+	y := 54
+	for _, currency := range wallet.GetCurrencies() {
+		if currency == components.CurrencyGold {
+			continue
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s: %d", currency.DisplayName(), wallet.Balance(currency)), x, y)
+		y += 14
+	}
+
+	if recent := wallet.GetRecentTransactions(1); len(recent) > 0 {
+		last := fmt.Sprintf("Last: %s", recent[0].String())
+		if len(last) > 60 {
+			last = last[:57] + "..."
+		}
+		ebitenutil.DebugPrintAt(screen, last, x, y)
+	}
 }
 
 // drawExplorationPanel renders the exploration mode UI
@@ -331,6 +364,22 @@ func (ui *UIManager) Update() InputResult {
 			ui.questJournal = nil
 		}
 	}
+	if ui.shop != nil {
+		shopResult := ui.shop.Update()
+		result.Combine(shopResult)
+		// Check if shop was closed
+		if !ui.shop.Visible {
+			ui.shop = nil
+		}
+	}
+	if ui.crafting != nil {
+		craftingResult := ui.crafting.Update()
+		result.Combine(craftingResult)
+		// Check if crafting was closed
+		if !ui.crafting.Visible {
+			ui.crafting = nil
+		}
+	}
 	if ui.infoWidget != nil {
 		infoResult := ui.infoWidget.Update()
 		result.Combine(infoResult)
@@ -365,6 +414,12 @@ func (ui *UIManager) DrawPopups(screen *ebiten.Image) {
 	if ui.questJournal != nil {
 		ui.questJournal.Draw(screen)
 	}
+	if ui.shop != nil {
+		ui.shop.Draw(screen)
+	}
+	if ui.crafting != nil {
+		ui.crafting.Draw(screen)
+	}
 	if ui.infoWidget != nil {
 		ui.infoWidget.Draw(screen)
 	}
@@ -542,6 +597,15 @@ func (ui *UIManager) ShowSkills(entity *ecs.Entity) error {
 	return nil
 }
 
+// ShowSkillRefunds shows the skills widget with skill refunds paid through the given handler
+func (ui *UIManager) ShowSkillRefunds(entity *ecs.Entity, handler func(entity *ecs.Entity, skill *components.Skill) error) error {
+	if err := ui.ShowSkills(entity); err != nil {
+		return err
+	}
+	ui.skills.SetRespecHandler(handler)
+	return nil
+}
+
 // HideSkills closes the skills widget
 func (ui *UIManager) HideSkills() {
 	if ui.skills != nil {
@@ -609,6 +673,64 @@ func (ui *UIManager) ToggleQuestJournal(entity *ecs.Entity) error {
 	return ui.ShowQuestJournal(entity)
 }
 
+// ShowShop opens a shop for a customer paying from the party wallet
+func (ui *UIManager) ShowShop(s *shop.Shop, customer *ecs.Entity, wallet shop.Wallet) error {
+	if s == nil || customer == nil {
+		return fmt.Errorf("shop or customer is nil")
+	}
+
+	shopX := (ScreenWidth - 760) / 2  // Center horizontally
+	shopY := (ScreenHeight - 460) / 2 // Center vertically
+	ui.shop = NewShopWidget(shopX, shopY, 760, 460, s, customer, wallet)
+	return nil
+}
+
+// HideShop closes the shop widget
+func (ui *UIManager) HideShop() {
+	if ui.shop != nil {
+		ui.shop.Visible = false
+	}
+}
+
+// IsShopVisible returns true if a shop is open
+func (ui *UIManager) IsShopVisible() bool {
+	return ui.shop != nil && ui.shop.Visible
+}
+
+// ShowCrafting opens the crafting widget for a crafter paying from the party wallet
+func (ui *UIManager) ShowCrafting(registry *crafting.CraftingRegistry, crafter *ecs.Entity, wallet crafting.Wallet, rng *rand.Rand) error {
+	if registry == nil || crafter == nil {
+		return fmt.Errorf("crafting registry or crafter is nil")
+	}
+	if crafter.Inventory() == nil {
+		return fmt.Errorf("crafter has no inventory")
+	}
+
+	craftingX := (ScreenWidth - 760) / 2  // Center horizontally
+	craftingY := (ScreenHeight - 460) / 2 // Center vertically
+	ui.crafting = NewCraftingWidget(craftingX, craftingY, 760, 460, registry, crafter, wallet, rng)
+	return nil
+}
+
+// HideCrafting closes the crafting widget
+func (ui *UIManager) HideCrafting() {
+	if ui.crafting != nil {
+		ui.crafting.Visible = false
+	}
+}
+
+// IsCraftingVisible returns true if the crafting widget is open
+func (ui *UIManager) IsCraftingVisible() bool {
+	return ui.crafting != nil && ui.crafting.Visible
+}
+
+// SetDialogActionHandler sets the function called for every action a dialog executes
+func (ui *UIManager) SetDialogActionHandler(handler func(action Action)) {
+	if ui.dialog != nil {
+		ui.dialog.OnActionExecute = handler
+	}
+}
+
 // IsInventoryVisible returns true if inventory widget is visible
 func (ui *UIManager) IsInventoryVisible() bool {
 	return ui.inventory != nil && ui.inventory.IsOpen()
@@ -623,7 +745,9 @@ func (ui *UIManager) IsPopupVisible() bool {
 	dialogVisible := ui.dialog != nil && ui.dialog.IsVisible()
 	inventoryVisible := ui.inventory != nil && ui.inventory.IsOpen()
 	infoWidgetVisible := ui.infoWidget != nil && ui.infoWidget.IsVisible()
-	return selectionVisible || infoVisible || statsVisible || equipmentVisible || dialogVisible || inventoryVisible || infoWidgetVisible
+	shopVisible := ui.IsShopVisible()
+	craftingVisible := ui.IsCraftingVisible()
+	return selectionVisible || infoVisible || statsVisible || equipmentVisible || dialogVisible || inventoryVisible || infoWidgetVisible || shopVisible || craftingVisible
 }
 
 // ShowInfoWidget displays the info widget with the specified content